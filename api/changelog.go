@@ -35,6 +35,10 @@ type GetFullChangelogParams struct {
 	ChangelogID string
 	Page        int
 	PageSize    int
+	// Only returns the articles with these labels.
+	Labels []string
+	// Requires the articles to have all Labels instead of at least one.
+	MatchAllLabels bool
 }
 
 func (c *Client) GetFullChangelog(ctx context.Context, args GetFullChangelogParams) (FullChangelog, error) {
@@ -45,6 +49,12 @@ func (c *Client) GetFullChangelog(ctx context.Context, args GetFullChangelogPara
 	if args.PageSize != 0 {
 		q.Set("page-size", fmt.Sprint(args.PageSize))
 	}
+	for _, l := range args.Labels {
+		q.Add("label", l)
+	}
+	if args.MatchAllLabels {
+		q.Set("label-mode", "all")
+	}
 
 	url := fmt.Sprintf("/changelogs/%s/full?%s", args.ChangelogID, q.Encode())
 
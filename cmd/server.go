@@ -1,6 +1,7 @@
 package main
 
 import (
+	"encoding/hex"
 	"errors"
 	"flag"
 	"fmt"
@@ -8,6 +9,7 @@ import (
 	"log/slog"
 	"net/http"
 	"os"
+	"time"
 
 	"github.com/btvoidx/mint"
 	"github.com/jonashiltl/openchangelog/internal/config"
@@ -58,7 +60,7 @@ func main() {
 	parser := parse.NewParser(parse.CreateGoldmark())
 	loader := load.NewLoader(cfg, st, cache, parser, e)
 	renderer := web.NewRenderer(cfg)
-	listener := events.NewListener(cfg, e, parser, searcher, cache)
+	listener := events.NewListener(cfg, e, st, parser, searcher, cache)
 	listener.Start()
 	defer listener.Close()
 
@@ -81,7 +83,21 @@ func parseConfig() (config.Config, error) {
 func createStore(cfg config.Config) (store.Store, error) {
 	if cfg.IsDBMode() {
 		slog.Info("Starting Openchangelog backed by sqlite")
-		return store.NewSQLiteStore(cfg.SqliteURL)
+		var encryptionKey []byte
+		if cfg.EncryptionKey != "" {
+			key, err := hex.DecodeString(cfg.EncryptionKey)
+			if err != nil {
+				return nil, fmt.Errorf("invalid encryption key: %w", err)
+			}
+			encryptionKey = key
+		}
+		return store.NewSQLiteStore(cfg.SqliteURL, store.SQLiteOptions{
+			SubdomainCacheTTL: time.Minute,
+			EncryptionKey:     encryptionKey,
+			CNAMETarget:       cfg.CNAMETarget,
+			BaseURL:           cfg.BaseURL,
+			TokenTTL:          cfg.TokenTTL,
+		})
 	} else {
 		slog.Info("Starting Openchangelog in config mode")
 		return store.NewConfigStore(cfg), nil
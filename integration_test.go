@@ -131,7 +131,7 @@ func NewTestApp(t *testing.T, cfg config.Config, tempDir string) *TestApp {
 
 		runMigrations(t, dbPath)
 
-		st, err = store.NewSQLiteStore(connStr)
+		st, err = store.NewSQLiteStore(connStr, store.SQLiteOptions{})
 		if err != nil {
 			os.RemoveAll(tempDir)
 			t.Fatalf("Failed to create SQLite store: %v", err)
@@ -148,7 +148,7 @@ func NewTestApp(t *testing.T, cfg config.Config, tempDir string) *TestApp {
 	loader := load.NewLoader(cfg, st, cache, parser, e)
 	renderer := web.NewRenderer(cfg)
 
-	listener := events.NewListener(cfg, e, parser, searcher, cache)
+	listener := events.NewListener(cfg, e, st, parser, searcher, cache)
 	listener.Start()
 
 	rest.RegisterRestHandler(mux, rest.NewEnv(st, loader, parser, e))
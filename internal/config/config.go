@@ -3,6 +3,7 @@ package config
 import (
 	"log/slog"
 	"strings"
+	"time"
 
 	"github.com/spf13/viper"
 )
@@ -122,6 +123,14 @@ type Config struct {
 	Admin     *AdminConfig     `mapstructure:"admin"`
 	Log       *LogConfig       `mapstructure:"log"`
 	Search    *SearchConfig    `mapstructure:"search"`
+	// Hex encoded 32 byte key, used to encrypt secrets stored in sqlite.
+	EncryptionKey string `mapstructure:"encryptionKey"`
+	// Host the custom domains of changelogs must point to with a CNAME record.
+	CNAMETarget string `mapstructure:"cnameTarget"`
+	// Url the subdomains of changelogs are served under, e.g. https://openchangelog.com
+	BaseURL string `mapstructure:"baseUrl"`
+	// How long new workspace tokens are valid, e.g. 8760h. Tokens never expire if it's not set.
+	TokenTTL time.Duration `mapstructure:"tokenTtl"`
 }
 
 func (c Config) HasGithubAuth() bool {
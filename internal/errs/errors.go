@@ -11,6 +11,7 @@ var (
 	ErrNotFound           = errors.New("not found")
 	ErrUnauthorized       = errors.New("unauthorized")
 	ErrServiceUnavailable = errors.New("service unavailable")
+	ErrInternalError      = errors.New("internal error")
 )
 
 type Error struct {
@@ -30,6 +31,8 @@ func (e Error) Status() int {
 		return http.StatusUnauthorized
 	case ErrServiceUnavailable:
 		return http.StatusServiceUnavailable
+	case ErrInternalError:
+		return http.StatusInternalServerError
 	}
 
 	return http.StatusInternalServerError
@@ -74,6 +77,13 @@ func NewServiceUnavailable(wrapped error) error {
 	}
 }
 
+func NewInternalError(wrapped error) error {
+	return Error{
+		appErr:    wrapped,
+		domainErr: ErrInternalError,
+	}
+}
+
 func (e Error) AppErr() error {
 	return e.appErr
 }
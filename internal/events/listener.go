@@ -1,7 +1,12 @@
 package events
 
 import (
+	"bytes"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
 	"log/slog"
 
 	"github.com/btvoidx/mint"
@@ -10,6 +15,7 @@ import (
 	"github.com/jonashiltl/openchangelog/internal/parse"
 	"github.com/jonashiltl/openchangelog/internal/search"
 	"github.com/jonashiltl/openchangelog/internal/source"
+	"github.com/jonashiltl/openchangelog/internal/store"
 	"github.com/jonashiltl/openchangelog/internal/xcache"
 	"github.com/jonashiltl/openchangelog/internal/xlog"
 )
@@ -18,6 +24,7 @@ type offFunc func() <-chan struct{}
 
 type EventListener struct {
 	e        *mint.Emitter
+	store    store.Store
 	parser   parse.Parser
 	searcher search.Searcher
 	cache    xcache.Cache
@@ -28,12 +35,14 @@ type EventListener struct {
 func NewListener(
 	cfg config.Config,
 	e *mint.Emitter,
+	store store.Store,
 	parser parse.Parser,
 	searcher search.Searcher,
 	cache xcache.Cache,
 ) *EventListener {
 	return &EventListener{
 		e:        e,
+		store:    store,
 		parser:   parser,
 		searcher: searcher,
 		cfg:      cfg,
@@ -59,21 +68,22 @@ func (l *EventListener) Close() {
 func (l *EventListener) OnSourceChanged(e SourceContentChanged) {
 	slog.Debug("source content changed event", slog.String("sid", e.Source.ID().String()))
 	if e.CL.Searchable {
-		go l.reindexSource(e.Source)
+		go l.reindexSource(e.CL, e.Source, false)
 	}
 }
 
 func (l *EventListener) OnChangelogUpdated(e ChangelogUpdated) {
 	slog.Debug("changelog updated event", slog.String("cid", e.CL.ID.String()))
 	if e.Args.Searchable != nil && *e.Args.Searchable {
-		souce, err := source.NewSourceFromStore(l.cfg, e.CL, l.cache)
+		souce, err := source.NewSourceFromStore(l.cfg, e.CL, l.cache, nil)
 		if err == nil {
-			go l.reindexSource(souce)
+			// the index might have been removed, so reindex even if the content didn't change
+			go l.reindexSource(e.CL, souce, true)
 		} else {
 			slog.Error("failed to create source", xlog.ErrAttr(err))
 		}
 	} else if e.Args.Searchable != nil && !*e.Args.Searchable {
-		souce, err := source.NewSourceFromStore(l.cfg, e.CL, l.cache)
+		souce, err := source.NewSourceFromStore(l.cfg, e.CL, l.cache, nil)
 		if err == nil {
 			go l.removeIndex(souce)
 		} else {
@@ -82,18 +92,35 @@ func (l *EventListener) OnChangelogUpdated(e ChangelogUpdated) {
 	}
 }
 
-func (l *EventListener) reindexSource(source source.Source) {
+// Reindexes the content of the source, unless force is false and the content
+// hash of the changelog shows that it didn't change since the last reindex.
+func (l *EventListener) reindexSource(cl store.Changelog, source source.Source, force bool) {
 	if source == nil {
 		return
 	}
 
-	slog.Debug("reindexing content of source", slog.String("sid", source.ID().String()))
 	ctx := context.Background()
 	loaded, err := source.Load(ctx, internal.NoPagination())
 	if err != nil {
 		slog.Error("failed to load source content for search indexing", xlog.ErrAttr(err))
 		return
 	}
+	hash, err := hashContent(loaded.Raw)
+	if err != nil {
+		slog.Error("failed to hash source content", xlog.ErrAttr(err))
+		return
+	}
+	if !force {
+		prev, err := l.store.GetContentHash(ctx, cl.WorkspaceID, cl.ID)
+		if err != nil {
+			slog.Error("failed to get content hash", xlog.ErrAttr(err))
+		} else if prev == hash {
+			slog.Debug("content of source didn't change, skipping reindex", slog.String("sid", source.ID().String()))
+			return
+		}
+	}
+
+	slog.Debug("reindexing content of source", slog.String("sid", source.ID().String()))
 	parsed := l.parser.Parse(ctx, loaded.Raw, internal.NoPagination())
 	err = l.searcher.BatchIndex(ctx, search.BatchIndexArgs{
 		SID:          source.ID().String(),
@@ -103,6 +130,27 @@ func (l *EventListener) reindexSource(source source.Source) {
 		slog.Error("failed to index parsed release notes", xlog.ErrAttr(err))
 		return
 	}
+	err = l.store.SetContentHash(ctx, cl.WorkspaceID, cl.ID, hash)
+	if err != nil {
+		slog.Error("failed to store content hash", xlog.ErrAttr(err))
+	}
+}
+
+// Returns the hex encoded SHA-256 hash of the content of all notes.
+// The content is read into memory, so the notes can still be parsed afterwards.
+func hashContent(notes []source.RawReleaseNote) (string, error) {
+	h := sha256.New()
+	for i, note := range notes {
+		b, err := io.ReadAll(note.Content)
+		if err != nil {
+			return "", err
+		}
+		// prefix the length, so moving content between notes changes the hash
+		fmt.Fprintf(h, "%d:", len(b))
+		h.Write(b)
+		notes[i].Content = bytes.NewReader(b)
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
 }
 
 func (l *EventListener) removeIndex(source source.Source) {
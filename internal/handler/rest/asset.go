@@ -0,0 +1,67 @@
+package rest
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+
+	"github.com/jonashiltl/openchangelog/internal/errs"
+	"github.com/jonashiltl/openchangelog/internal/store"
+)
+
+const (
+	asset_id_param = "aid"
+)
+
+// Serves the raw content of a changelog asset, e.g. the favicon.
+// Assets are public, since they are referenced by the public changelog page.
+func getAsset(e *env, w http.ResponseWriter, r *http.Request) error {
+	a, err := e.store.GetChangelogAsset(r.Context(), r.PathValue(asset_id_param))
+	if err != nil {
+		return err
+	}
+
+	w.Header().Set("Content-Type", a.ContentType)
+	w.Header().Set("Cache-Control", "public, max-age=86400")
+	// assets are user supplied, make sure browsers never render them as anything but an image
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.Header().Set("Content-Security-Policy", "default-src 'none'")
+	_, err = w.Write(a.Data)
+	return err
+}
+
+var errNoOGImage = errs.NewError(errs.ErrNotFound, errors.New("og image not found"))
+
+// Serves the generated open graph image of a changelog.
+// The image is public, unless the changelog is password protected.
+// Responds with 304 Not Modified if the client's If-None-Match header matches the ETag of the image.
+func getOGImage(e *env, w http.ResponseWriter, r *http.Request) error {
+	wID, err := store.ParseWID(r.PathValue(workspace_id_param))
+	if err != nil {
+		return err
+	}
+	cID, err := store.ParseCID(r.PathValue(changelog_id_param))
+	if err != nil {
+		return err
+	}
+
+	cl, err := e.store.GetChangelog(r.Context(), wID, cID)
+	if err != nil {
+		return err
+	}
+	// don't leak the content of protected changelogs
+	if cl.Protected {
+		return errNoOGImage
+	}
+
+	img, etag, generatedAt, err := e.store.GetChangelogOGImage(r.Context(), wID, cID)
+	if err != nil {
+		return err
+	}
+
+	w.Header().Set("ETag", `"`+etag+`"`)
+	w.Header().Set("Cache-Control", "public, max-age=3600")
+	// handles If-None-Match, If-Modified-Since and sets Last-Modified
+	http.ServeContent(w, r, "", generatedAt, bytes.NewReader(img))
+	return nil
+}
@@ -2,7 +2,9 @@ package rest
 
 import (
 	"errors"
+	"fmt"
 	"net/http"
+	"slices"
 	"strings"
 
 	"github.com/jonashiltl/openchangelog/internal/errs"
@@ -15,7 +17,8 @@ type Token struct {
 	WorkspaceID store.WorkspaceID
 }
 
-func bearerAuth(e *env, r *http.Request) (Token, error) {
+// Authenticates the request by its bearer token, the token needs to be granted all of the given scopes.
+func bearerAuth(e *env, r *http.Request, scopes ...string) (Token, error) {
 	h := r.Header.Get("Authorization")
 	if h == "" {
 		return Token{}, errs.NewError(errs.ErrUnauthorized, errors.New("missing authorization header"))
@@ -31,6 +34,19 @@ func bearerAuth(e *env, r *http.Request) (Token, error) {
 		return Token{}, err
 	}
 	xlog.AddWorkspaceID(r, id.String())
+
+	if len(scopes) > 0 {
+		granted, err := e.store.GetTokenScopes(r.Context(), key)
+		if err != nil {
+			return Token{}, err
+		}
+		for _, s := range scopes {
+			if !slices.Contains(granted, s) {
+				return Token{}, errs.NewError(errs.ErrUnauthorized, fmt.Errorf("token is missing the %s scope", s))
+			}
+		}
+	}
+
 	return Token{
 		Key:         key,
 		WorkspaceID: id,
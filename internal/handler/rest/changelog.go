@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
 
 	"github.com/btvoidx/mint"
 	"github.com/jonashiltl/openchangelog/apitypes"
@@ -13,6 +14,7 @@ import (
 	"github.com/jonashiltl/openchangelog/internal/errs"
 	"github.com/jonashiltl/openchangelog/internal/events"
 	"github.com/jonashiltl/openchangelog/internal/handler"
+	"github.com/jonashiltl/openchangelog/internal/parse"
 	"github.com/jonashiltl/openchangelog/internal/store"
 	"golang.org/x/crypto/bcrypt"
 )
@@ -58,7 +60,7 @@ func encodeChangelog(w http.ResponseWriter, cl store.Changelog) error {
 }
 
 func createChangelog(e *env, w http.ResponseWriter, r *http.Request) error {
-	t, err := bearerAuth(e, r)
+	t, err := bearerAuth(e, r, store.ScopeChangelogWrite)
 	if err != nil {
 		return err
 	}
@@ -119,7 +121,7 @@ func createChangelog(e *env, w http.ResponseWriter, r *http.Request) error {
 }
 
 func updateChangelog(e *env, w http.ResponseWriter, r *http.Request) error {
-	t, err := bearerAuth(e, r)
+	t, err := bearerAuth(e, r, store.ScopeChangelogWrite)
 	if err != nil {
 		return err
 	}
@@ -180,7 +182,7 @@ func updateChangelog(e *env, w http.ResponseWriter, r *http.Request) error {
 }
 
 func setChangelogSource(e *env, w http.ResponseWriter, r *http.Request) error {
-	t, err := bearerAuth(e, r)
+	t, err := bearerAuth(e, r, store.ScopeChangelogWrite)
 	if err != nil {
 		return err
 	}
@@ -212,7 +214,7 @@ func setChangelogSource(e *env, w http.ResponseWriter, r *http.Request) error {
 }
 
 func deleteChangelogSource(e *env, _ http.ResponseWriter, r *http.Request) error {
-	t, err := bearerAuth(e, r)
+	t, err := bearerAuth(e, r, store.ScopeChangelogWrite)
 	if err != nil {
 		return err
 	}
@@ -226,7 +228,7 @@ func deleteChangelogSource(e *env, _ http.ResponseWriter, r *http.Request) error
 }
 
 func getChangelog(e *env, w http.ResponseWriter, r *http.Request) error {
-	t, err := bearerAuth(e, r)
+	t, err := bearerAuth(e, r, store.ScopeChangelogRead)
 	if err != nil {
 		return err
 	}
@@ -244,7 +246,7 @@ func getChangelog(e *env, w http.ResponseWriter, r *http.Request) error {
 }
 
 func getFullChangelog(e *env, w http.ResponseWriter, r *http.Request) error {
-	t, err := bearerAuth(e, r)
+	t, err := bearerAuth(e, r, store.ScopeChangelogRead, store.ScopeEntryRead)
 	if err != nil {
 		return err
 	}
@@ -266,8 +268,27 @@ func getFullChangelog(e *env, w http.ResponseWriter, r *http.Request) error {
 	page, pageSize := handler.ParsePagination(r.URL.Query())
 	pagination := internal.NewPagination(pageSize, page)
 
-	loaded, err := e.loader.LoadAndParseReleaseNotes(r.Context(), cl, pagination)
+	labels, mode, err := parseLabelFilter(r.URL.Query())
+	if err != nil {
+		return err
+	}
+
+	toLoad := pagination
+	var entryIDs []string
+	if len(labels) > 0 {
+		entryIDs, err = e.store.ListEntryIDsByLabels(r.Context(), t.WorkspaceID, cID, labels, mode)
+		if err != nil {
+			return err
+		}
+		// labels aren't stored in the source, so all release notes are loaded and paginated after filtering
+		toLoad = internal.NoPagination()
+	}
+
+	loaded, err := e.loader.LoadAndParseReleaseNotes(r.Context(), cl, toLoad)
 	if err == nil {
+		if len(labels) > 0 {
+			loaded.Notes, loaded.HasMore = filterNotesByIDs(loaded.Notes, entryIDs, pagination)
+		}
 		articles := make([]apitypes.Article, len(loaded.Notes))
 		for i, a := range loaded.Notes {
 			content, _ := io.ReadAll(a.Content)
@@ -288,8 +309,44 @@ func getFullChangelog(e *env, w http.ResponseWriter, r *http.Request) error {
 	return json.NewEncoder(w).Encode(res)
 }
 
+// Parses the repeated label query param and the label-mode, which is either any (default) or all.
+func parseLabelFilter(q url.Values) ([]string, store.LabelMatchMode, error) {
+	labels := q["label"]
+	switch q.Get("label-mode") {
+	case "", "any":
+		return labels, store.AnyOf, nil
+	case "all":
+		return labels, store.AllOf, nil
+	default:
+		return nil, store.AnyOf, errs.NewBadRequest(errors.New("label-mode must be any or all"))
+	}
+}
+
+// Keeps the release notes with one of the ids and returns the requested page of them,
+// together with whether more notes follow.
+func filterNotesByIDs(notes []parse.ParsedReleaseNote, ids []string, page internal.Pagination) ([]parse.ParsedReleaseNote, bool) {
+	keep := make(map[string]struct{}, len(ids))
+	for _, id := range ids {
+		keep[id] = struct{}{}
+	}
+
+	filtered := make([]parse.ParsedReleaseNote, 0, len(ids))
+	for _, n := range notes {
+		if _, ok := keep[n.Meta.ID]; ok {
+			filtered = append(filtered, n)
+		}
+	}
+
+	start := max(page.StartIdx(), 0)
+	if start >= len(filtered) {
+		return []parse.ParsedReleaseNote{}, false
+	}
+	end := min(page.EndIdx()+1, len(filtered))
+	return filtered[start:end], end < len(filtered)
+}
+
 func listChangelogs(e *env, w http.ResponseWriter, r *http.Request) error {
-	t, err := bearerAuth(e, r)
+	t, err := bearerAuth(e, r, store.ScopeChangelogRead)
 	if err != nil {
 		return err
 	}
@@ -308,7 +365,7 @@ func listChangelogs(e *env, w http.ResponseWriter, r *http.Request) error {
 }
 
 func deleteChangelog(e *env, w http.ResponseWriter, r *http.Request) error {
-	t, err := bearerAuth(e, r)
+	t, err := bearerAuth(e, r, store.ScopeChangelogWrite)
 	if err != nil {
 		return err
 	}
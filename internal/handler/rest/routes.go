@@ -29,6 +29,7 @@ func RegisterRestHandler(mux *http.ServeMux, e *env) {
 	mux.HandleFunc("GET /api/sources/gh", serveHTTP(e, listGHSources))
 	mux.HandleFunc("GET /api/sources/gh/{id}", serveHTTP(e, getGHSource))
 	mux.HandleFunc("DELETE /api/sources/gh/{id}", serveHTTP(e, deleteGHSources))
+	mux.HandleFunc("POST /api/workspaces/{wid}/sources/gh/{id}/webhook", serveHTTP(e, ghSourceWebhook))
 
 	// changelog
 	mux.HandleFunc("POST /api/changelogs", serveHTTP(e, createChangelog))
@@ -39,6 +40,10 @@ func RegisterRestHandler(mux *http.ServeMux, e *env) {
 	mux.HandleFunc("DELETE /api/changelogs/{cid}", serveHTTP(e, deleteChangelog))
 	mux.HandleFunc("PUT /api/changelogs/{cid}/source/{sid}", serveHTTP(e, setChangelogSource))
 	mux.HandleFunc("DELETE /api/changelogs/{cid}/source", serveHTTP(e, deleteChangelogSource))
+
+	// assets
+	mux.HandleFunc("GET /assets/{aid}", serveHTTP(e, getAsset))
+	mux.HandleFunc("GET /og-images/{wid}/{cid}", serveHTTP(e, getOGImage))
 }
 
 func NewEnv(store store.Store, loader *load.Loader, parser parse.Parser, e *mint.Emitter) *env {
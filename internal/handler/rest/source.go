@@ -1,20 +1,28 @@
 package rest
 
 import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"errors"
+	"io"
 	"log/slog"
 	"net/http"
+	"strings"
 
 	"github.com/guregu/null/v5"
 	"github.com/jonashiltl/openchangelog/apitypes"
 	"github.com/jonashiltl/openchangelog/internal"
+	"github.com/jonashiltl/openchangelog/internal/errs"
 	"github.com/jonashiltl/openchangelog/internal/store"
 	"github.com/jonashiltl/openchangelog/internal/xlog"
 )
 
 const (
 	ghsource_id_param = "id"
+	// GitHub caps webhook payloads at 25MB
+	max_gh_webhook_payload = 25 << 20
 )
 
 func ghToApiType(gh store.GHSource) apitypes.GHSource {
@@ -34,7 +42,7 @@ func encodeGHSource(w http.ResponseWriter, gh store.GHSource) error {
 }
 
 func listSources(e *env, w http.ResponseWriter, r *http.Request) error {
-	t, err := bearerAuth(e, r)
+	t, err := bearerAuth(e, r, store.ScopeChangelogRead)
 	if err != nil {
 		return err
 	}
@@ -55,7 +63,7 @@ func listSources(e *env, w http.ResponseWriter, r *http.Request) error {
 }
 
 func createGHSource(e *env, w http.ResponseWriter, r *http.Request) error {
-	t, err := bearerAuth(e, r)
+	t, err := bearerAuth(e, r, store.ScopeChangelogWrite)
 	if err != nil {
 		return err
 	}
@@ -97,7 +105,7 @@ func createGHSource(e *env, w http.ResponseWriter, r *http.Request) error {
 }
 
 func getGHSource(e *env, w http.ResponseWriter, r *http.Request) error {
-	t, err := bearerAuth(e, r)
+	t, err := bearerAuth(e, r, store.ScopeChangelogRead)
 	if err != nil {
 		return err
 	}
@@ -115,7 +123,7 @@ func getGHSource(e *env, w http.ResponseWriter, r *http.Request) error {
 }
 
 func listGHSources(e *env, w http.ResponseWriter, r *http.Request) error {
-	t, err := bearerAuth(e, r)
+	t, err := bearerAuth(e, r, store.ScopeChangelogRead)
 	if err != nil {
 		return err
 	}
@@ -134,7 +142,7 @@ func listGHSources(e *env, w http.ResponseWriter, r *http.Request) error {
 }
 
 func deleteGHSources(e *env, w http.ResponseWriter, r *http.Request) error {
-	t, err := bearerAuth(e, r)
+	t, err := bearerAuth(e, r, store.ScopeChangelogWrite)
 	if err != nil {
 		return err
 	}
@@ -150,3 +158,83 @@ func deleteGHSources(e *env, w http.ResponseWriter, r *http.Request) error {
 	}
 	return nil
 }
+
+type ghPushPayload struct {
+	Commits []struct {
+		Added    []string `json:"added"`
+		Removed  []string `json:"removed"`
+		Modified []string `json:"modified"`
+	} `json:"commits"`
+}
+
+// Receives the events of the repository webhook of the source.
+// The request isn't authenticated by a token, instead its payload is signed with the webhook secret of the source.
+func ghSourceWebhook(e *env, w http.ResponseWriter, r *http.Request) error {
+	wID, err := store.ParseWID(r.PathValue(workspace_id_param))
+	if err != nil {
+		return err
+	}
+	ghID, err := store.ParseGHID(r.PathValue(ghsource_id_param))
+	if err != nil {
+		return err
+	}
+
+	payload, err := io.ReadAll(io.LimitReader(r.Body, max_gh_webhook_payload))
+	if err != nil {
+		return err
+	}
+
+	secret, err := e.store.GetGHSourceWebhookSecret(r.Context(), wID, ghID)
+	if err != nil {
+		return err
+	}
+	err = validateGHSignature(secret, payload, r.Header.Get("X-Hub-Signature-256"))
+	if err != nil {
+		return err
+	}
+
+	switch r.Header.Get("X-GitHub-Event") {
+	case "push":
+		var push ghPushPayload
+		err = json.Unmarshal(payload, &push)
+		if err != nil {
+			return errs.NewBadRequest(err)
+		}
+
+		var added, modified, removed []string
+		for _, c := range push.Commits {
+			added = append(added, c.Added...)
+			modified = append(modified, c.Modified...)
+			removed = append(removed, c.Removed...)
+		}
+		// the changed files are fetched again on the next sync of the source
+		_, err = e.store.ProcessGHPushPayload(r.Context(), wID, ghID, added, modified, removed)
+		if err != nil {
+			return err
+		}
+	default:
+		// other events, like the initial ping, are acknowledged without processing them
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+	return nil
+}
+
+// Validates the X-Hub-Signature-256 header, the hex encoded HMAC-SHA256 of the payload prefixed by sha256=.
+func validateGHSignature(secret string, payload []byte, header string) error {
+	sig, ok := strings.CutPrefix(header, "sha256=")
+	if !ok {
+		return errs.NewError(errs.ErrUnauthorized, errors.New("missing webhook signature"))
+	}
+	got, err := hex.DecodeString(sig)
+	if err != nil {
+		return errs.NewError(errs.ErrUnauthorized, errors.New("invalid webhook signature"))
+	}
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(payload)
+	if !hmac.Equal(got, mac.Sum(nil)) {
+		return errs.NewError(errs.ErrUnauthorized, errors.New("invalid webhook signature"))
+	}
+	return nil
+}
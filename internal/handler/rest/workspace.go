@@ -43,7 +43,7 @@ func createWorkspace(e *env, w http.ResponseWriter, r *http.Request) error {
 }
 
 func updateWorkspace(e *env, w http.ResponseWriter, r *http.Request) error {
-	t, err := bearerAuth(e, r)
+	t, err := bearerAuth(e, r, store.AllTokenScopes...)
 	if err != nil {
 		return err
 	}
@@ -107,7 +107,7 @@ func getMyWorkspace(e *env, w http.ResponseWriter, r *http.Request) error {
 }
 
 func deleteWorkspace(e *env, w http.ResponseWriter, r *http.Request) error {
-	t, err := bearerAuth(e, r)
+	t, err := bearerAuth(e, r, store.AllTokenScopes...)
 	if err != nil {
 		return err
 	}
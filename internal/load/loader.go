@@ -76,7 +76,7 @@ func (l *Loader) LoadAndParse(r *http.Request, page internal.Pagination) (Loaded
 
 // Loads and parses the release notes for the specified changelog.
 func (l *Loader) LoadAndParseReleaseNotes(ctx context.Context, cl store.Changelog, page internal.Pagination) (LoadedChangelog, error) {
-	s, err := source.NewSourceFromStore(l.cfg, cl, l.cache)
+	s, err := source.NewSourceFromStore(l.cfg, cl, l.cache, l.store)
 	if err != nil {
 		return LoadedChangelog{}, err
 	}
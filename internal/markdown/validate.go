@@ -0,0 +1,125 @@
+package markdown
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/jonashiltl/openchangelog/internal/errs"
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/text"
+	"golang.org/x/net/html"
+)
+
+// Maximum size of a markdown body in bytes.
+const MaxBodySize = 1 << 20
+
+// Elements that never have a closing tag.
+var voidElements = map[string]bool{
+	"area": true, "base": true, "br": true, "col": true, "embed": true,
+	"hr": true, "img": true, "input": true, "link": true, "meta": true,
+	"source": true, "track": true, "wbr": true,
+}
+
+// Elements whose closing tag may be omitted, they are closed implicitly by their parent or the end of the document.
+var optionalEndElements = map[string]bool{
+	"p": true, "li": true, "dt": true, "dd": true, "rt": true, "rp": true,
+	"optgroup": true, "option": true, "colgroup": true, "caption": true,
+	"thead": true, "tbody": true, "tfoot": true, "tr": true, "td": true, "th": true,
+}
+
+// Validates body before it is persisted.
+// Returns a bad request error if body exceeds MaxBodySize or contains unbalanced html tags,
+// which would break the layout of the rendered page.
+func ValidateMarkdown(body string) error {
+	if len(body) > MaxBodySize {
+		return errs.NewBadRequest(fmt.Errorf("markdown body exceeds the maximum size of %d bytes", MaxBodySize))
+	}
+
+	err := validateHTML(body)
+	if err != nil {
+		return errs.NewBadRequest(err)
+	}
+	return nil
+}
+
+// Parses body and checks that all raw html in the document is balanced.
+func validateHTML(body string) error {
+	src := []byte(body)
+	doc := goldmark.New().Parser().Parse(text.NewReader(src))
+
+	var raw strings.Builder
+	err := ast.Walk(doc, func(n ast.Node, entering bool) (ast.WalkStatus, error) {
+		if !entering {
+			return ast.WalkContinue, nil
+		}
+		switch node := n.(type) {
+		case *ast.HTMLBlock:
+			lines := node.Lines()
+			for i := 0; i < lines.Len(); i++ {
+				seg := lines.At(i)
+				raw.Write(seg.Value(src))
+			}
+			if node.HasClosure() {
+				raw.Write(node.ClosureLine.Value(src))
+			}
+		case *ast.RawHTML:
+			for i := 0; i < node.Segments.Len(); i++ {
+				seg := node.Segments.At(i)
+				raw.Write(seg.Value(src))
+			}
+		}
+		return ast.WalkContinue, nil
+	})
+	if err != nil {
+		return err
+	}
+
+	return checkBalanced(raw.String())
+}
+
+// Tokenizes the html and makes sure every opened tag is closed in the right order.
+// Void elements and the omitted closing tags of optionalEndElements are allowed.
+func checkBalanced(s string) error {
+	var open []string
+	// closes the open elements with an optional closing tag, until an element which requires one is on top
+	closeOptional := func() {
+		for len(open) > 0 && optionalEndElements[open[len(open)-1]] {
+			open = open[:len(open)-1]
+		}
+	}
+
+	z := html.NewTokenizer(strings.NewReader(s))
+	for {
+		switch z.Next() {
+		case html.ErrorToken:
+			if !errors.Is(z.Err(), io.EOF) {
+				return z.Err()
+			}
+			closeOptional()
+			if len(open) > 0 {
+				return fmt.Errorf("unclosed html tag <%s>", open[len(open)-1])
+			}
+			return nil
+		case html.StartTagToken:
+			name, _ := z.TagName()
+			if !voidElements[string(name)] {
+				open = append(open, string(name))
+			}
+		case html.EndTagToken:
+			name, _ := z.TagName()
+			if voidElements[string(name)] {
+				continue
+			}
+			if len(open) > 0 && open[len(open)-1] != string(name) {
+				closeOptional()
+			}
+			if len(open) == 0 || open[len(open)-1] != string(name) {
+				return fmt.Errorf("unexpected closing html tag </%s>", name)
+			}
+			open = open[:len(open)-1]
+		}
+	}
+}
@@ -0,0 +1,85 @@
+package markdown
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidateMarkdown(t *testing.T) {
+	tables := []struct {
+		name   string
+		body   string
+		hasErr bool
+	}{
+		{
+			name: "plain markdown",
+			body: "# Title\n\nSome **bold** text with a [link](https://openchangelog.com).",
+		},
+		{
+			name: "balanced html block",
+			body: "<details>\n<summary>More</summary>\n\nHidden content\n\n</details>",
+		},
+		{
+			name: "inline html with void element",
+			body: "Line one<br>line <em>two</em>",
+		},
+		{
+			name:   "unclosed html block",
+			body:   "<div>\n\nsome text",
+			hasErr: true,
+		},
+		{
+			name:   "unclosed inline html",
+			body:   "some <span>text",
+			hasErr: true,
+		},
+		{
+			name:   "mismatched closing tag",
+			body:   "some <em>text</strong>",
+			hasErr: true,
+		},
+		{
+			name: "omitted paragraph closing tags",
+			body: "<p>a<p>b",
+		},
+		{
+			name: "omitted list item closing tags",
+			body: "<ul>\n<li>a\n<li>b\n</ul>",
+		},
+		{
+			name: "omitted table closing tags",
+			body: "<table>\n<tr><td>a<td>b\n<tr><td>c\n</table>",
+		},
+		{
+			name:   "unclosed element around omitted closing tag",
+			body:   "<div><p>a",
+			hasErr: true,
+		},
+		{
+			name:   "stray closing tag",
+			body:   "some text</div>",
+			hasErr: true,
+		},
+		{
+			name: "html inside code is ignored",
+			body: "`<div>` and\n\n```\n<span>\n```",
+		},
+		{
+			name:   "body too large",
+			body:   strings.Repeat("a", MaxBodySize+1),
+			hasErr: true,
+		},
+	}
+
+	for _, table := range tables {
+		t.Run(table.name, func(t *testing.T) {
+			err := ValidateMarkdown(table.body)
+			if table.hasErr && err == nil {
+				t.Error("expected error")
+			}
+			if !table.hasErr && err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
@@ -2,10 +2,14 @@ package source
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"io/fs"
+	"log/slog"
 	"net/http"
+	"net/url"
 	"path/filepath"
+	"slices"
 	"sort"
 	"strings"
 	"sync"
@@ -16,18 +20,30 @@ import (
 	"github.com/jonashiltl/openchangelog/internal/config"
 	"github.com/jonashiltl/openchangelog/internal/store"
 	"github.com/jonashiltl/openchangelog/internal/xcache"
+	"github.com/jonashiltl/openchangelog/internal/xlog"
 	"github.com/naveensrinivasan/httpcache"
 )
 
 type ghSource struct {
 	client         *github.Client
+	trees          TreeCache
+	WorkspaceID    store.WorkspaceID
+	GHSourceID     store.GHSourceID
 	Owner          string
 	Repo           string
 	Path           string
 	InstallationID int64
 }
 
-func NewGHSourceFromStore(cfg config.Config, gh store.GHSource, cache xcache.Cache) (Source, error) {
+// Caches the markdown files of a GitHub source directory with the ETag of the response they were read from.
+type TreeCache interface {
+	GetCachedTree(ctx context.Context, wID store.WorkspaceID, ghID store.GHSourceID) ([]string, string, error)
+	SetCachedTree(ctx context.Context, wID store.WorkspaceID, ghID store.GHSourceID, files []string, etag string) error
+}
+
+// Trees is optional, if set the directory listing is requested with If-None-Match
+// and the cached listing is used if GitHub responds with 304 Not Modified.
+func NewGHSourceFromStore(cfg config.Config, gh store.GHSource, cache xcache.Cache, trees TreeCache) (Source, error) {
 	tr := http.DefaultTransport
 
 	if cfg.HasGithubAuth() && cfg.Github.Auth.AppPrivateKey != "" && gh.InstallationID != 0 {
@@ -52,6 +68,9 @@ func NewGHSourceFromStore(cfg config.Config, gh store.GHSource, cache xcache.Cac
 
 	return &ghSource{
 		client:         client,
+		trees:          trees,
+		WorkspaceID:    gh.WorkspaceID,
+		GHSourceID:     gh.ID,
 		Owner:          gh.Owner,
 		Repo:           gh.Repo,
 		Path:           gh.Path,
@@ -73,7 +92,11 @@ func (s *ghSource) Load(ctx context.Context, page internal.Pagination) (LoadResu
 		return LoadResult{}, nil
 	}
 
-	file, dir, resp, err := s.client.Repositories.GetContents(ctx, s.Owner, s.Repo, s.Path, nil)
+	cached, etag := s.cachedTree(ctx)
+	file, dir, resp, err := s.getContents(ctx, etag)
+	if resp != nil && resp.StatusCode == http.StatusNotModified {
+		return s.loadDir(ctx, cached, page)
+	}
 	if err != nil {
 		return LoadResult{}, err
 	}
@@ -91,11 +114,74 @@ func (s *ghSource) Load(ctx context.Context, page internal.Pagination) (LoadResu
 			},
 		}, nil
 	}
-	return s.loadDir(ctx, dir, page)
+
+	files := filter(dir, githubFileIsMD)
+	names := make([]string, len(files))
+	for i, f := range files {
+		names[i] = f.GetName()
+	}
+	s.cacheTree(ctx, names, resp.Header.Get("ETag"))
+	return s.loadDir(ctx, names, page)
+}
+
+// Same as Repositories.GetContents, but sends the etag as If-None-Match if set.
+// On 304 Not Modified the response is returned together with an error.
+func (s *ghSource) getContents(ctx context.Context, etag string) (*github.RepositoryContent, []*github.RepositoryContent, *github.Response, error) {
+	if strings.Contains(s.Path, "..") {
+		return nil, nil, nil, github.ErrPathForbidden
+	}
+
+	escapedPath := (&url.URL{Path: strings.TrimSuffix(s.Path, "/")}).String()
+	req, err := s.client.NewRequest(http.MethodGet, fmt.Sprintf("repos/%s/%s/contents/%s", s.Owner, s.Repo, escapedPath), nil)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	if etag != "" {
+		req.Header.Set("If-None-Match", etag)
+	}
+
+	var raw json.RawMessage
+	resp, err := s.client.Do(ctx, req, &raw)
+	if err != nil {
+		return nil, nil, resp, err
+	}
+
+	var file *github.RepositoryContent
+	if err := json.Unmarshal(raw, &file); err == nil {
+		return file, nil, resp, nil
+	}
+	var dir []*github.RepositoryContent
+	if err := json.Unmarshal(raw, &dir); err != nil {
+		return nil, nil, resp, err
+	}
+	return nil, dir, resp, nil
+}
+
+// Returns the cached markdown files of the source directory and the ETag they were read from.
+// The ETag is empty if nothing is cached.
+func (s *ghSource) cachedTree(ctx context.Context) ([]string, string) {
+	if s.trees == nil {
+		return nil, ""
+	}
+	files, etag, err := s.trees.GetCachedTree(ctx, s.WorkspaceID, s.GHSourceID)
+	if err != nil {
+		return nil, ""
+	}
+	return files, etag
+}
+
+func (s *ghSource) cacheTree(ctx context.Context, files []string, etag string) {
+	if s.trees == nil || etag == "" {
+		return
+	}
+	err := s.trees.SetCachedTree(ctx, s.WorkspaceID, s.GHSourceID, files, etag)
+	if err != nil {
+		slog.Warn("failed to cache github source tree", slog.String("ghID", s.GHSourceID.String()), xlog.ErrAttr(err))
+	}
 }
 
-func (s *ghSource) loadDir(ctx context.Context, files []*github.RepositoryContent, page internal.Pagination) (LoadResult, error) {
-	files = filter(files, githubFileIsMD)
+func (s *ghSource) loadDir(ctx context.Context, names []string, page internal.Pagination) (LoadResult, error) {
+	files := slices.Clone(names)
 	totalFiles := len(files)
 	start, end := calculatePaginationIndices(page, totalFiles)
 	if start >= totalFiles {
@@ -104,7 +190,7 @@ func (s *ghSource) loadDir(ctx context.Context, files []*github.RepositoryConten
 
 	// sort files in descending order by filename
 	sort.Slice(files, func(i, j int) bool {
-		return files[i].GetName() >= files[j].GetName()
+		return files[i] >= files[j]
 	})
 
 	var wg sync.WaitGroup
@@ -122,7 +208,7 @@ func (s *ghSource) loadDir(ctx context.Context, files []*github.RepositoryConten
 			mutex.Lock()
 			notes = append(notes, note)
 			mutex.Unlock()
-		}(file.GetName())
+		}(file)
 	}
 	wg.Wait()
 
@@ -53,11 +53,12 @@ func NewIDFromChangelog(cl store.Changelog) ID {
 	return ""
 }
 
-func NewSourceFromStore(cfg config.Config, cl store.Changelog, cache xcache.Cache) (Source, error) {
+// Trees is optional and only used by GitHub sources, see NewGHSourceFromStore.
+func NewSourceFromStore(cfg config.Config, cl store.Changelog, cache xcache.Cache, trees TreeCache) (Source, error) {
 	if cl.LocalSource.Valid {
 		return NewLocalSourceFromStore(cl.LocalSource.ValueOrZero(), cache), nil
 	} else if cl.GHSource.Valid {
-		return NewGHSourceFromStore(cfg, cl.GHSource.ValueOrZero(), cache)
+		return NewGHSourceFromStore(cfg, cl.GHSource.ValueOrZero(), cache, trees)
 	}
 	return nil, errors.New("changelog has no active source")
 }
@@ -0,0 +1,56 @@
+package store
+
+import (
+	"errors"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/jonashiltl/openchangelog/apitypes"
+	"github.com/jonashiltl/openchangelog/internal/errs"
+)
+
+const (
+	announcement_prefix = "an"
+)
+
+// A banner message which is shown above the changelog feed between StartsAt and EndsAt.
+type Announcement struct {
+	ID          string
+	WorkspaceID WorkspaceID
+	ChangelogID ChangelogID
+	Message     string
+	CTAURL      apitypes.NullString
+	StartsAt    time.Time
+	EndsAt      time.Time
+	CreatedAt   time.Time
+}
+
+func (a Announcement) validate() error {
+	if strings.TrimSpace(a.Message) == "" {
+		return errs.NewBadRequest(errors.New("announcement message can't be empty"))
+	}
+	if a.EndsAt.Before(a.StartsAt) {
+		return errs.NewBadRequest(errors.New("announcement can't end before it starts"))
+	}
+	if a.CTAURL.IsValid() {
+		u, err := url.Parse(a.CTAURL.V())
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+			return errs.NewBadRequest(errors.New("invalid announcement cta url"))
+		}
+	}
+	return nil
+}
+
+func (a changelogAnnouncement) toExported() Announcement {
+	return Announcement{
+		ID:          a.ID,
+		WorkspaceID: WorkspaceID(a.WorkspaceID),
+		ChangelogID: ChangelogID(a.ChangelogID),
+		Message:     a.Message,
+		CTAURL:      a.CtaUrl,
+		StartsAt:    time.Unix(a.StartsAt, 0),
+		EndsAt:      time.Unix(a.EndsAt, 0),
+		CreatedAt:   time.Unix(a.CreatedAt, 0),
+	}
+}
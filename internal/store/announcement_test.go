@@ -0,0 +1,51 @@
+package store
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jonashiltl/openchangelog/apitypes"
+)
+
+func TestValidateAnnouncement(t *testing.T) {
+	now := time.Now()
+	tables := []struct {
+		name   string
+		input  Announcement
+		hasErr bool
+	}{
+		{
+			name:  "valid",
+			input: Announcement{Message: "We're hiring!", StartsAt: now, EndsAt: now.Add(time.Hour)},
+		},
+		{
+			name:  "valid with cta",
+			input: Announcement{Message: "We're hiring!", CTAURL: apitypes.NewString("https://example.com/jobs"), StartsAt: now, EndsAt: now},
+		},
+		{
+			name:   "empty message",
+			input:  Announcement{Message: " ", StartsAt: now, EndsAt: now.Add(time.Hour)},
+			hasErr: true,
+		},
+		{
+			name:   "ends before start",
+			input:  Announcement{Message: "We're hiring!", StartsAt: now, EndsAt: now.Add(-time.Hour)},
+			hasErr: true,
+		},
+		{
+			name:   "invalid cta",
+			input:  Announcement{Message: "We're hiring!", CTAURL: apitypes.NewString("javascript:alert(1)"), StartsAt: now, EndsAt: now},
+			hasErr: true,
+		},
+	}
+
+	for _, table := range tables {
+		err := table.input.validate()
+		if table.hasErr && err == nil {
+			t.Errorf("%s: expected error", table.name)
+		}
+		if !table.hasErr && err != nil {
+			t.Errorf("%s: %s", table.name, err)
+		}
+	}
+}
@@ -0,0 +1,167 @@
+package store
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"slices"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/jonashiltl/openchangelog/internal/errs"
+)
+
+const (
+	asset_prefix      = "as"
+	asset_path_prefix = "/assets/"
+	// maximum size of a downloaded favicon in bytes
+	max_favicon_size = 512 << 10
+	// maximum size of a generated open graph image in bytes
+	max_og_image_size = 2 << 20
+)
+
+type AssetType string
+
+const (
+	AssetFavicon AssetType = "favicon"
+)
+
+type ChangelogAsset struct {
+	ID          string
+	WorkspaceID WorkspaceID
+	ChangelogID ChangelogID
+	Type        AssetType
+	ContentType string
+	Data        []byte
+	CreatedAt   time.Time
+}
+
+// Returns the path under which the asset is served.
+func AssetPath(assetID string) string {
+	return asset_path_prefix + assetID
+}
+
+// Raster image types an asset can have.
+// Other images, especially SVGs which can contain scripts, are rejected.
+var asset_content_types = []string{"image/png", "image/jpeg", "image/gif", "image/webp"}
+
+var assetClient = &http.Client{
+	Timeout: 10 * time.Second,
+	Transport: &http.Transport{
+		// a proxy would be dialed instead of the image host, bypassing denyPrivateAddress
+		Proxy: nil,
+		DialContext: (&net.Dialer{
+			Timeout: 5 * time.Second,
+			Control: denyPrivateAddress,
+		}).DialContext,
+		TLSHandshakeTimeout: 5 * time.Second,
+	},
+	// every redirect dials its target again, which is checked by denyPrivateAddress
+	CheckRedirect: func(req *http.Request, via []*http.Request) error {
+		if len(via) >= 5 {
+			return errors.New("stopped after 5 redirects")
+		}
+		return validateImageURL(req.URL.String())
+	},
+}
+
+// Rejects connections to loopback, private and link-local addresses,
+// so image urls can't be used to reach internal services.
+// Runs after the hostname was resolved, for every connection the client dials.
+func denyPrivateAddress(network, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	ip, err := netip.ParseAddr(host)
+	if err != nil {
+		return err
+	}
+	if !isPublicIP(ip) {
+		return fmt.Errorf("address %s is not public", ip)
+	}
+	return nil
+}
+
+// Returns false for loopback, private, link-local, multicast and unspecified addresses.
+func isPublicIP(ip netip.Addr) bool {
+	ip = ip.Unmap()
+	return !(ip.IsLoopback() || ip.IsPrivate() || ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() ||
+		ip.IsInterfaceLocalMulticast() || ip.IsMulticast() || ip.IsUnspecified())
+}
+
+func validateImageURL(imageURL string) error {
+	u, err := url.Parse(imageURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return errs.NewBadRequest(errors.New("invalid image url"))
+	}
+	return nil
+}
+
+// Detects the type of the image from its content, the Content-Type header of the response isn't trusted.
+// Returns an error if data isn't one of the asset_content_types.
+func sniffImageType(data []byte) (string, error) {
+	contentType := http.DetectContentType(data)
+	if !slices.Contains(asset_content_types, contentType) {
+		return "", errs.NewBadRequest(fmt.Errorf("expected a png, jpeg, gif or webp image, got %s", contentType))
+	}
+	return contentType, nil
+}
+
+// Downloads the image at imageURL.
+// Returns the image and it's content type or an error if the response isn't a raster image or exceeds maxSize.
+func downloadImage(ctx context.Context, imageURL string, maxSize int64) ([]byte, string, error) {
+	err := validateImageURL(imageURL)
+	if err != nil {
+		return nil, "", err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, imageURL, nil)
+	if err != nil {
+		return nil, "", err
+	}
+	res, err := assetClient.Do(req)
+	if err != nil {
+		return nil, "", errs.NewBadRequest(fmt.Errorf("failed to download image: %w", err))
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return nil, "", errs.NewBadRequest(fmt.Errorf("failed to download image, got status %d", res.StatusCode))
+	}
+
+	// read one more byte than allowed to detect too large images
+	data, err := io.ReadAll(io.LimitReader(res.Body, maxSize+1))
+	if err != nil {
+		return nil, "", err
+	}
+	if int64(len(data)) > maxSize {
+		return nil, "", errs.NewBadRequest(fmt.Errorf("image exceeds the maximum size of %d KB", maxSize>>10))
+	}
+
+	contentType, err := sniffImageType(data)
+	if err != nil {
+		return nil, "", err
+	}
+	return data, contentType, nil
+}
+
+// Validates the open graph image and returns its ETag.
+func parseOGImage(image []byte) (string, error) {
+	if len(image) >= max_og_image_size {
+		return "", errs.NewBadRequest(fmt.Errorf("image must be smaller than %d MB", max_og_image_size>>20))
+	}
+	if contentType := http.DetectContentType(image); !strings.HasPrefix(contentType, "image/") {
+		return "", errs.NewBadRequest(fmt.Errorf("expected an image, got %s", contentType))
+	}
+	hash := sha256.Sum256(image)
+	return hex.EncodeToString(hash[:16]), nil
+}
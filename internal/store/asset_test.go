@@ -0,0 +1,115 @@
+package store
+
+import (
+	"testing"
+)
+
+func TestDenyPrivateAddress(t *testing.T) {
+	tables := []struct {
+		address   string
+		expectErr bool
+	}{
+		{
+			address: "93.184.216.34:443",
+		},
+		{
+			address: "[2606:2800:220:1:248:1893:25c8:1946]:443",
+		},
+		{
+			address:   "127.0.0.1:80",
+			expectErr: true,
+		},
+		{
+			address:   "[::1]:80",
+			expectErr: true,
+		},
+		{
+			address:   "10.0.0.5:80",
+			expectErr: true,
+		},
+		{
+			address:   "192.168.1.1:80",
+			expectErr: true,
+		},
+		{
+			address:   "169.254.169.254:80",
+			expectErr: true,
+		},
+		{
+			address:   "[fe80::1]:80",
+			expectErr: true,
+		},
+		{
+			address:   "0.0.0.0:80",
+			expectErr: true,
+		},
+		{
+			address:   "[::ffff:127.0.0.1]:80",
+			expectErr: true,
+		},
+	}
+
+	for _, table := range tables {
+		t.Run(table.address, func(t *testing.T) {
+			err := denyPrivateAddress("tcp", table.address, nil)
+			if table.expectErr && err == nil {
+				t.Error("expected to error but no error returned")
+			}
+			if !table.expectErr && err != nil {
+				t.Errorf("expected no error but got %s", err)
+			}
+		})
+	}
+}
+
+func TestSniffImageType(t *testing.T) {
+	tables := []struct {
+		name      string
+		data      []byte
+		expected  string
+		expectErr bool
+	}{
+		{
+			name:     "png",
+			data:     []byte("\x89PNG\x0D\x0A\x1A\x0A"),
+			expected: "image/png",
+		},
+		{
+			name:     "jpeg",
+			data:     []byte("\xFF\xD8\xFF\xE0"),
+			expected: "image/jpeg",
+		},
+		{
+			name:     "gif",
+			data:     []byte("GIF89a"),
+			expected: "image/gif",
+		},
+		{
+			name:     "webp",
+			data:     []byte("RIFF\x00\x00\x00\x00WEBPVP"),
+			expected: "image/webp",
+		},
+		{
+			name:      "svg",
+			data:      []byte(`<svg xmlns="http://www.w3.org/2000/svg"><script>alert(1)</script></svg>`),
+			expectErr: true,
+		},
+		{
+			name:      "html",
+			data:      []byte("<html><body></body></html>"),
+			expectErr: true,
+		},
+	}
+
+	for _, table := range tables {
+		t.Run(table.name, func(t *testing.T) {
+			contentType, err := sniffImageType(table.data)
+			if table.expectErr && err == nil {
+				t.Error("expected to error but no error returned")
+			}
+			if contentType != table.expected {
+				t.Errorf("expected %s to equal %s", contentType, table.expected)
+			}
+		})
+	}
+}
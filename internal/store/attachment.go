@@ -0,0 +1,73 @@
+package store
+
+import (
+	"errors"
+	"fmt"
+	"mime"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/jonashiltl/openchangelog/internal/errs"
+)
+
+const (
+	attachment_prefix       = "att"
+	max_attachment_size     = 100 << 20
+	max_attachment_filename = 255
+)
+
+// A screenshot or video embedded in an entry of a changelog.
+// Entries are identified by the id of the parsed release note.
+type Attachment struct {
+	ID          string
+	WorkspaceID WorkspaceID
+	ChangelogID ChangelogID
+	EntryID     string
+	Filename    string
+	MimeType    string
+	SizeBytes   int64
+	// Absolute http(s) url or the path of an uploaded asset.
+	URL       string
+	CreatedAt time.Time
+}
+
+func (a Attachment) validate() error {
+	if strings.TrimSpace(a.EntryID) == "" {
+		return errs.NewBadRequest(errors.New("entry id can't be empty"))
+	}
+	if strings.TrimSpace(a.Filename) == "" || len(a.Filename) > max_attachment_filename {
+		return errs.NewBadRequest(fmt.Errorf("filename must be between 1 and %d characters", max_attachment_filename))
+	}
+	if strings.ContainsAny(a.Filename, `/\`) {
+		return errs.NewBadRequest(errors.New("filename can't contain a path"))
+	}
+	mediaType, _, err := mime.ParseMediaType(a.MimeType)
+	if err != nil || (!strings.HasPrefix(mediaType, "image/") && !strings.HasPrefix(mediaType, "video/")) {
+		return errs.NewBadRequest(errors.New("attachment must be an image or video"))
+	}
+	if a.SizeBytes <= 0 || a.SizeBytes > max_attachment_size {
+		return errs.NewBadRequest(fmt.Errorf("attachment must be smaller than %d MB", max_attachment_size>>20))
+	}
+	if !strings.HasPrefix(a.URL, asset_path_prefix) {
+		u, err := url.Parse(a.URL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return errs.NewBadRequest(errors.New("invalid attachment url"))
+		}
+	}
+	return nil
+}
+
+func (a entryAttachment) toExported() Attachment {
+	return Attachment{
+		ID:          a.ID,
+		WorkspaceID: WorkspaceID(a.WorkspaceID),
+		ChangelogID: ChangelogID(a.ChangelogID),
+		EntryID:     a.EntryID,
+		Filename:    a.Filename,
+		MimeType:    a.MimeType,
+		SizeBytes:   a.SizeBytes,
+		URL:         a.Url,
+		CreatedAt:   time.Unix(a.CreatedAt, 0),
+	}
+}
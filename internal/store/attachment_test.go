@@ -0,0 +1,102 @@
+package store
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestAttachmentValidate(t *testing.T) {
+	valid := Attachment{
+		EntryID:   "1718000000",
+		Filename:  "screenshot.png",
+		MimeType:  "image/png",
+		SizeBytes: 1024,
+		URL:       "https://acme.com/screenshot.png",
+	}
+	tables := []struct {
+		name      string
+		modify    func(a *Attachment)
+		expectErr bool
+	}{
+		{
+			name:   "valid",
+			modify: func(a *Attachment) {},
+		},
+		{
+			name: "video",
+			modify: func(a *Attachment) {
+				a.Filename = "demo.mp4"
+				a.MimeType = "video/mp4"
+			},
+		},
+		{
+			name: "asset path",
+			modify: func(a *Attachment) {
+				a.URL = asset_path_prefix + "as_123"
+			},
+		},
+		{
+			name: "empty entry id",
+			modify: func(a *Attachment) {
+				a.EntryID = " "
+			},
+			expectErr: true,
+		},
+		{
+			name: "filename with path",
+			modify: func(a *Attachment) {
+				a.Filename = "../screenshot.png"
+			},
+			expectErr: true,
+		},
+		{
+			name: "filename too long",
+			modify: func(a *Attachment) {
+				a.Filename = strings.Repeat("a", max_attachment_filename+1)
+			},
+			expectErr: true,
+		},
+		{
+			name: "not an image or video",
+			modify: func(a *Attachment) {
+				a.MimeType = "application/pdf"
+			},
+			expectErr: true,
+		},
+		{
+			name: "empty",
+			modify: func(a *Attachment) {
+				a.SizeBytes = 0
+			},
+			expectErr: true,
+		},
+		{
+			name: "too large",
+			modify: func(a *Attachment) {
+				a.SizeBytes = max_attachment_size + 1
+			},
+			expectErr: true,
+		},
+		{
+			name: "javascript url",
+			modify: func(a *Attachment) {
+				a.URL = "javascript:alert(1)"
+			},
+			expectErr: true,
+		},
+	}
+
+	for _, table := range tables {
+		t.Run(table.name, func(t *testing.T) {
+			a := valid
+			table.modify(&a)
+			err := a.validate()
+			if table.expectErr && err == nil {
+				t.Error("expected to error but no error returned")
+			}
+			if !table.expectErr && err != nil {
+				t.Errorf("expected no error but got %s", err)
+			}
+		})
+	}
+}
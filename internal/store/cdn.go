@@ -0,0 +1,24 @@
+package store
+
+import "net/url"
+
+// paths of the pages of a changelog which are cached by a CDN
+const (
+	feed_path            = "/feed"
+	og_image_path_prefix = "/og-images/"
+	release_path_prefix  = "/release/"
+)
+
+// Returns the urls which serve content of the changelog and need to be purged from the CDN after it changed.
+// If entryID is not empty, the permalink of the entry is included.
+func cdnPurgeList(changelogURL string, cID ChangelogID, entryID string) []string {
+	urls := []string{
+		changelogURL,
+		changelogURL + feed_path,
+		changelogURL + og_image_path_prefix + cID.String(),
+	}
+	if entryID != "" {
+		urls = append(urls, changelogURL+release_path_prefix+url.PathEscape(entryID))
+	}
+	return urls
+}
@@ -0,0 +1,42 @@
+package store
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestCDNPurgeList(t *testing.T) {
+	tables := []struct {
+		name     string
+		entryID  string
+		expected []string
+	}{
+		{
+			name: "changelog",
+			expected: []string{
+				"https://changelog.acme.com",
+				"https://changelog.acme.com/feed",
+				"https://changelog.acme.com/og-images/cl_1",
+			},
+		},
+		{
+			name:    "entry",
+			entryID: "v1.2 beta",
+			expected: []string{
+				"https://changelog.acme.com",
+				"https://changelog.acme.com/feed",
+				"https://changelog.acme.com/og-images/cl_1",
+				"https://changelog.acme.com/release/v1.2%20beta",
+			},
+		},
+	}
+
+	for _, table := range tables {
+		t.Run(table.name, func(t *testing.T) {
+			urls := cdnPurgeList("https://changelog.acme.com", "cl_1", table.entryID)
+			if !slices.Equal(urls, table.expected) {
+				t.Errorf("expected %v to equal %v", urls, table.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,65 @@
+package store
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jonashiltl/openchangelog/internal/errs"
+	"github.com/jonashiltl/openchangelog/internal/markdown"
+)
+
+const (
+	comment_prefix     = "cm"
+	max_comment_length = 10000
+)
+
+// An internal note of the team on an entry, comments are never shown on the public changelog.
+// Entries are identified by the id of the parsed release note.
+type Comment struct {
+	ID          string
+	WorkspaceID WorkspaceID
+	ChangelogID ChangelogID
+	EntryID     string
+	AuthorID    string
+	// Markdown, validated with markdown.ValidateMarkdown.
+	Body      string
+	Resolved  bool
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+func (c Comment) validate() error {
+	if strings.TrimSpace(c.EntryID) == "" {
+		return errs.NewBadRequest(errors.New("entry id can't be empty"))
+	}
+	if strings.TrimSpace(c.AuthorID) == "" {
+		return errs.NewBadRequest(errors.New("comment author can't be empty"))
+	}
+	return validateCommentBody(c.Body)
+}
+
+func validateCommentBody(body string) error {
+	if strings.TrimSpace(body) == "" {
+		return errs.NewBadRequest(errors.New("comment can't be empty"))
+	}
+	if len(body) > max_comment_length {
+		return errs.NewBadRequest(fmt.Errorf("comment must be shorter than %d characters", max_comment_length))
+	}
+	return markdown.ValidateMarkdown(body)
+}
+
+func (c entryComment) toExported() Comment {
+	return Comment{
+		ID:          c.ID,
+		WorkspaceID: WorkspaceID(c.WorkspaceID),
+		ChangelogID: ChangelogID(c.ChangelogID),
+		EntryID:     c.EntryID,
+		AuthorID:    c.AuthorID,
+		Body:        c.Body,
+		Resolved:    c.Resolved == 1,
+		CreatedAt:   time.Unix(c.CreatedAt, 0),
+		UpdatedAt:   time.Unix(c.UpdatedAt, 0),
+	}
+}
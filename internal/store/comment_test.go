@@ -0,0 +1,63 @@
+package store
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCommentValidate(t *testing.T) {
+	valid := Comment{
+		EntryID:  "1718000000",
+		AuthorID: "us_123",
+		Body:     "Can we add a screenshot here?",
+	}
+	tables := []struct {
+		name      string
+		modify    func(c *Comment)
+		expectErr bool
+	}{
+		{
+			name:   "valid",
+			modify: func(c *Comment) {},
+		},
+		{
+			name:      "missing entry",
+			modify:    func(c *Comment) { c.EntryID = "" },
+			expectErr: true,
+		},
+		{
+			name:      "missing author",
+			modify:    func(c *Comment) { c.AuthorID = " " },
+			expectErr: true,
+		},
+		{
+			name:      "empty body",
+			modify:    func(c *Comment) { c.Body = "  \n" },
+			expectErr: true,
+		},
+		{
+			name:      "unclosed html",
+			modify:    func(c *Comment) { c.Body = "<details>\n\nsee the screenshot" },
+			expectErr: true,
+		},
+		{
+			name:      "body too long",
+			modify:    func(c *Comment) { c.Body = strings.Repeat("a", max_comment_length+1) },
+			expectErr: true,
+		},
+	}
+
+	for _, table := range tables {
+		t.Run(table.name, func(t *testing.T) {
+			c := valid
+			table.modify(&c)
+			err := c.validate()
+			if table.expectErr && err == nil {
+				t.Error("expected to error but no error returned")
+			}
+			if !table.expectErr && err != nil {
+				t.Errorf("expected no error but got %s", err)
+			}
+		})
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"strings"
+	"time"
 
 	"github.com/guregu/null/v5"
 	"github.com/jonashiltl/openchangelog/apitypes"
@@ -102,6 +103,70 @@ func (s *configStore) GetChangelogByDomainOrSubdomain(ctx context.Context, domai
 	return s.GetChangelog(ctx, WS_DEFAULT_ID, CL_DEFAULT_ID)
 }
 
+func (s *configStore) GetChangelogBrandKit(ctx context.Context, domain Domain, subdomain Subdomain) (BrandKit, error) {
+	cl, err := s.GetChangelog(ctx, WS_DEFAULT_ID, CL_DEFAULT_ID)
+	if err != nil {
+		return BrandKit{}, err
+	}
+	return BrandKit{
+		LogoSrc:     cl.LogoSrc,
+		LogoLink:    cl.LogoLink,
+		LogoAlt:     cl.LogoAlt,
+		LogoHeight:  cl.LogoHeight,
+		LogoWidth:   cl.LogoWidth,
+		ColorScheme: cl.ColorScheme,
+		FaviconSrc:  cl.FaviconSrc,
+		SocialLinks: cl.SocialLinks,
+	}, nil
+}
+
+func (s *configStore) GetChangelogBySlug(ctx context.Context, wID WorkspaceID, slug string) (Changelog, error) {
+	return s.GetChangelog(ctx, WS_DEFAULT_ID, CL_DEFAULT_ID)
+}
+
+func (s *configStore) GetChangelogBySubdomainOrSlug(ctx context.Context, subdomain Subdomain, slug string) (Changelog, error) {
+	return s.GetChangelog(ctx, WS_DEFAULT_ID, CL_DEFAULT_ID)
+}
+
+func (s *configStore) GetChangelogOEmbedData(ctx context.Context, url string) (OEmbedResponse, error) {
+	cl, err := s.GetChangelog(ctx, WS_DEFAULT_ID, CL_DEFAULT_ID)
+	if err != nil {
+		return OEmbedResponse{}, err
+	}
+	return newOEmbedResponse(cl, ""), nil
+}
+
+func (s *configStore) GetChangelogPublicURL(context.Context, WorkspaceID, ChangelogID) (string, error) {
+	return "", errs.NewError(errs.ErrBadRequest, errors.New("public urls not supported in local config mode"))
+}
+
+func (s *configStore) GetCDNPurgeList(context.Context, WorkspaceID, ChangelogID, string) ([]string, error) {
+	return nil, errs.NewError(errs.ErrBadRequest, errors.New("public urls not supported in local config mode"))
+}
+
+var errCustomDomainsNotSupported = errs.NewError(errs.ErrBadRequest, errors.New("custom domains not supported in local config mode"))
+
+func (s *configStore) AddCustomDomain(context.Context, WorkspaceID, ChangelogID, string) (CustomDomain, error) {
+	return CustomDomain{}, errCustomDomainsNotSupported
+}
+
+func (s *configStore) RemoveCustomDomain(context.Context, WorkspaceID, ChangelogID, string) error {
+	return errCustomDomainsNotSupported
+}
+
+func (s *configStore) SetCustomDomainVerified(context.Context, WorkspaceID, ChangelogID, string, bool) error {
+	return errCustomDomainsNotSupported
+}
+
+func (s *configStore) ListCustomDomains(context.Context, WorkspaceID, ChangelogID) ([]CustomDomain, error) {
+	return []CustomDomain{}, nil
+}
+
+func (s *configStore) GetChangelogByCustomDomain(ctx context.Context, _ Domain) (Changelog, error) {
+	// the config changelog is served under any host
+	return s.GetChangelog(ctx, WS_DEFAULT_ID, CL_DEFAULT_ID)
+}
+
 func (s *configStore) ListChangelogs(ctx context.Context, wID WorkspaceID) ([]Changelog, error) {
 	cl, err := s.GetChangelog(ctx, wID, CL_DEFAULT_ID)
 	if err != nil {
@@ -110,6 +175,101 @@ func (s *configStore) ListChangelogs(ctx context.Context, wID WorkspaceID) ([]Ch
 	return []Changelog{cl}, nil
 }
 
+func (s *configStore) BulkGetChangelogs(ctx context.Context, wID WorkspaceID, cIDs []ChangelogID) ([]*Changelog, error) {
+	res := make([]*Changelog, len(cIDs))
+	for i, id := range cIDs {
+		if id != CL_DEFAULT_ID {
+			continue
+		}
+		cl, err := s.GetChangelog(ctx, wID, id)
+		if err != nil {
+			return nil, err
+		}
+		res[i] = &cl
+	}
+	return res, nil
+}
+
+var errDomainVerificationNotSupported = errs.NewError(errs.ErrBadRequest, errors.New("domain verification not supported in local config mode"))
+
+func (s *configStore) StartDomainVerification(context.Context, WorkspaceID, ChangelogID) error {
+	return errDomainVerificationNotSupported
+}
+
+func (s *configStore) RecordDomainVerification(context.Context, WorkspaceID, ChangelogID, bool, error) error {
+	return errDomainVerificationNotSupported
+}
+
+func (s *configStore) GetDomainVerificationStatus(context.Context, WorkspaceID, ChangelogID) (DomainVerification, error) {
+	return DomainVerification{}, errDomainVerificationNotSupported
+}
+
+func (s *configStore) SetChangelogRobotsTxt(context.Context, WorkspaceID, ChangelogID, string) error {
+	return errs.NewError(errs.ErrBadRequest, errors.New("robots.txt overrides not supported in local config mode"))
+}
+
+func (s *configStore) GetChangelogRobotsTxt(context.Context, Domain, Subdomain) (string, error) {
+	return DefaultRobotsTxt, nil
+}
+
+func (s *configStore) SetCanonicalURL(context.Context, WorkspaceID, ChangelogID, string) error {
+	return errs.NewError(errs.ErrBadRequest, errors.New("canonical urls not supported in local config mode"))
+}
+
+func (s *configStore) GetCanonicalURL(context.Context, Domain, Subdomain) (string, error) {
+	return "", errs.NewError(errs.ErrBadRequest, errors.New("public urls not supported in local config mode"))
+}
+
+func (s *configStore) SetI18nString(context.Context, WorkspaceID, ChangelogID, string, string) error {
+	return errs.NewError(errs.ErrBadRequest, errors.New("i18n overrides not supported in local config mode"))
+}
+
+func (s *configStore) GetI18nStrings(context.Context, WorkspaceID, ChangelogID) (map[string]string, error) {
+	return map[string]string{}, nil
+}
+
+func (s *configStore) DeleteI18nString(context.Context, WorkspaceID, ChangelogID, string) error {
+	return errs.NewError(errs.ErrBadRequest, errors.New("i18n overrides not supported in local config mode"))
+}
+
+func (s *configStore) GetChangelogTheme(context.Context, WorkspaceID, ChangelogID) (Theme, error) {
+	return Theme{}, nil
+}
+
+func (s *configStore) SetChangelogTheme(context.Context, WorkspaceID, ChangelogID, Theme) error {
+	return errs.NewError(errs.ErrBadRequest, errors.New("themes not supported in local config mode"))
+}
+
+func (s *configStore) SetSocialLinks(context.Context, WorkspaceID, ChangelogID, SocialLinks) error {
+	return errs.NewError(errs.ErrBadRequest, errors.New("social links not supported in local config mode"))
+}
+
+func (s *configStore) SetChangelogSortOrder(context.Context, WorkspaceID, ChangelogID, int) error {
+	return errs.NewError(errs.ErrBadRequest, errors.New("changelog sort order not supported in local config mode"))
+}
+
+func (s *configStore) BulkSetChangelogSortOrder(context.Context, WorkspaceID, map[ChangelogID]int) error {
+	return errs.NewError(errs.ErrBadRequest, errors.New("changelog sort order not supported in local config mode"))
+}
+
+func (s *configStore) ListChangelogsWithoutSource(ctx context.Context, wID WorkspaceID) ([]Changelog, error) {
+	if s.cfg.Local != nil || s.cfg.Github != nil {
+		return []Changelog{}, nil
+	}
+	return s.ListChangelogs(ctx, wID)
+}
+
+func (s *configStore) ListChangelogsByInstallationID(ctx context.Context, installationID int64) ([]Changelog, error) {
+	cl, err := s.GetChangelog(ctx, WS_DEFAULT_ID, CL_DEFAULT_ID)
+	if err != nil {
+		return []Changelog{}, err
+	}
+	if !cl.GHSource.Valid || cl.GHSource.V.InstallationID != installationID {
+		return []Changelog{}, nil
+	}
+	return []Changelog{cl}, nil
+}
+
 func (s *configStore) DeleteChangelog(context.Context, WorkspaceID, ChangelogID) error {
 	return errs.NewError(errs.ErrBadRequest, errors.New("changelog deletion not allowed in local config mode"))
 }
@@ -122,6 +282,49 @@ func (s *configStore) DeleteChangelogSource(context.Context, WorkspaceID, Change
 	return errs.NewError(errs.ErrBadRequest, errors.New("changelog source deletion not allowed in local config mode"))
 }
 
+func (s *configStore) SetChangelogFaviconFromURL(context.Context, WorkspaceID, ChangelogID, string) error {
+	return errs.NewError(errs.ErrBadRequest, errors.New("changing the favicon not allowed in local config mode"))
+}
+
+func (s *configStore) GetChangelogAsset(context.Context, string) (ChangelogAsset, error) {
+	return ChangelogAsset{}, errs.NewError(errs.ErrNotFound, errors.New("asset not found"))
+}
+
+func (s *configStore) SetChangelogOGImage(context.Context, WorkspaceID, ChangelogID, []byte) error {
+	return errs.NewError(errs.ErrBadRequest, errors.New("og images not supported in local config mode"))
+}
+
+func (s *configStore) GetChangelogOGImage(context.Context, WorkspaceID, ChangelogID) ([]byte, string, time.Time, error) {
+	return nil, "", time.Time{}, errs.NewError(errs.ErrNotFound, errors.New("og image not found"))
+}
+
+func (s *configStore) GetContentHash(context.Context, WorkspaceID, ChangelogID) (string, error) {
+	return "", nil
+}
+
+func (s *configStore) SetContentHash(context.Context, WorkspaceID, ChangelogID, string) error {
+	return nil
+}
+
+func (s *configStore) GetChangelogSEOScore(ctx context.Context, wID WorkspaceID, cID ChangelogID) (SEOScore, error) {
+	cl, err := s.GetChangelog(ctx, wID, cID)
+	if err != nil {
+		return SEOScore{}, err
+	}
+
+	row := getChangelogSEOSignalsRow{
+		Title:    cl.Title,
+		Subtitle: cl.Subtitle,
+		Domain:   apitypes.NullString(cl.Domain),
+	}
+	if cl.GHSource.Valid {
+		row.SourceID = apitypes.NewString(cl.GHSource.V.ID.String())
+	} else if cl.LocalSource.Valid {
+		row.SourceID = apitypes.NewString(cl.LocalSource.V.Path)
+	}
+	return row.toSEOScore(), nil
+}
+
 func (s *configStore) CreateGHSource(context.Context, GHSource) (GHSource, error) {
 	return GHSource{}, errs.NewError(errs.ErrBadRequest, errors.New("github source creation not allowed in local config mode"))
 }
@@ -155,10 +358,152 @@ func (s *configStore) GetGHSource(context.Context, WorkspaceID, GHSourceID) (GHS
 	return g, nil
 }
 
+func (s *configStore) GetGHSourceByChangelog(ctx context.Context, wID WorkspaceID, cID ChangelogID) (GHSource, error) {
+	if cID != CL_DEFAULT_ID {
+		return GHSource{}, errNoChangelog
+	}
+	return s.GetGHSource(ctx, wID, GH_DEFAULT_ID)
+}
+
+func (s *configStore) ListGHSourcesNeedingRefresh(context.Context, int) ([]GHSource, error) {
+	return []GHSource{}, errs.NewError(errs.ErrBadRequest, errors.New("github source refresh tracking not supported in local config mode"))
+}
+
+func (s *configStore) ListOrphanedGHSources(context.Context) ([]GHSource, error) {
+	// the config source is always used by the config changelog
+	return []GHSource{}, nil
+}
+
+func (s *configStore) UpdateGHSourceLastFetched(context.Context, WorkspaceID, GHSourceID) error {
+	return errs.NewError(errs.ErrBadRequest, errors.New("github source refresh tracking not supported in local config mode"))
+}
+
+func (s *configStore) RecordGHSourceFailure(context.Context, WorkspaceID, GHSourceID, error) error {
+	return errs.NewError(errs.ErrBadRequest, errors.New("github source health tracking not supported in local config mode"))
+}
+
+func (s *configStore) RecordGHSourceSuccess(context.Context, WorkspaceID, GHSourceID) error {
+	return errs.NewError(errs.ErrBadRequest, errors.New("github source health tracking not supported in local config mode"))
+}
+
+func (s *configStore) GetGHSourceHealth(context.Context, WorkspaceID, GHSourceID) (GHSourceHealth, error) {
+	return GHSourceHealth{}, errs.NewError(errs.ErrBadRequest, errors.New("github source health tracking not supported in local config mode"))
+}
+
+func (s *configStore) SetGHSourcePollInterval(context.Context, WorkspaceID, GHSourceID, int) error {
+	return errs.NewError(errs.ErrBadRequest, errors.New("github source poll interval not supported in local config mode"))
+}
+
+func (s *configStore) SetGHSourceWebhookSecret(context.Context, WorkspaceID, GHSourceID, string) error {
+	return errs.NewError(errs.ErrBadRequest, errors.New("github webhook secrets not supported in local config mode"))
+}
+
+func (s *configStore) GetGHSourceWebhookSecret(context.Context, WorkspaceID, GHSourceID) (string, error) {
+	return "", errs.NewError(errs.ErrBadRequest, errors.New("github webhook secrets not supported in local config mode"))
+}
+
+func (s *configStore) AppendSyncLog(context.Context, GHSyncLog) error {
+	return errs.NewError(errs.ErrBadRequest, errors.New("github sync logs not supported in local config mode"))
+}
+
+func (s *configStore) ListSyncLogs(context.Context, WorkspaceID, GHSourceID, int) ([]GHSyncLog, error) {
+	return []GHSyncLog{}, errs.NewError(errs.ErrBadRequest, errors.New("github sync logs not supported in local config mode"))
+}
+
+func (s *configStore) PurgeSyncLogs(context.Context, time.Time) (int64, error) {
+	return 0, nil
+}
+
+func (s *configStore) GetCachedTree(context.Context, WorkspaceID, GHSourceID) ([]string, string, error) {
+	return nil, "", errs.NewError(errs.ErrNotFound, errors.New("tree not cached"))
+}
+
+func (s *configStore) SetCachedTree(context.Context, WorkspaceID, GHSourceID, []string, string) error {
+	return nil
+}
+
+func (s *configStore) GetFileCache(context.Context, WorkspaceID, GHSourceID) (map[string]string, error) {
+	return map[string]string{}, nil
+}
+
+func (s *configStore) SetFileCache(context.Context, WorkspaceID, GHSourceID, map[string]string) error {
+	return nil
+}
+
+func (s *configStore) ProcessGHPushPayload(_ context.Context, _ WorkspaceID, _ GHSourceID, addedFiles, modifiedFiles, removedFiles []string) ([]string, error) {
+	return pushedPaths(addedFiles, modifiedFiles, removedFiles), nil
+}
+
 func (s *configStore) SaveWorkspace(context.Context, Workspace) (Workspace, error) {
 	return Workspace{}, errs.NewError(errs.ErrBadRequest, errors.New("workspace creation not allowed in local config mode"))
 }
 
+func (s *configStore) GetOrCreateWorkspace(context.Context, string) (Workspace, bool, error) {
+	return Workspace{}, false, errs.NewError(errs.ErrBadRequest, errors.New("workspace creation not allowed in local config mode"))
+}
+
+var errBillingNotSupported = errs.NewError(errs.ErrBadRequest, errors.New("billing not supported in local config mode"))
+
+func (s *configStore) GetWorkspaceBillingInfo(context.Context, WorkspaceID) (BillingInfo, error) {
+	return BillingInfo{}, errBillingNotSupported
+}
+
+func (s *configStore) UpdateWorkspaceBillingInfo(context.Context, WorkspaceID, BillingInfo) error {
+	return errBillingNotSupported
+}
+
+func (s *configStore) GetWorkspaceWebhookSecret(context.Context, WorkspaceID) (string, error) {
+	return "", errWebhooksNotSupported
+}
+
+func (s *configStore) RotateWorkspaceWebhookSecret(context.Context, WorkspaceID) (string, error) {
+	return "", errWebhooksNotSupported
+}
+
+var errEmailSettingsNotSupported = errs.NewError(errs.ErrBadRequest, errors.New("email settings not supported in local config mode"))
+
+func (s *configStore) RecordEmailDelivery(context.Context, EmailDelivery) error {
+	return errEmailSettingsNotSupported
+}
+
+func (s *configStore) GetEmailDelivery(context.Context, WorkspaceID, string) (EmailDelivery, error) {
+	return EmailDelivery{}, errEmailSettingsNotSupported
+}
+
+func (s *configStore) ListEmailDeliveries(context.Context, WorkspaceID, ChangelogID, int, int) ([]EmailDelivery, error) {
+	return []EmailDelivery{}, nil
+}
+
+func (s *configStore) GetEmailSettings(context.Context, WorkspaceID) (EmailSettings, error) {
+	return EmailSettings{}, errEmailSettingsNotSupported
+}
+
+func (s *configStore) SetEmailSettings(context.Context, WorkspaceID, EmailSettings) error {
+	return errEmailSettingsNotSupported
+}
+
+var errSSONotSupported = errs.NewError(errs.ErrBadRequest, errors.New("sso not supported in local config mode"))
+
+func (s *configStore) GetSSOConfig(context.Context, WorkspaceID) (SSOConfig, error) {
+	return SSOConfig{}, errSSONotSupported
+}
+
+func (s *configStore) SetSSOConfig(context.Context, WorkspaceID, SSOConfig) error {
+	return errSSONotSupported
+}
+
+func (s *configStore) DeleteSSOConfig(context.Context, WorkspaceID) error {
+	return errSSONotSupported
+}
+
+func (s *configStore) GetWorkspaceQuotaUsage(context.Context, WorkspaceID) (QuotaUsage, error) {
+	usage := QuotaUsage{Changelogs: 1}
+	if s.cfg.Github != nil {
+		usage.GHSources = 1
+	}
+	return usage, nil
+}
+
 func (s *configStore) DeleteWorkspace(context.Context, WorkspaceID) error {
 	return errs.NewError(errs.ErrBadRequest, errors.New("workspace deletion not allowed in local config mode"))
 }
@@ -167,10 +512,371 @@ func (s *configStore) GetWorkspace(context.Context, WorkspaceID) (Workspace, err
 	return Workspace{}, errs.NewError(errs.ErrBadRequest, errors.New("get workspace not allowed in local config mode"))
 }
 
+func (s *configStore) GetWorkspaceByChangelog(context.Context, WorkspaceID, ChangelogID) (Workspace, error) {
+	return Workspace{}, errs.NewError(errs.ErrBadRequest, errors.New("get workspace not allowed in local config mode"))
+}
+
 func (s *configStore) GetWorkspaceIDByToken(ctx context.Context, token string) (WorkspaceID, error) {
 	return WS_DEFAULT_ID, nil
 }
 
+func (s *configStore) ListAPIKeys(context.Context, WorkspaceID) ([]APIKey, error) {
+	return []APIKey{}, nil
+}
+
+func (s *configStore) RevokeAPIKey(context.Context, WorkspaceID, string) error {
+	return errs.NewError(errs.ErrBadRequest, errors.New("api keys not supported in local config mode"))
+}
+
+func (s *configStore) SetTokenScopes(context.Context, WorkspaceID, string, []string) error {
+	return errs.NewError(errs.ErrBadRequest, errors.New("token scopes not supported in local config mode"))
+}
+
+func (s *configStore) GetTokenScopes(context.Context, string) ([]string, error) {
+	return AllTokenScopes, nil
+}
+
+func (s *configStore) RecordAPIRequest(context.Context, WorkspaceID, string, string) error {
+	return nil
+}
+
+func (s *configStore) GetAPIUsage(context.Context, WorkspaceID, time.Time, time.Time) (APIUsage, error) {
+	return APIUsage{}, errs.NewError(errs.ErrBadRequest, errors.New("api usage not supported in local config mode"))
+}
+
+func (s *configStore) PurgeAPIRequests(context.Context, time.Time) error {
+	return nil
+}
+
+func (s *configStore) GetWorkspaceToken(context.Context, WorkspaceID) (Token, error) {
+	return "", errs.NewError(errs.ErrBadRequest, errors.New("get workspace token not allowed in local config mode"))
+}
+
+func (s *configStore) ListWorkspacesWithExpiredTokens(context.Context, time.Time) ([]WorkspaceID, error) {
+	return []WorkspaceID{}, nil
+}
+
+func (s *configStore) PurgeExpiredTokens(context.Context, time.Time) (int64, error) {
+	return 0, errs.NewError(errs.ErrBadRequest, errors.New("purge expired tokens not allowed in local config mode"))
+}
+
+var errInvitesNotSupported = errs.NewError(errs.ErrBadRequest, errors.New("workspace invites not supported in local config mode"))
+
+func (s *configStore) CreateInvite(context.Context, WorkspaceID, string, string, time.Duration) (string, error) {
+	return "", errInvitesNotSupported
+}
+
+func (s *configStore) AcceptInvite(context.Context, string) (WorkspaceID, error) {
+	return "", errInvitesNotSupported
+}
+
+func (s *configStore) RevokeInvite(context.Context, WorkspaceID, string) error {
+	return errInvitesNotSupported
+}
+
+var errMembersNotSupported = errs.NewError(errs.ErrBadRequest, errors.New("workspace members not supported in local config mode"))
+
+func (s *configStore) AddWorkspaceMember(context.Context, WorkspaceID, string, MemberRole) (WorkspaceMember, error) {
+	return WorkspaceMember{}, errMembersNotSupported
+}
+
+func (s *configStore) RemoveWorkspaceMember(context.Context, WorkspaceID, string) error {
+	return errMembersNotSupported
+}
+
+func (s *configStore) GetWorkspaceMember(context.Context, WorkspaceID, string) (WorkspaceMember, error) {
+	return WorkspaceMember{}, errMembersNotSupported
+}
+
+func (s *configStore) ListWorkspaceMembers(context.Context, WorkspaceID) ([]WorkspaceMember, error) {
+	return []WorkspaceMember{}, nil
+}
+
+func (s *configStore) UpdateMemberRole(context.Context, WorkspaceID, string, MemberRole) error {
+	return errMembersNotSupported
+}
+
+func (s *configStore) ListPublicChangelogs(context.Context, int, int) ([]Changelog, int64, error) {
+	return []Changelog{}, 0, errs.NewError(errs.ErrBadRequest, errors.New("list public changelogs not supported in local config mode"))
+}
+
+func (s *configStore) ListChangelogsWithAnalytics(ctx context.Context, page, pageSize int) ([]Changelog, int64, error) {
+	if page < 1 || pageSize < 1 {
+		return nil, 0, errs.NewError(errs.ErrBadRequest, errors.New("page and page size must be greater than 0"))
+	}
+	cl, err := s.GetChangelog(ctx, WS_DEFAULT_ID, CL_DEFAULT_ID)
+	if err != nil {
+		return nil, 0, err
+	}
+	if !cl.Analytics {
+		return []Changelog{}, 0, nil
+	}
+	if page > 1 {
+		return []Changelog{}, 1, nil
+	}
+	return []Changelog{cl}, 1, nil
+}
+
+func (s *configStore) CountPublicChangelogs(context.Context) (int64, error) {
+	return 0, errs.NewError(errs.ErrBadRequest, errors.New("count public changelogs not supported in local config mode"))
+}
+
+func (s *configStore) ListAllSubdomains(context.Context) ([]Subdomain, error) {
+	return []Subdomain{}, nil
+}
+
+func (s *configStore) ListSubdomainsByWorkspace(context.Context, WorkspaceID) ([]Subdomain, error) {
+	return []Subdomain{}, nil
+}
+
+func (s *configStore) CreateChangelogToken(context.Context, WorkspaceID, ChangelogID) (ChangelogToken, error) {
+	return "", errs.NewError(errs.ErrBadRequest, errors.New("changelog tokens not supported in local config mode"))
+}
+
+func (s *configStore) GetChangelogByToken(context.Context, string) (Changelog, error) {
+	return Changelog{}, errs.NewError(errs.ErrBadRequest, errors.New("changelog tokens not supported in local config mode"))
+}
+
+func (s *configStore) RevokeChangelogToken(context.Context, WorkspaceID, ChangelogToken) error {
+	return errs.NewError(errs.ErrBadRequest, errors.New("changelog tokens not supported in local config mode"))
+}
+
+func (s *configStore) CreateRSSToken(context.Context, WorkspaceID, ChangelogID) (string, error) {
+	return "", errs.NewError(errs.ErrBadRequest, errors.New("rss tokens not supported in local config mode"))
+}
+
+func (s *configStore) GetChangelogByRSSToken(context.Context, string) (Changelog, error) {
+	return Changelog{}, errs.NewError(errs.ErrBadRequest, errors.New("rss tokens not supported in local config mode"))
+}
+
+func (s *configStore) RevokeRSSToken(context.Context, WorkspaceID, ChangelogID, string) error {
+	return errs.NewError(errs.ErrBadRequest, errors.New("rss tokens not supported in local config mode"))
+}
+
+func (s *configStore) CreatePreviewToken(context.Context, WorkspaceID, ChangelogID) (string, error) {
+	return "", errs.NewError(errs.ErrBadRequest, errors.New("preview tokens not supported in local config mode"))
+}
+
+func (s *configStore) GetChangelogByPreviewToken(context.Context, string) (Changelog, error) {
+	return Changelog{}, errs.NewError(errs.ErrBadRequest, errors.New("preview tokens not supported in local config mode"))
+}
+
+func (s *configStore) RevokePreviewToken(context.Context, WorkspaceID, ChangelogID, string) error {
+	return errs.NewError(errs.ErrBadRequest, errors.New("preview tokens not supported in local config mode"))
+}
+
+func (s *configStore) SnapshotChangelog(context.Context, WorkspaceID, ChangelogID) error {
+	return errs.NewError(errs.ErrBadRequest, errors.New("changelog snapshots not supported in local config mode"))
+}
+
+func (s *configStore) ListSnapshots(context.Context, WorkspaceID, ChangelogID, int) ([]ChangelogSnapshot, error) {
+	return []ChangelogSnapshot{}, errs.NewError(errs.ErrBadRequest, errors.New("changelog snapshots not supported in local config mode"))
+}
+
+func (s *configStore) RestoreSnapshot(context.Context, WorkspaceID, ChangelogID, string) (Changelog, error) {
+	return Changelog{}, errs.NewError(errs.ErrBadRequest, errors.New("changelog snapshots not supported in local config mode"))
+}
+
+func (s *configStore) DiffChangelogs(ctx context.Context, wID WorkspaceID, aID, bID ChangelogID) (ChangelogDiff, error) {
+	a, err := s.GetChangelog(ctx, wID, aID)
+	if err != nil {
+		return nil, err
+	}
+	b, err := s.GetChangelog(ctx, wID, bID)
+	if err != nil {
+		return nil, err
+	}
+	return diffChangelogs(a, b), nil
+}
+
+func (s *configStore) RecordSearchQuery(context.Context, WorkspaceID, ChangelogID, string, int) error {
+	return nil
+}
+
+func (s *configStore) GetTopSearchQueries(context.Context, WorkspaceID, ChangelogID, int, time.Time, time.Time) ([]SearchQueryStat, error) {
+	return []SearchQueryStat{}, errs.NewError(errs.ErrBadRequest, errors.New("search analytics not supported in local config mode"))
+}
+
+func (s *configStore) AppendAccessLog(context.Context, AccessLogEntry) error {
+	return nil
+}
+
+func (s *configStore) ListAccessLog(context.Context, WorkspaceID, ChangelogID, time.Time, time.Time, int, int) ([]AccessLogEntry, error) {
+	return []AccessLogEntry{}, errs.NewError(errs.ErrBadRequest, errors.New("access log not supported in local config mode"))
+}
+
+func (s *configStore) PurgeAccessLog(context.Context, time.Time) (int64, error) {
+	return 0, nil
+}
+
+func (s *configStore) GetWorkspaceDashboardSummary(context.Context, WorkspaceID) (DashboardSummary, error) {
+	return DashboardSummary{ChangelogCount: 1}, nil
+}
+
+func (s *configStore) GetHeatmapByDayOfWeek(context.Context, WorkspaceID, ChangelogID, time.Time, time.Time) ([7]int64, error) {
+	return [7]int64{}, errs.NewError(errs.ErrBadRequest, errors.New("access log not supported in local config mode"))
+}
+
+func (s *configStore) GetHeatmapByHour(context.Context, WorkspaceID, ChangelogID, time.Time, time.Time) ([24]int64, error) {
+	return [24]int64{}, errs.NewError(errs.ErrBadRequest, errors.New("access log not supported in local config mode"))
+}
+
+func (s *configStore) GetTrafficSources(context.Context, WorkspaceID, ChangelogID, time.Time, time.Time, int) ([]TrafficSource, error) {
+	return []TrafficSource{}, errs.NewError(errs.ErrBadRequest, errors.New("access log not supported in local config mode"))
+}
+
+func (s *configStore) GetWorkspaceAnalytics(context.Context, WorkspaceID, time.Time, time.Time) (WorkspaceAnalytics, error) {
+	return WorkspaceAnalytics{}, errs.NewError(errs.ErrBadRequest, errors.New("access log not supported in local config mode"))
+}
+
+func (s *configStore) CreateAnnouncement(context.Context, Announcement) (Announcement, error) {
+	return Announcement{}, errs.NewError(errs.ErrBadRequest, errors.New("announcements not supported in local config mode"))
+}
+
+func (s *configStore) DeleteAnnouncement(context.Context, WorkspaceID, ChangelogID, string) error {
+	return errs.NewError(errs.ErrBadRequest, errors.New("announcements not supported in local config mode"))
+}
+
+func (s *configStore) ListActiveAnnouncements(context.Context, ChangelogID, time.Time) ([]Announcement, error) {
+	return []Announcement{}, nil
+}
+
+func (s *configStore) ListAllAnnouncements(context.Context, WorkspaceID, ChangelogID) ([]Announcement, error) {
+	return []Announcement{}, nil
+}
+
+var errWebhooksNotSupported = errs.NewError(errs.ErrBadRequest, errors.New("webhooks not supported in local config mode"))
+
+func (s *configStore) CreateWebhookEndpoint(context.Context, WebhookEndpoint) (WebhookEndpoint, error) {
+	return WebhookEndpoint{}, errWebhooksNotSupported
+}
+
+func (s *configStore) UpdateWebhookEndpoint(context.Context, WebhookEndpoint) (WebhookEndpoint, error) {
+	return WebhookEndpoint{}, errWebhooksNotSupported
+}
+
+func (s *configStore) DeleteWebhookEndpoint(context.Context, WorkspaceID, string) error {
+	return errWebhooksNotSupported
+}
+
+func (s *configStore) ListWebhookEndpoints(context.Context, WorkspaceID, ChangelogID) ([]WebhookEndpoint, error) {
+	return []WebhookEndpoint{}, nil
+}
+
+func (s *configStore) GetWebhookEndpoint(context.Context, WorkspaceID, string) (WebhookEndpoint, error) {
+	return WebhookEndpoint{}, errWebhooksNotSupported
+}
+
+func (s *configStore) RecordWebhookDelivery(context.Context, WebhookDelivery) error {
+	return errWebhooksNotSupported
+}
+
+func (s *configStore) ListWebhookDeliveries(context.Context, WorkspaceID, string, int, int) ([]WebhookDelivery, error) {
+	return []WebhookDelivery{}, nil
+}
+
+func (s *configStore) RetryFailedDeliveries(context.Context, string, int) ([]WebhookDelivery, error) {
+	return []WebhookDelivery{}, nil
+}
+
 func (s *configStore) ListWorkspacesChangelogCount(ctx context.Context) ([]WorkspaceChangelogCount, error) {
 	return []WorkspaceChangelogCount{}, errs.NewError(errs.ErrBadRequest, errors.New("list workspaces and changelog count not supported in local config mode"))
 }
+
+func (s *configStore) GetWorkspaceGrowthStats(context.Context, string, time.Time, time.Time) ([]GrowthDataPoint, error) {
+	return []GrowthDataPoint{}, errs.NewError(errs.ErrBadRequest, errors.New("workspace growth stats not supported in local config mode"))
+}
+
+func (s *configStore) GetWorkspaceCreationTimeline(context.Context, string, string, string) ([]TimelinePoint, error) {
+	return []TimelinePoint{}, errs.NewError(errs.ErrBadRequest, errors.New("workspace creation timeline not supported in local config mode"))
+}
+
+func (s *configStore) SearchWorkspaces(context.Context, string, int) ([]Workspace, error) {
+	return []Workspace{}, errs.NewError(errs.ErrBadRequest, errors.New("search workspaces not supported in local config mode"))
+}
+
+func (s *configStore) GetInstallationIDByWorkspace(context.Context, WorkspaceID) (int64, error) {
+	if s.cfg.Github == nil || s.cfg.Github.Auth == nil || s.cfg.Github.Auth.AppInstallationId == 0 {
+		return 0, errs.NewError(errs.ErrNotFound, errors.New("github installation not found"))
+	}
+	return s.cfg.Github.Auth.AppInstallationId, nil
+}
+
+func (s *configStore) SetInstallationIDForWorkspace(context.Context, WorkspaceID, int64) error {
+	return errs.NewError(errs.ErrBadRequest, errors.New("setting github installation not allowed in local config mode"))
+}
+
+func (s *configStore) GetInstallationToken(context.Context, int64) (string, error) {
+	return "", errs.NewError(errs.ErrNotFound, errors.New("installation token not found"))
+}
+
+// installation tokens aren't cached in local config mode
+func (s *configStore) SetInstallationToken(context.Context, int64, string, time.Time) error {
+	return nil
+}
+
+var errLabelsNotSupported = errs.NewError(errs.ErrBadRequest, errors.New("labels not supported in local config mode"))
+
+func (s *configStore) CreateLabel(context.Context, WorkspaceID, string) (Label, error) {
+	return Label{}, errLabelsNotSupported
+}
+
+func (s *configStore) DeleteLabel(context.Context, WorkspaceID, string) error {
+	return errLabelsNotSupported
+}
+
+func (s *configStore) AttachLabelToEntry(context.Context, WorkspaceID, ChangelogID, string, string) error {
+	return errLabelsNotSupported
+}
+
+func (s *configStore) DetachLabelFromEntry(context.Context, WorkspaceID, ChangelogID, string, string) error {
+	return errLabelsNotSupported
+}
+
+func (s *configStore) ListLabelsByEntry(context.Context, WorkspaceID, ChangelogID, string) ([]Label, error) {
+	return []Label{}, errLabelsNotSupported
+}
+
+func (s *configStore) BulkUpdateEntryLabels(context.Context, WorkspaceID, string, string) (int64, error) {
+	return 0, errLabelsNotSupported
+}
+
+func (s *configStore) ListEntryIDsByLabels(context.Context, WorkspaceID, ChangelogID, []string, LabelMatchMode) ([]string, error) {
+	return []string{}, errLabelsNotSupported
+}
+
+var errAttachmentsNotSupported = errs.NewError(errs.ErrBadRequest, errors.New("attachments not supported in local config mode"))
+
+func (s *configStore) AttachMediaToEntry(context.Context, WorkspaceID, ChangelogID, string, Attachment) (Attachment, error) {
+	return Attachment{}, errAttachmentsNotSupported
+}
+
+func (s *configStore) DetachMedia(context.Context, WorkspaceID, ChangelogID, string, string) error {
+	return errAttachmentsNotSupported
+}
+
+func (s *configStore) ListEntryAttachments(context.Context, WorkspaceID, ChangelogID, string) ([]Attachment, error) {
+	return []Attachment{}, nil
+}
+
+var errCommentsNotSupported = errs.NewError(errs.ErrBadRequest, errors.New("comments not supported in local config mode"))
+
+func (s *configStore) CreateComment(context.Context, Comment) (Comment, error) {
+	return Comment{}, errCommentsNotSupported
+}
+
+func (s *configStore) UpdateComment(context.Context, WorkspaceID, ChangelogID, string, string) (Comment, error) {
+	return Comment{}, errCommentsNotSupported
+}
+
+func (s *configStore) DeleteComment(context.Context, WorkspaceID, ChangelogID, string) error {
+	return errCommentsNotSupported
+}
+
+func (s *configStore) ResolveComment(context.Context, WorkspaceID, ChangelogID, string, bool) error {
+	return errCommentsNotSupported
+}
+
+func (s *configStore) ListComments(context.Context, WorkspaceID, ChangelogID, string) ([]Comment, error) {
+	return []Comment{}, nil
+}
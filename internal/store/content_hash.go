@@ -0,0 +1,18 @@
+package store
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+
+	"github.com/jonashiltl/openchangelog/internal/errs"
+)
+
+// Validates that hash is a hex encoded SHA-256 hash of the content of a changelog.
+func validateContentHash(hash string) error {
+	b, err := hex.DecodeString(hash)
+	if err != nil || len(b) != sha256.Size {
+		return errs.NewBadRequest(errors.New("content hash must be a hex encoded sha-256 hash"))
+	}
+	return nil
+}
@@ -0,0 +1,49 @@
+package store
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"testing"
+)
+
+func TestValidateContentHash(t *testing.T) {
+	hash := sha256.Sum256([]byte("# v1.0.0"))
+	tables := []struct {
+		name      string
+		hash      string
+		expectErr bool
+	}{
+		{
+			name: "sha-256",
+			hash: hex.EncodeToString(hash[:]),
+		},
+		{
+			name:      "empty",
+			hash:      "",
+			expectErr: true,
+		},
+		{
+			name:      "not hex",
+			hash:      strings.Repeat("z", 64),
+			expectErr: true,
+		},
+		{
+			name:      "too short",
+			hash:      hex.EncodeToString(hash[:16]),
+			expectErr: true,
+		},
+	}
+
+	for _, table := range tables {
+		t.Run(table.name, func(t *testing.T) {
+			err := validateContentHash(table.hash)
+			if table.expectErr && err == nil {
+				t.Error("expected to error but no error returned")
+			}
+			if !table.expectErr && err != nil {
+				t.Errorf("expected no error but got %s", err)
+			}
+		})
+	}
+}
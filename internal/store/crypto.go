@@ -0,0 +1,63 @@
+package store
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"errors"
+
+	"github.com/jonashiltl/openchangelog/internal/errs"
+)
+
+// length of the AES-256 key used to encrypt secrets
+const encryption_key_size = 32
+
+var errEncryptionKey = errs.NewInternalError(errors.New("encryption key must be 32 bytes"))
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	if len(key) != encryption_key_size {
+		return nil, errEncryptionKey
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, errs.NewInternalError(err)
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, errs.NewInternalError(err)
+	}
+	return gcm, nil
+}
+
+// Encrypts plaintext with AES-256-GCM, the random nonce is prepended to the ciphertext.
+func encrypt(key []byte, plaintext []byte) ([]byte, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := make([]byte, gcm.NonceSize())
+	_, err = rand.Read(nonce)
+	if err != nil {
+		return nil, errs.NewInternalError(err)
+	}
+	return gcm.Seal(nonce, nonce, plaintext, nil), nil
+}
+
+// Decrypts ciphertext created by encrypt.
+func decrypt(key []byte, ciphertext []byte) ([]byte, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(ciphertext) < gcm.NonceSize() {
+		return nil, errs.NewInternalError(errors.New("ciphertext too short"))
+	}
+	nonce, sealed := ciphertext[:gcm.NonceSize()], ciphertext[gcm.NonceSize():]
+	plaintext, err := gcm.Open(nil, nonce, sealed, nil)
+	if err != nil {
+		return nil, errs.NewInternalError(err)
+	}
+	return plaintext, nil
+}
@@ -0,0 +1,210 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+)
+
+type dashboardSeed struct {
+	changelogs      int
+	views           int
+	failedWebhooks  int
+	retriedWebhooks int
+	sources         int
+	degradedSources int
+}
+
+// Fills the workspace with the data shown on its dashboard.
+func seedDashboard(tb testing.TB, s *sqlite, wID WorkspaceID, seed dashboardSeed) {
+	tb.Helper()
+	ctx := context.Background()
+
+	_, err := s.SaveWorkspace(ctx, Workspace{ID: wID, Name: wID.String()})
+	if err != nil {
+		tb.Fatal(err)
+	}
+
+	var cID ChangelogID
+	for i := 0; i < seed.changelogs; i++ {
+		cl, err := s.CreateChangelog(ctx, Changelog{
+			ID:          NewCID(),
+			WorkspaceID: wID,
+			Subdomain:   NewSubdomain(wID.String()),
+			ColorScheme: System,
+		})
+		if err != nil {
+			tb.Fatal(err)
+		}
+		cID = cl.ID
+	}
+
+	for i := 0; i < seed.views; i++ {
+		err := s.AppendAccessLog(ctx, AccessLogEntry{
+			WorkspaceID: wID,
+			ChangelogID: cID,
+			VisitorHash: fmt.Sprintf("visitor-%d", i%50),
+		})
+		if err != nil {
+			tb.Fatal(err)
+		}
+	}
+
+	endpoint, err := s.CreateWebhookEndpoint(ctx, WebhookEndpoint{
+		WorkspaceID: wID,
+		ChangelogID: cID,
+		URL:         "https://hooks.slack.com/services/T000/B000/XXXX",
+		Events:      []WebhookEvent{WebhookEntryPublished},
+		Active:      true,
+	})
+	if err != nil {
+		tb.Fatal(err)
+	}
+	deliveredAt := time.Now().Add(-time.Hour)
+	deliver := func(payload string, success bool) {
+		deliveredAt = deliveredAt.Add(time.Second)
+		err := s.RecordWebhookDelivery(ctx, WebhookDelivery{
+			EndpointID:  endpoint.ID,
+			EventType:   WebhookEntryPublished,
+			Payload:     []byte(payload),
+			DeliveredAt: deliveredAt,
+			Success:     success,
+		})
+		if err != nil {
+			tb.Fatal(err)
+		}
+	}
+	for i := 0; i < seed.failedWebhooks; i++ {
+		deliver(fmt.Sprintf(`{"entry":"failed-%d"}`, i), false)
+	}
+	for i := 0; i < seed.retriedWebhooks; i++ {
+		payload := fmt.Sprintf(`{"entry":"retried-%d"}`, i)
+		deliver(payload, false)
+		deliver(payload, true)
+	}
+
+	for i := 0; i < seed.sources; i++ {
+		gh, err := s.CreateGHSource(ctx, GHSource{
+			ID:          NewGHID(),
+			WorkspaceID: wID,
+			Owner:       "acme",
+			Repo:        fmt.Sprintf("repo-%d", i),
+		})
+		if err != nil {
+			tb.Fatal(err)
+		}
+		failures := 1
+		if i < seed.degradedSources {
+			failures = gh_source_degraded_threshold
+		}
+		for j := 0; j < failures; j++ {
+			err = s.RecordGHSourceFailure(ctx, wID, gh.ID, errors.New("bad credentials"))
+			if err != nil {
+				tb.Fatal(err)
+			}
+		}
+	}
+}
+
+func TestGetWorkspaceDashboardSummary(t *testing.T) {
+	s := newTestSQLite(t)
+	wID := NewWID()
+	seedDashboard(t, s, wID, dashboardSeed{
+		changelogs:      3,
+		views:           10,
+		failedWebhooks:  2,
+		retriedWebhooks: 4,
+		sources:         3,
+		degradedSources: 1,
+	})
+	// data of other workspaces must not be counted
+	seedDashboard(t, s, NewWID(), dashboardSeed{
+		changelogs:      1,
+		views:           5,
+		failedWebhooks:  1,
+		sources:         1,
+		degradedSources: 1,
+	})
+
+	summary, err := s.GetWorkspaceDashboardSummary(context.Background(), wID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := DashboardSummary{
+		ChangelogCount:       3,
+		TotalViewsLast30Days: 10,
+		UnprocessedWebhooks:  2,
+		DegradedSources:      1,
+	}
+	if summary != expected {
+		t.Errorf("expected %+v to equal %+v", summary, expected)
+	}
+}
+
+// The queries the dashboard made before GetWorkspaceDashboardSummary, one per metric.
+var dashboardSummaryQueries = []string{
+	`SELECT COUNT(*) FROM changelogs WHERE workspace_id = ?1`,
+	`SELECT COUNT(*) FROM changelog_access_log WHERE workspace_id = ?1 AND accessed_at >= ?2`,
+	`SELECT COUNT(*) FROM webhook_deliveries d
+	JOIN webhook_endpoints e ON e.id = d.endpoint_id
+	WHERE e.workspace_id = ?1 AND d.success = 0 AND e.active = 1
+	AND NOT EXISTS (
+		SELECT 1 FROM webhook_deliveries l
+		WHERE l.endpoint_id = d.endpoint_id
+		AND l.event_type = d.event_type
+		AND l.payload_hash = d.payload_hash
+		AND (l.delivered_at > d.delivered_at OR (l.delivered_at = d.delivered_at AND l.id > d.id))
+	)`,
+	`SELECT COUNT(*) FROM gh_sources gh
+	JOIN gh_source_health h ON gh.workspace_id = h.workspace_id AND gh.id = h.gh_source_id
+	WHERE gh.workspace_id = ?1 AND h.consecutive_failures >= ?3`,
+}
+
+var benchmarkDashboardSeed = dashboardSeed{
+	changelogs:      20,
+	views:           5000,
+	failedWebhooks:  200,
+	retriedWebhooks: 400,
+	sources:         20,
+	degradedSources: 5,
+}
+
+// Compares the single dashboard query against querying each metric separately,
+// run with go test -bench Dashboard ./internal/store.
+func BenchmarkDashboardSummary(b *testing.B) {
+	s := newTestSQLite(b)
+	wID := NewWID()
+	seedDashboard(b, s, wID, benchmarkDashboardSeed)
+	ctx := context.Background()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, err := s.GetWorkspaceDashboardSummary(ctx, wID)
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkDashboardSummaryNQueries(b *testing.B) {
+	s := newTestSQLite(b)
+	wID := NewWID()
+	seedDashboard(b, s, wID, benchmarkDashboardSeed)
+	ctx := context.Background()
+	viewsSince := time.Now().AddDate(0, 0, -30).Unix()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for _, query := range dashboardSummaryQueries {
+			var n int64
+			err := s.db.QueryRowContext(ctx, query, wID.String(), viewsSince, gh_source_degraded_threshold).Scan(&n)
+			if err != nil && !errors.Is(err, sql.ErrNoRows) {
+				b.Fatal(err)
+			}
+		}
+	}
+}
@@ -0,0 +1,49 @@
+package store
+
+import (
+	"strconv"
+)
+
+type FieldDiff struct {
+	Before string
+	After  string
+}
+
+// Maps the names of the changed Changelog fields to their before and after values.
+type ChangelogDiff map[string]FieldDiff
+
+// Compares the settings of before and after and returns the fields that differ.
+// Ids, timestamps, sources and the password hash are not compared.
+func diffChangelogs(before, after Changelog) ChangelogDiff {
+	fields := []struct {
+		name          string
+		before, after string
+	}{
+		{"Subdomain", before.Subdomain.String(), after.Subdomain.String()},
+		{"Domain", before.Domain.String(), after.Domain.String()},
+		{"Title", before.Title.V(), after.Title.V()},
+		{"Subtitle", before.Subtitle.V(), after.Subtitle.V()},
+		{"LogoSrc", before.LogoSrc.V(), after.LogoSrc.V()},
+		{"LogoLink", before.LogoLink.V(), after.LogoLink.V()},
+		{"LogoAlt", before.LogoAlt.V(), after.LogoAlt.V()},
+		{"LogoHeight", before.LogoHeight.V(), after.LogoHeight.V()},
+		{"LogoWidth", before.LogoWidth.V(), after.LogoWidth.V()},
+		{"FaviconSrc", before.FaviconSrc.V(), after.FaviconSrc.V()},
+		{"ColorScheme", before.ColorScheme.String(), after.ColorScheme.String()},
+		{"Analytics", strconv.FormatBool(before.Analytics), strconv.FormatBool(after.Analytics)},
+		{"HidePoweredBy", strconv.FormatBool(before.HidePoweredBy), strconv.FormatBool(after.HidePoweredBy)},
+		{"Protected", strconv.FormatBool(before.Protected), strconv.FormatBool(after.Protected)},
+		{"Searchable", strconv.FormatBool(before.Searchable), strconv.FormatBool(after.Searchable)},
+	}
+
+	diff := ChangelogDiff{}
+	for _, f := range fields {
+		if f.before != f.after {
+			diff[f.name] = FieldDiff{
+				Before: f.before,
+				After:  f.after,
+			}
+		}
+	}
+	return diff
+}
@@ -0,0 +1,42 @@
+package store
+
+import (
+	"testing"
+
+	"github.com/jonashiltl/openchangelog/apitypes"
+)
+
+func TestDiffChangelogs(t *testing.T) {
+	before := Changelog{
+		Subdomain:   "acme",
+		Title:       apitypes.NewString("Acme"),
+		ColorScheme: Dark,
+	}
+	after := Changelog{
+		Subdomain:   "acme",
+		Title:       apitypes.NewString("Acme Changelog"),
+		LogoSrc:     apitypes.NewString("https://acme.com/logo.png"),
+		ColorScheme: Dark,
+		Analytics:   true,
+	}
+
+	diff := diffChangelogs(before, after)
+	expected := ChangelogDiff{
+		"Title":     {Before: "Acme", After: "Acme Changelog"},
+		"LogoSrc":   {Before: "", After: "https://acme.com/logo.png"},
+		"Analytics": {Before: "false", After: "true"},
+	}
+
+	if len(diff) != len(expected) {
+		t.Fatalf("expected %d changed fields but got %d: %v", len(expected), len(diff), diff)
+	}
+	for field, d := range expected {
+		if diff[field] != d {
+			t.Errorf("expected %s to be %v but got %v", field, d, diff[field])
+		}
+	}
+
+	if d := diffChangelogs(before, before); len(d) != 0 {
+		t.Errorf("expected no diff but got %v", d)
+	}
+}
@@ -103,3 +103,26 @@ func SubdomainFromHost(host string) (Subdomain, error) {
 	}
 	return "", errs.NewBadRequest(errors.New("host has no subdomain"))
 }
+
+// Returns the canonical url of a changelog, the custom domain is preferred over the subdomain.
+// Subdomain urls are built from baseURL, e.g. http://localhost:6001 in development.
+func publicChangelogURL(baseURL string, domain Domain, subdomain Subdomain) (string, error) {
+	if domain.String() != "" {
+		return "https://" + domain.String(), nil
+	}
+
+	base, err := url.Parse(baseURL)
+	if baseURL == "" || err != nil || base.Host == "" {
+		return "", errs.NewBadRequest(errors.New("base url is not configured"))
+	}
+	return fmt.Sprintf("%s://%s.%s", base.Scheme, subdomain, base.Host), nil
+}
+
+// Validates a canonical url set by the user, it must be an absolute http(s) url.
+func validateCanonicalURL(canonicalURL string) error {
+	u, err := url.Parse(canonicalURL)
+	if err != nil || (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" {
+		return errs.NewBadRequest(errors.New("canonical url must be an absolute http or https url"))
+	}
+	return nil
+}
@@ -108,3 +108,91 @@ func TestParseSubdomain(t *testing.T) {
 		})
 	}
 }
+
+func TestPublicChangelogURL(t *testing.T) {
+	tables := []struct {
+		name      string
+		baseURL   string
+		domain    Domain
+		subdomain Subdomain
+		expected  string
+		expectErr bool
+	}{
+		{
+			name:      "custom domain",
+			baseURL:   "https://openchangelog.com",
+			domain:    Domain(apitypes.NewString("changelog.acme.com")),
+			subdomain: "acme",
+			expected:  "https://changelog.acme.com",
+		},
+		{
+			name:      "subdomain",
+			baseURL:   "https://openchangelog.com",
+			subdomain: "acme",
+			expected:  "https://acme.openchangelog.com",
+		},
+		{
+			name:      "development",
+			baseURL:   "http://localhost:6001",
+			subdomain: "acme",
+			expected:  "http://acme.localhost:6001",
+		},
+		{
+			name:      "custom domain without base url",
+			domain:    Domain(apitypes.NewString("changelog.acme.com")),
+			subdomain: "acme",
+			expected:  "https://changelog.acme.com",
+		},
+		{
+			name:      "missing base url",
+			subdomain: "acme",
+			expectErr: true,
+		},
+	}
+
+	for _, table := range tables {
+		t.Run(table.name, func(t *testing.T) {
+			u, err := publicChangelogURL(table.baseURL, table.domain, table.subdomain)
+			if table.expectErr {
+				if err == nil {
+					t.Error("expected to error but no error returned")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("expected no error but got %s", err)
+			}
+			if u != table.expected {
+				t.Errorf("expected %s to equal %s", u, table.expected)
+			}
+		})
+	}
+}
+
+func TestValidateCanonicalURL(t *testing.T) {
+	tables := []struct {
+		name      string
+		url       string
+		expectErr bool
+	}{
+		{name: "https", url: "https://changelog.acme.com"},
+		{name: "with path", url: "https://acme.com/changelog"},
+		{name: "http", url: "http://localhost:6001"},
+		{name: "relative", url: "/changelog", expectErr: true},
+		{name: "missing scheme", url: "acme.com", expectErr: true},
+		{name: "other scheme", url: "ftp://acme.com", expectErr: true},
+		{name: "missing host", url: "https://", expectErr: true},
+	}
+
+	for _, table := range tables {
+		t.Run(table.name, func(t *testing.T) {
+			err := validateCanonicalURL(table.url)
+			if table.expectErr && err == nil {
+				t.Error("expected to error but no error returned")
+			}
+			if !table.expectErr && err != nil {
+				t.Errorf("expected no error but got %s", err)
+			}
+		})
+	}
+}
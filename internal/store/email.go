@@ -0,0 +1,98 @@
+package store
+
+import (
+	"errors"
+	"fmt"
+	"net/mail"
+	"time"
+
+	"github.com/jonashiltl/openchangelog/internal/errs"
+)
+
+const email_delivery_prefix = "em"
+
+type EmailStatus string
+
+const (
+	EmailSent   EmailStatus = "sent"
+	EmailFailed EmailStatus = "failed"
+)
+
+// SMTP server and sender used for the transactional emails of a workspace.
+type EmailSettings struct {
+	FromEmail string
+	FromName  string
+	SMTPHost  string
+	SMTPPort  int
+	SMTPUser  string
+	// Stored encrypted. The stored password is kept if empty.
+	SMTPPassword string
+	// Optional, replies go to FromEmail if empty.
+	ReplyTo string
+}
+
+// A transactional email sent on behalf of a changelog.
+type EmailDelivery struct {
+	// Generated if empty. Recording a delivery with the id of an existing one replaces it,
+	// so a retried job can use a stable id.
+	ID          string
+	WorkspaceID WorkspaceID
+	ChangelogID ChangelogID
+	ToEmail     string
+	Subject     string
+	Template    string
+	// Defaults to the current time if zero.
+	SentAt time.Time
+	Status EmailStatus
+	// Reason the email couldn't be sent.
+	Error string
+}
+
+var errNoEmailSettings = errs.NewError(errs.ErrNotFound, errors.New("email settings not found"))
+var errNoEmailDelivery = errs.NewError(errs.ErrNotFound, errors.New("email delivery not found"))
+
+// Validates the settings and normalizes the email addresses.
+func (e EmailSettings) parse() (EmailSettings, error) {
+	from, err := mail.ParseAddress(e.FromEmail)
+	if err != nil {
+		return EmailSettings{}, errs.NewBadRequest(errors.New("from email is not valid"))
+	}
+	e.FromEmail = from.Address
+
+	if e.ReplyTo != "" {
+		replyTo, err := mail.ParseAddress(e.ReplyTo)
+		if err != nil {
+			return EmailSettings{}, errs.NewBadRequest(errors.New("reply to email is not valid"))
+		}
+		e.ReplyTo = replyTo.Address
+	}
+
+	if e.SMTPHost == "" {
+		return EmailSettings{}, errs.NewBadRequest(errors.New("smtp host is required"))
+	}
+	if e.SMTPPort < 1 || e.SMTPPort > 65535 {
+		return EmailSettings{}, errs.NewBadRequest(errors.New("smtp port must be between 1 and 65535"))
+	}
+	return e, nil
+}
+
+func (d EmailDelivery) validate() error {
+	if d.Status != EmailSent && d.Status != EmailFailed {
+		return errs.NewBadRequest(fmt.Errorf("email status must be %s or %s", EmailSent, EmailFailed))
+	}
+	return nil
+}
+
+func (d emailDelivery) toExported() EmailDelivery {
+	return EmailDelivery{
+		ID:          d.ID,
+		WorkspaceID: WorkspaceID(d.WorkspaceID),
+		ChangelogID: ChangelogID(d.ChangelogID),
+		ToEmail:     d.ToEmail,
+		Subject:     d.Subject,
+		Template:    d.Template,
+		SentAt:      time.Unix(d.SentAt, 0),
+		Status:      EmailStatus(d.Status),
+		Error:       d.Error.V(),
+	}
+}
@@ -0,0 +1,65 @@
+package store
+
+import "testing"
+
+func TestEmailDeliveryValidate(t *testing.T) {
+	tables := []struct {
+		status    EmailStatus
+		expectErr bool
+	}{
+		{status: EmailSent},
+		{status: EmailFailed},
+		{status: "", expectErr: true},
+		{status: "bounced", expectErr: true},
+	}
+
+	for _, table := range tables {
+		t.Run(string(table.status), func(t *testing.T) {
+			err := EmailDelivery{Status: table.status}.validate()
+			if table.expectErr && err == nil {
+				t.Error("expected to error but no error returned")
+			}
+			if !table.expectErr && err != nil {
+				t.Errorf("expected no error but got %s", err)
+			}
+		})
+	}
+}
+
+func TestEmailSettingsParse(t *testing.T) {
+	valid := EmailSettings{
+		FromEmail: "Acme <news@acme.com>",
+		SMTPHost:  "smtp.acme.com",
+		SMTPPort:  587,
+		ReplyTo:   "support@acme.com",
+	}
+
+	parsed, err := valid.parse()
+	if err != nil {
+		t.Fatalf("expected settings to be valid, got %s", err)
+	}
+	if parsed.FromEmail != "news@acme.com" {
+		t.Errorf("expected %s to equal news@acme.com", parsed.FromEmail)
+	}
+
+	tables := []struct {
+		name   string
+		modify func(*EmailSettings)
+	}{
+		{name: "invalid from", modify: func(e *EmailSettings) { e.FromEmail = "acme" }},
+		{name: "invalid reply to", modify: func(e *EmailSettings) { e.ReplyTo = "support" }},
+		{name: "missing host", modify: func(e *EmailSettings) { e.SMTPHost = "" }},
+		{name: "invalid port", modify: func(e *EmailSettings) { e.SMTPPort = 70000 }},
+	}
+
+	for _, table := range tables {
+		t.Run(table.name, func(t *testing.T) {
+			settings := valid
+			table.modify(&settings)
+			_, err := settings.parse()
+			if err == nil {
+				t.Error("expected settings to be invalid")
+			}
+		})
+	}
+}
@@ -0,0 +1,31 @@
+package store
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+
+	"github.com/jonashiltl/openchangelog/internal/errs"
+)
+
+const (
+	max_i18n_key_length   = 64
+	max_i18n_value_length = 256
+)
+
+// keys are dot separated identifiers, e.g. entry.read_more
+var i18nKeyRegex = regexp.MustCompile(`^[a-z0-9_]+(\.[a-z0-9_]+)*$`)
+
+// Validates an override of a ui text of a changelog.
+func validateI18nString(key, value string) error {
+	if len(key) > max_i18n_key_length || !i18nKeyRegex.MatchString(key) {
+		return errs.NewBadRequest(errors.New("invalid i18n key, use lowercase identifiers separated by dots"))
+	}
+	if value == "" {
+		return errs.NewBadRequest(errors.New("i18n value can't be empty"))
+	}
+	if len(value) > max_i18n_value_length {
+		return errs.NewBadRequest(fmt.Errorf("i18n value can't be longer than %d characters", max_i18n_value_length))
+	}
+	return nil
+}
@@ -0,0 +1,36 @@
+package store
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidateI18nString(t *testing.T) {
+	tables := []struct {
+		name  string
+		key   string
+		value string
+		valid bool
+	}{
+		{name: "simple", key: "subscribe", value: "Abonnieren", valid: true},
+		{name: "nested", key: "entry.read_more", value: "Weiterlesen", valid: true},
+		{name: "empty key", key: "", value: "x", valid: false},
+		{name: "uppercase key", key: "Entry.ReadMore", value: "x", valid: false},
+		{name: "trailing dot", key: "entry.", value: "x", valid: false},
+		{name: "long key", key: strings.Repeat("a", max_i18n_key_length+1), value: "x", valid: false},
+		{name: "empty value", key: "share", value: "", valid: false},
+		{name: "long value", key: "share", value: strings.Repeat("a", max_i18n_value_length+1), valid: false},
+	}
+
+	for _, table := range tables {
+		t.Run(table.name, func(t *testing.T) {
+			err := validateI18nString(table.key, table.value)
+			if table.valid && err != nil {
+				t.Errorf("expected %s to be valid, got %s", table.key, err)
+			}
+			if !table.valid && err == nil {
+				t.Errorf("expected %s to be invalid", table.key)
+			}
+		})
+	}
+}
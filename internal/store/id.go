@@ -109,3 +109,9 @@ func IsGHID(id string) bool {
 func (i GHSourceID) String() string {
 	return string(i)
 }
+
+// Creates a new random id with the specified prefix,
+// used for entities that don't need their own id type.
+func newID(prefix string) string {
+	return prefix + id_separator + xid.New().String()
+}
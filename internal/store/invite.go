@@ -0,0 +1,24 @@
+package store
+
+import (
+	"errors"
+	"net/mail"
+
+	"github.com/jonashiltl/openchangelog/internal/errs"
+)
+
+var errInvalidInvite = errs.NewError(errs.ErrUnauthorized, errors.New("invalid invite"))
+
+// Normalizes the email address and checks that the role can be granted by an invite.
+func parseInvite(email, role string) (string, error) {
+	addr, err := mail.ParseAddress(email)
+	if err != nil {
+		return "", errs.NewBadRequest(errors.New("email is not valid"))
+	}
+	switch MemberRole(role) {
+	case MemberEditor, MemberViewer:
+	default:
+		return "", errs.NewBadRequest(errors.New("role must be one of editor or viewer"))
+	}
+	return addr.Address, nil
+}
@@ -0,0 +1,45 @@
+package store
+
+import "testing"
+
+func TestParseInvite(t *testing.T) {
+	tables := []struct {
+		email     string
+		role      string
+		expected  string
+		expectErr bool
+	}{
+		{
+			email:    "jane@openchangelog.com",
+			role:     MemberViewer.String(),
+			expected: "jane@openchangelog.com",
+		},
+		{
+			email:    "Jane <jane@openchangelog.com>",
+			role:     MemberEditor.String(),
+			expected: "jane@openchangelog.com",
+		},
+		{
+			email:     "jane",
+			role:      MemberViewer.String(),
+			expectErr: true,
+		},
+		{
+			email:     "jane@openchangelog.com",
+			role:      MemberOwner.String(),
+			expectErr: true,
+		},
+	}
+
+	for _, table := range tables {
+		t.Run(table.email+" "+table.role, func(t *testing.T) {
+			email, err := parseInvite(table.email, table.role)
+			if table.expectErr && err == nil {
+				t.Error("expected to error but no error returned")
+			}
+			if email != table.expected {
+				t.Errorf("expected %s to equal %s", email, table.expected)
+			}
+		})
+	}
+}
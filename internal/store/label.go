@@ -0,0 +1,39 @@
+package store
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/jonashiltl/openchangelog/internal/errs"
+)
+
+const max_label_length = 64
+
+type Label struct {
+	WorkspaceID WorkspaceID
+	Name        string
+	CreatedAt   time.Time
+}
+
+// Defines how multiple labels are combined when filtering entries.
+type LabelMatchMode int
+
+const (
+	// Matches entries which have at least one of the labels.
+	AnyOf LabelMatchMode = iota
+	// Matches entries which have every one of the labels.
+	AllOf
+)
+
+// Normalizes the label name, labels are case insensitive and can't be empty.
+func ParseLabel(name string) (string, error) {
+	n := strings.ToLower(strings.TrimSpace(name))
+	if n == "" {
+		return "", errs.NewBadRequest(errors.New("label name can't be empty"))
+	}
+	if len(n) > max_label_length {
+		return "", errs.NewBadRequest(errors.New("label name is too long"))
+	}
+	return n, nil
+}
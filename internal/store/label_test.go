@@ -0,0 +1,44 @@
+package store
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseLabel(t *testing.T) {
+	tables := []struct {
+		input    string
+		expected string
+		hasErr   bool
+	}{
+		{
+			input:    "breaking-change",
+			expected: "breaking-change",
+		},
+		{
+			input:    "  Deprecation ",
+			expected: "deprecation",
+		},
+		{
+			input:  "   ",
+			hasErr: true,
+		},
+		{
+			input:  strings.Repeat("a", max_label_length+1),
+			hasErr: true,
+		},
+	}
+
+	for _, table := range tables {
+		l, err := ParseLabel(table.input)
+		if table.hasErr && err == nil {
+			t.Errorf("expected error for %s", table.input)
+		}
+		if !table.hasErr && err != nil {
+			t.Error(err)
+		}
+		if l != table.expected {
+			t.Errorf("expected %s to equal %s", l, table.expected)
+		}
+	}
+}
@@ -0,0 +1,46 @@
+package store
+
+import (
+	"errors"
+	"time"
+
+	"github.com/jonashiltl/openchangelog/internal/errs"
+)
+
+type MemberRole string
+
+const (
+	MemberOwner  MemberRole = "owner"
+	MemberEditor MemberRole = "editor"
+	MemberViewer MemberRole = "viewer"
+)
+
+func ParseMemberRole(role string) (MemberRole, error) {
+	switch r := MemberRole(role); r {
+	case MemberOwner, MemberEditor, MemberViewer:
+		return r, nil
+	}
+	return "", errs.NewBadRequest(errors.New("role must be one of owner, editor or viewer"))
+}
+
+func (r MemberRole) String() string {
+	return string(r)
+}
+
+type WorkspaceMember struct {
+	WorkspaceID WorkspaceID
+	UserID      string
+	Role        MemberRole
+	JoinedAt    time.Time
+}
+
+var errNoMember = errs.NewError(errs.ErrNotFound, errors.New("workspace member not found"))
+
+func (m workspaceMember) toExported() WorkspaceMember {
+	return WorkspaceMember{
+		WorkspaceID: WorkspaceID(m.WorkspaceID),
+		UserID:      m.UserID,
+		Role:        MemberRole(m.Role),
+		JoinedAt:    time.Unix(m.JoinedAt, 0),
+	}
+}
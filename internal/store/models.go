@@ -10,6 +10,13 @@ import (
 	"github.com/jonashiltl/openchangelog/apitypes"
 )
 
+type apiRequest struct {
+	WorkspaceID string
+	TokenKey    apitypes.NullString
+	Endpoint    string
+	RequestedAt int64
+}
+
 type changelog struct {
 	ID            string
 	WorkspaceID   string
@@ -30,32 +37,343 @@ type changelog struct {
 	PasswordHash  apitypes.NullString
 	Analytics     int64
 	Searchable    int64
+	FaviconSrc    apitypes.NullString
+	SortOrder     int64
+	SocialLinks   apitypes.NullString
+	RobotsTxt     apitypes.NullString
+	Slug          apitypes.NullString
+	CanonicalUrl  apitypes.NullString
+}
+
+type changelogAccessLog struct {
+	ID          string
+	WorkspaceID string
+	ChangelogID string
+	VisitorHash string
+	UserAgent   apitypes.NullString
+	Referer     apitypes.NullString
+	AccessedAt  int64
+}
+
+type changelogAnnouncement struct {
+	ID          string
+	WorkspaceID string
+	ChangelogID string
+	Message     string
+	CtaUrl      apitypes.NullString
+	StartsAt    int64
+	EndsAt      int64
+	CreatedAt   int64
+}
+
+type changelogAsset struct {
+	ID          string
+	WorkspaceID string
+	ChangelogID string
+	AssetType   string
+	ContentType string
+	Data        []byte
+	CreatedAt   int64
+}
+
+type changelogContentHash struct {
+	WorkspaceID string
+	ChangelogID string
+	Hash        string
+	UpdatedAt   int64
+}
+
+type changelogCustomDomain struct {
+	ID          string
+	WorkspaceID string
+	ChangelogID string
+	Domain      string
+	Verified    int64
+	CreatedAt   int64
+}
+
+type changelogI18n struct {
+	WorkspaceID string
+	ChangelogID string
+	Key         string
+	Value       string
+}
+
+type changelogOgImage struct {
+	WorkspaceID string
+	ChangelogID string
+	Image       []byte
+	GeneratedAt int64
+	Etag        string
+}
+
+type changelogPreviewToken struct {
+	TokenHash   string
+	ChangelogID string
+	WorkspaceID string
+	CreatedAt   int64
+	ExpiresAt   int64
+}
+
+type changelogRssToken struct {
+	TokenHash   string
+	ChangelogID string
+	WorkspaceID string
+	CreatedAt   int64
+}
+
+type changelogSnapshot struct {
+	ID          string
+	WorkspaceID string
+	ChangelogID string
+	Snapshot    []byte
+	CreatedAt   int64
 }
 
 type changelogSource struct {
-	ID             apitypes.NullString
-	WorkspaceID    apitypes.NullString
-	Owner          apitypes.NullString
-	Repo           apitypes.NullString
-	Path           apitypes.NullString
-	InstallationID sql.NullInt64
+	ID                   apitypes.NullString
+	WorkspaceID          apitypes.NullString
+	Owner                apitypes.NullString
+	Repo                 apitypes.NullString
+	Path                 apitypes.NullString
+	InstallationID       sql.NullInt64
+	LastFetchedAt        sql.NullInt64
+	FetchIntervalSeconds sql.NullInt64
+	PrivateKeyID         apitypes.NullString
+	PrivateKeyBlob       []byte
+	WebhookSecret        apitypes.NullString
 }
 
-type ghSource struct {
-	ID             string
-	WorkspaceID    string
-	Owner          string
-	Repo           string
-	Path           string
+type changelogTheme struct {
+	WorkspaceID     string
+	ChangelogID     string
+	FontFamily      apitypes.NullString
+	AccentColor     apitypes.NullString
+	BackgroundColor apitypes.NullString
+	TextColor       apitypes.NullString
+	BorderRadiusPx  sql.NullInt64
+	CustomCss       apitypes.NullString
+}
+
+type changelogToken struct {
+	TokenHash   string
+	ChangelogID string
+	WorkspaceID string
+	CreatedAt   int64
+}
+
+type domainVerification struct {
+	WorkspaceID   string
+	ChangelogID   string
+	Domain        string
+	CnameTarget   string
+	VerifiedAt    sql.NullInt64
+	LastCheckedAt sql.NullInt64
+	CheckError    apitypes.NullString
+}
+
+type emailDelivery struct {
+	ID          string
+	WorkspaceID string
+	ChangelogID string
+	ToEmail     string
+	Subject     string
+	Template    string
+	SentAt      int64
+	Status      string
+	Error       apitypes.NullString
+}
+
+type entryAttachment struct {
+	ID          string
+	WorkspaceID string
+	ChangelogID string
+	EntryID     string
+	Filename    string
+	MimeType    string
+	SizeBytes   int64
+	Url         string
+	CreatedAt   int64
+}
+
+type entryComment struct {
+	ID          string
+	WorkspaceID string
+	ChangelogID string
+	EntryID     string
+	AuthorID    string
+	Body        string
+	CreatedAt   int64
+	UpdatedAt   int64
+	Resolved    int64
+}
+
+type entryLabel struct {
+	WorkspaceID string
+	ChangelogID string
+	EntryID     string
+	LabelName   string
+}
+
+type ghInstallationToken struct {
 	InstallationID int64
+	Token          string
+	ExpiresAt      int64
+}
+
+type ghSource struct {
+	ID                   string
+	WorkspaceID          string
+	Owner                string
+	Repo                 string
+	Path                 string
+	InstallationID       int64
+	LastFetchedAt        int64
+	FetchIntervalSeconds int64
+	PrivateKeyID         apitypes.NullString
+	PrivateKeyBlob       []byte
+	WebhookSecret        apitypes.NullString
+}
+
+type ghSourceFileCache struct {
+	WorkspaceID string
+	GhSourceID  string
+	Path        string
+	Sha         string
+}
+
+type ghSourceHealth struct {
+	WorkspaceID         string
+	GhSourceID          string
+	LastError           apitypes.NullString
+	ConsecutiveFailures int64
+	LastCheckedAt       int64
+}
+
+type ghSourceTreeCache struct {
+	WorkspaceID string
+	GhSourceID  string
+	TreeJson    []byte
+	CachedAt    int64
+	Etag        string
+}
+
+type ghSyncLog struct {
+	ID           string
+	WorkspaceID  string
+	GhSourceID   string
+	StartedAt    int64
+	FinishedAt   int64
+	FilesFound   int64
+	FilesChanged int64
+	Error        apitypes.NullString
+}
+
+type label struct {
+	WorkspaceID string
+	Name        string
+	CreatedAt   int64
+}
+
+type searchQuery struct {
+	WorkspaceID string
+	ChangelogID string
+	Query       string
+	ResultCount int64
+	SearchedAt  int64
 }
 
 type token struct {
 	Key         string
 	WorkspaceID string
+	CreatedAt   int64
+	ExpiresAt   sql.NullInt64
+	Scopes      apitypes.NullString
+	Label       apitypes.NullString
+	LastUsedAt  sql.NullInt64
+	ID          apitypes.NullString
+}
+
+type webhookDelivery struct {
+	ID           string
+	EndpointID   string
+	EventType    string
+	Payload      []byte
+	PayloadHash  string
+	StatusCode   sql.NullInt64
+	ResponseBody apitypes.NullString
+	DeliveredAt  int64
+	DurationMs   int64
+	Success      int64
+}
+
+type webhookEndpoint struct {
+	ID          string
+	ChangelogID string
+	WorkspaceID string
+	Url         string
+	Secret      string
+	Events      string
+	Active      int64
+	CreatedAt   int64
 }
 
 type workspace struct {
-	ID   string
-	Name string
+	ID            string
+	Name          string
+	CreatedAt     int64
+	WebhookSecret apitypes.NullString
+}
+
+type workspaceBilling struct {
+	WorkspaceID        string
+	CustomerID         string
+	SubscriptionID     apitypes.NullString
+	SubscriptionStatus apitypes.NullString
+	CurrentPeriodEnd   sql.NullInt64
+}
+
+type workspaceEmailSetting struct {
+	WorkspaceID string
+	FromEmail   string
+	FromName    apitypes.NullString
+	SmtpHost    string
+	SmtpPort    int64
+	SmtpUser    apitypes.NullString
+	SmtpPassEnc []byte
+	ReplyTo     apitypes.NullString
+}
+
+type workspaceGhInstallation struct {
+	WorkspaceID    string
+	InstallationID int64
+	InstalledAt    int64
+}
+
+type workspaceInvite struct {
+	TokenHash   string
+	WorkspaceID string
+	Email       string
+	Role        string
+	CreatedAt   int64
+	ExpiresAt   int64
+	AcceptedAt  sql.NullInt64
+}
+
+type workspaceMember struct {
+	WorkspaceID string
+	UserID      string
+	Role        string
+	JoinedAt    int64
+}
+
+type workspaceSso struct {
+	WorkspaceID     string
+	Provider        string
+	IssuerUrl       string
+	ClientID        apitypes.NullString
+	ClientSecretEnc []byte
+	Certificate     apitypes.NullString
+	Enabled         int64
+	CreatedAt       int64
 }
@@ -0,0 +1,48 @@
+package store
+
+import (
+	"errors"
+	"net/url"
+
+	"github.com/jonashiltl/openchangelog/internal/errs"
+)
+
+// The response of an oEmbed link type, see https://oembed.com
+type OEmbedResponse struct {
+	Type         string `json:"type"`
+	Version      string `json:"version"`
+	Title        string `json:"title,omitempty"`
+	AuthorName   string `json:"author_name,omitempty"`
+	ProviderName string `json:"provider_name"`
+	ThumbnailURL string `json:"thumbnail_url,omitempty"`
+}
+
+// Returns the domain and subdomain of the changelog the url points to.
+func parseChangelogURL(rawURL string) (Domain, Subdomain, error) {
+	parsed, err := url.Parse(rawURL)
+	if err != nil || parsed.Host == "" {
+		return Domain{}, "", errs.NewBadRequest(errors.New("url is not valid"))
+	}
+
+	subdomain, err1 := SubdomainFromHost(parsed.Host)
+	domain, err2 := ParseDomain(parsed.Host)
+	if err1 != nil && err2 != nil {
+		return Domain{}, "", errs.NewBadRequest(errors.New("host & subdomain is not a valid url"))
+	}
+	return domain, subdomain, nil
+}
+
+func newOEmbedResponse(cl Changelog, authorName string) OEmbedResponse {
+	title := cl.Title.V()
+	if title == "" {
+		title = cl.Subdomain.String()
+	}
+	return OEmbedResponse{
+		Type:         "link",
+		Version:      "1.0",
+		Title:        title,
+		AuthorName:   authorName,
+		ProviderName: "Openchangelog",
+		ThumbnailURL: cl.LogoSrc.V(),
+	}
+}
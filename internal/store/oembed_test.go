@@ -0,0 +1,57 @@
+package store
+
+import "testing"
+
+func TestParseChangelogURL(t *testing.T) {
+	tables := []struct {
+		url               string
+		expectedDomain    string
+		expectedSubdomain string
+		expectErr         bool
+	}{
+		{
+			url:               "https://tenant.openchangelog.com/",
+			expectedDomain:    "tenant.openchangelog.com",
+			expectedSubdomain: "tenant",
+		},
+		{
+			url:            "https://changelog.example.com/release/v1",
+			expectedDomain: "changelog.example.com",
+			// the subdomain of a custom domain doesn't match any changelog, the domain is used instead
+			expectedSubdomain: "changelog",
+		},
+		{
+			url:            "https://example.com",
+			expectedDomain: "example.com",
+		},
+		{
+			url:       "tenant.openchangelog.com",
+			expectErr: true,
+		},
+		{
+			url:       "://",
+			expectErr: true,
+		},
+	}
+
+	for _, table := range tables {
+		t.Run(table.url, func(t *testing.T) {
+			d, s, err := parseChangelogURL(table.url)
+			if table.expectErr {
+				if err == nil {
+					t.Error("expected to error but no error returned")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if d.String() != table.expectedDomain {
+				t.Errorf("expected domain %s to equal %s", d.String(), table.expectedDomain)
+			}
+			if s.String() != table.expectedSubdomain {
+				t.Errorf("expected subdomain %s to equal %s", s.String(), table.expectedSubdomain)
+			}
+		})
+	}
+}
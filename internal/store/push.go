@@ -0,0 +1,20 @@
+package store
+
+// Returns the paths of a push which have to be fetched from GitHub again,
+// the added and modified paths without duplicates in the order they were pushed.
+// Removed paths are left out, they can't be fetched anymore.
+func pushedPaths(added, modified, removed []string) []string {
+	seen := make(map[string]bool, len(added)+len(modified)+len(removed))
+	for _, p := range removed {
+		seen[p] = true
+	}
+	paths := make([]string, 0, len(added)+len(modified))
+	for _, p := range append(append([]string{}, added...), modified...) {
+		if p == "" || seen[p] {
+			continue
+		}
+		seen[p] = true
+		paths = append(paths, p)
+	}
+	return paths
+}
@@ -0,0 +1,54 @@
+package store
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestPushedPaths(t *testing.T) {
+	tables := []struct {
+		name     string
+		added    []string
+		modified []string
+		removed  []string
+		expected []string
+	}{
+		{
+			name:     "empty",
+			expected: []string{},
+		},
+		{
+			name:     "added and modified",
+			added:    []string{"v2.md"},
+			modified: []string{"v1.md"},
+			expected: []string{"v2.md", "v1.md"},
+		},
+		{
+			name:     "added then modified in a later commit",
+			added:    []string{"v2.md", "v3.md"},
+			modified: []string{"v2.md"},
+			expected: []string{"v2.md", "v3.md"},
+		},
+		{
+			name:     "empty path",
+			modified: []string{"", "v1.md"},
+			expected: []string{"v1.md"},
+		},
+		{
+			name:     "added then removed in a later commit",
+			added:    []string{"v2.md", "v3.md"},
+			modified: []string{"v1.md", "v3.md"},
+			removed:  []string{"v3.md"},
+			expected: []string{"v2.md", "v1.md"},
+		},
+	}
+
+	for _, table := range tables {
+		t.Run(table.name, func(t *testing.T) {
+			paths := pushedPaths(table.added, table.modified, table.removed)
+			if !slices.Equal(paths, table.expected) {
+				t.Errorf("expected %v to equal %v", paths, table.expected)
+			}
+		})
+	}
+}
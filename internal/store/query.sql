@@ -1,7 +1,7 @@
 -- name: saveWorkspace :one
 INSERT INTO workspaces (
-    id, name
-) VALUES (?1, ?2)
+    id, name, created_at
+) VALUES (?1, ?2, unixepoch('now'))
 ON CONFLICT (id)
 DO UPDATE SET name = ?2
 RETURNING *;
@@ -12,26 +12,56 @@ FROM workspaces w
 LEFT JOIN tokens t ON w.id = t.workspace_id
 WHERE id = ?;
 
+-- name: createWorkspaceIfNotExists :execrows
+INSERT OR IGNORE INTO workspaces (
+    id, name, created_at
+) VALUES (?, ?, unixepoch('now'));
+
+-- name: getWorkspaceByName :one
+SELECT * FROM workspaces
+WHERE name = ?;
+
 -- name: deleteWorkspace :exec
 DELETE FROM workspaces
 WHERE id = ?;
 
 -- name: createToken :exec
 INSERT INTO tokens (
-    key, workspace_id
+    id, key, workspace_id, created_at, expires_at
 ) VALUES (
-    ?, ?
+    ?, ?, ?, unixepoch('now'), ?
 );
 
 -- name: getToken :one
 SELECT * FROM tokens
 WHERE key = ?;
 
--- technically a workspace can have multiple tokens, but domain only create one token when workspace is created
+-- name: touchToken :exec
+UPDATE tokens
+SET last_used_at = unixepoch('now')
+WHERE key = ?;
 
--- name: getTokenByWorkspace :one
+-- name: listTokens :many
 SELECT * FROM tokens
 WHERE workspace_id = ?
+ORDER BY created_at DESC, id DESC;
+
+-- name: deleteToken :execrows
+DELETE FROM tokens
+WHERE workspace_id = ? AND id = ?;
+
+-- name: setTokenScopes :execrows
+UPDATE tokens
+SET scopes = ?
+WHERE workspace_id = ? AND key = ?;
+
+-- technically a workspace can have multiple tokens, return the most recently created one
+-- tokens created in the same second are ordered by key, so the same token is returned every time
+
+-- name: getTokenByWorkspace :one
+SELECT key FROM tokens
+WHERE workspace_id = ?
+ORDER BY created_at DESC, key DESC
 LIMIT 1;
 
 -- name: createChangelog :one
@@ -52,8 +82,9 @@ INSERT INTO changelogs (
     protected,
     analytics,
     searchable,
-    password_hash
-) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+    password_hash,
+    slug
+) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 RETURNING *;
 
 -- name: deleteChangelog :exec
@@ -74,11 +105,57 @@ LEFT JOIN changelog_source cs ON c.workspace_id = cs.workspace_id AND c.source_i
 WHERE c.domain = ? OR c.subdomain = ?
 LIMIT 1;
 
+-- name: getChangelogBySlug :one
+SELECT sqlc.embed(c), sqlc.embed(cs)
+FROM changelogs c
+LEFT JOIN changelog_source cs ON c.workspace_id = cs.workspace_id AND c.source_id = cs.id
+WHERE c.workspace_id = ? AND c.slug = ?;
+
+-- name: getChangelogBySubdomainOrSlug :one
+SELECT sqlc.embed(c), sqlc.embed(cs)
+FROM changelogs c
+LEFT JOIN changelog_source cs ON c.workspace_id = cs.workspace_id AND c.source_id = cs.id
+-- prefer the changelog with the subdomain over the one with the slug
+WHERE c.subdomain = sqlc.arg(subdomain) OR c.slug = sqlc.arg(slug)
+ORDER BY c.subdomain = sqlc.arg(subdomain) DESC
+LIMIT 1;
+
+-- name: changelogSlugExists :one
+SELECT EXISTS(SELECT 1 FROM changelogs WHERE slug = ?);
+
+-- name: getChangelogBrandKit :one
+SELECT c.logo_src, c.logo_link, c.logo_alt, c.logo_height, c.logo_width, c.color_scheme, c.favicon_src, c.social_links
+FROM changelogs c
+WHERE c.domain = sqlc.arg(domain) OR c.subdomain = sqlc.arg(subdomain) OR EXISTS (
+    SELECT 1 FROM changelog_custom_domains d
+    WHERE d.workspace_id = c.workspace_id AND d.changelog_id = c.id AND d.domain = sqlc.arg(domain) AND d.verified = 1
+)
+LIMIT 1;
+
 -- name: listChangelogs :many
 SELECT sqlc.embed(c), sqlc.embed(cs)
 FROM changelogs c
 LEFT JOIN changelog_source cs ON c.workspace_id = cs.workspace_id AND c.source_id = cs.id
-WHERE c.workspace_id = ?;
+WHERE c.workspace_id = ?
+ORDER BY c.sort_order ASC, c.created_at DESC;
+
+-- name: listChangelogsByInstallationID :many
+SELECT sqlc.embed(c), sqlc.embed(gh), COALESCE(h.consecutive_failures, 0) AS consecutive_failures
+FROM changelogs c
+JOIN gh_sources gh ON c.workspace_id = gh.workspace_id AND c.source_id = gh.id
+LEFT JOIN gh_source_health h ON gh.workspace_id = h.workspace_id AND gh.id = h.gh_source_id
+WHERE gh.installation_id = ?
+ORDER BY c.workspace_id, c.created_at DESC;
+
+-- name: getChangelogHost :one
+SELECT domain, subdomain FROM changelogs
+WHERE workspace_id = ? AND id = ?;
+
+-- name: listChangelogsByIDs :many
+SELECT sqlc.embed(c), sqlc.embed(cs)
+FROM changelogs c
+LEFT JOIN changelog_source cs ON c.workspace_id = cs.workspace_id AND c.source_id = cs.id
+WHERE c.workspace_id = ? AND c.id IN (sqlc.slice(ids));
 
 -- name: updateChangelog :one
 UPDATE changelogs
@@ -97,7 +174,10 @@ SET
    protected = coalesce(sqlc.narg(protected), protected),
    analytics = coalesce(sqlc.narg(analytics), analytics),
    searchable = coalesce(sqlc.narg(searchable), searchable),
-   password_hash = CASE WHEN cast(@set_password_hash as bool) THEN @password_hash ELSE password_hash END
+   password_hash = CASE WHEN cast(@set_password_hash as bool) THEN @password_hash ELSE password_hash END,
+   slug = CASE WHEN cast(@set_slug as bool) THEN @slug ELSE slug END,
+   favicon_src = CASE WHEN cast(@set_favicon_src as bool) THEN @favicon_src ELSE favicon_src END,
+   social_links = CASE WHEN cast(@set_social_links as bool) THEN @social_links ELSE social_links END
 WHERE workspace_id = sqlc.arg(workspace_id) AND id = sqlc.arg(id)
 RETURNING *;
 
@@ -113,8 +193,8 @@ WHERE workspace_id = ? AND id = ?;
 
 -- name: createGHSource :one
 INSERT INTO gh_sources (
-    id, workspace_id, owner, repo, path, installation_id
-) VALUES (?, ?, ?, ?, ?, ?)
+    id, workspace_id, owner, repo, path, installation_id, private_key_id, private_key_blob
+) VALUES (?, ?, ?, ?, ?, ?, ?, ?)
 RETURNING *;
 
 -- name: listGHSources :many
@@ -122,8 +202,17 @@ SELECT * FROM gh_sources
 WHERE workspace_id = ?;
 
 -- name: getGHSource :one
-SELECT * FROM gh_sources
-WHERE workspace_id = ? AND id = ?;
+SELECT sqlc.embed(gh), COALESCE(h.consecutive_failures, 0) AS consecutive_failures
+FROM gh_sources gh
+LEFT JOIN gh_source_health h ON gh.workspace_id = h.workspace_id AND gh.id = h.gh_source_id
+WHERE gh.workspace_id = ? AND gh.id = ?;
+
+-- name: getGHSourceByChangelog :one
+SELECT sqlc.embed(gh), COALESCE(h.consecutive_failures, 0) AS consecutive_failures
+FROM changelogs c
+JOIN gh_sources gh ON c.workspace_id = gh.workspace_id AND c.source_id = gh.id
+LEFT JOIN gh_source_health h ON gh.workspace_id = h.workspace_id AND gh.id = h.gh_source_id
+WHERE c.workspace_id = ? AND c.id = ?;
 
 -- name: deleteGHSource :exec
 DELETE FROM gh_sources
@@ -134,4 +223,840 @@ SELECT sqlc.embed(w), COUNT(c.id) AS changelog_count
 FROM workspaces w
 LEFT JOIN changelogs c ON w.id = c.workspace_id
 GROUP BY w.id, w.name
-ORDER BY changelog_count DESC;
\ No newline at end of file
+ORDER BY changelog_count DESC;
+
+-- name: getInstallationToken :one
+SELECT token FROM gh_installation_tokens
+WHERE installation_id = ? AND expires_at > ?;
+
+-- name: setInstallationToken :exec
+INSERT INTO gh_installation_tokens (installation_id, token, expires_at)
+VALUES (?, ?, ?)
+ON CONFLICT (installation_id) DO UPDATE SET
+    token = excluded.token,
+    expires_at = excluded.expires_at;
+
+-- name: getInstallationIDByWorkspace :one
+SELECT installation_id FROM workspace_gh_installations
+WHERE workspace_id = ?;
+
+-- name: setInstallationIDForWorkspace :exec
+INSERT INTO workspace_gh_installations (
+    workspace_id, installation_id
+) VALUES (?1, ?2)
+ON CONFLICT (workspace_id)
+DO UPDATE SET installation_id = ?2, installed_at = unixepoch('now');
+
+-- name: listGHSourcesNeedingRefresh :many
+SELECT * FROM gh_sources
+WHERE last_fetched_at + fetch_interval_seconds <= unixepoch('now')
+ORDER BY last_fetched_at ASC
+LIMIT ?;
+
+-- name: listOrphanedGHSources :many
+SELECT gh.* FROM gh_sources gh
+WHERE NOT EXISTS (
+    SELECT 1 FROM changelogs c
+    WHERE c.workspace_id = gh.workspace_id AND c.source_id = gh.id
+);
+
+-- name: updateGHSourceLastFetched :exec
+UPDATE gh_sources
+SET last_fetched_at = unixepoch('now')
+WHERE workspace_id = ? AND id = ?;
+
+-- name: createLabel :one
+INSERT INTO labels (
+    workspace_id, name
+) VALUES (?, ?)
+RETURNING *;
+
+-- name: deleteLabel :exec
+DELETE FROM labels
+WHERE workspace_id = ? AND name = ?;
+
+-- name: attachLabelToEntry :exec
+INSERT OR IGNORE INTO entry_labels (
+    workspace_id, changelog_id, entry_id, label_name
+) VALUES (?, ?, ?, ?);
+
+-- name: detachLabelFromEntry :exec
+DELETE FROM entry_labels
+WHERE workspace_id = ? AND changelog_id = ? AND entry_id = ? AND label_name = ?;
+
+-- entries which already have the new label keep their old one, it's deleted afterwards
+
+-- name: labelExists :one
+SELECT EXISTS(SELECT 1 FROM labels WHERE workspace_id = ? AND name = ?);
+
+-- name: moveEntryLabels :execrows
+UPDATE OR IGNORE entry_labels
+SET label_name = sqlc.arg(to_label)
+WHERE workspace_id = sqlc.arg(workspace_id) AND label_name = sqlc.arg(from_label);
+
+-- name: deleteEntryLabelsByLabel :execrows
+DELETE FROM entry_labels
+WHERE workspace_id = ? AND label_name = ?;
+
+-- name: listLabelsByEntry :many
+SELECT l.* FROM labels l
+JOIN entry_labels el ON l.workspace_id = el.workspace_id AND l.name = el.label_name
+WHERE el.workspace_id = ? AND el.changelog_id = ? AND el.entry_id = ?
+ORDER BY l.name;
+
+-- name: listEntryIDsByLabels :many
+SELECT entry_id FROM entry_labels
+WHERE workspace_id = ? AND changelog_id = ? AND label_name IN (sqlc.slice(labels))
+GROUP BY entry_id
+HAVING COUNT(*) >= sqlc.arg(min_matches);
+
+-- name: createChangelogAsset :exec
+INSERT INTO changelog_assets (
+    id, workspace_id, changelog_id, asset_type, content_type, data
+) VALUES (?, ?, ?, ?, ?, ?);
+
+-- name: deleteChangelogAssetsByType :exec
+DELETE FROM changelog_assets
+WHERE workspace_id = ? AND changelog_id = ? AND asset_type = ?;
+
+-- name: getChangelogAsset :one
+SELECT * FROM changelog_assets
+WHERE id = ?;
+
+-- name: setChangelogFaviconSrc :execrows
+UPDATE changelogs
+SET favicon_src = ?
+WHERE workspace_id = ? AND id = ?;
+
+-- changelogs pointing to a deleted source are treated as changelogs without source
+
+-- name: listChangelogsWithoutSource :many
+SELECT c.* FROM changelogs c
+LEFT JOIN gh_sources gh ON c.workspace_id = gh.workspace_id AND c.source_id = gh.id
+WHERE c.workspace_id = ? AND gh.id IS NULL;
+
+-- name: listWorkspacesWithExpiredTokens :many
+SELECT DISTINCT workspace_id FROM tokens
+WHERE expires_at IS NOT NULL AND expires_at < ?;
+
+-- name: purgeExpiredTokens :execrows
+DELETE FROM tokens
+WHERE expires_at IS NOT NULL AND expires_at < ?;
+
+-- name: getGHSourceHealth :one
+SELECT sqlc.embed(h)
+FROM gh_source_health h
+WHERE h.workspace_id = ? AND h.gh_source_id = ?;
+
+-- name: recordGHSourceFailure :exec
+INSERT INTO gh_source_health (workspace_id, gh_source_id, last_error, consecutive_failures, last_checked_at)
+VALUES (?, ?, ?, 1, unixepoch('now'))
+ON CONFLICT (workspace_id, gh_source_id) DO UPDATE SET
+    last_error = excluded.last_error,
+    consecutive_failures = consecutive_failures + 1,
+    last_checked_at = excluded.last_checked_at;
+
+-- name: recordGHSourceSuccess :exec
+INSERT INTO gh_source_health (workspace_id, gh_source_id, consecutive_failures, last_checked_at)
+VALUES (?, ?, 0, unixepoch('now'))
+ON CONFLICT (workspace_id, gh_source_id) DO UPDATE SET
+    last_error = NULL,
+    consecutive_failures = 0,
+    last_checked_at = excluded.last_checked_at;
+
+-- name: listPublicChangelogs :many
+SELECT sqlc.embed(c), sqlc.embed(cs)
+FROM changelogs c
+LEFT JOIN changelog_source cs ON c.workspace_id = cs.workspace_id AND c.source_id = cs.id
+WHERE c.protected = 0 AND c.analytics = 1
+ORDER BY c.created_at DESC
+LIMIT ? OFFSET ?;
+
+-- name: countPublicChangelogs :one
+SELECT COUNT(*) FROM changelogs
+WHERE protected = 0 AND analytics = 1;
+
+-- name: listChangelogsWithAnalytics :many
+SELECT sqlc.embed(c), sqlc.embed(cs)
+FROM changelogs c
+LEFT JOIN changelog_source cs ON c.workspace_id = cs.workspace_id AND c.source_id = cs.id
+WHERE c.analytics = 1
+ORDER BY c.created_at ASC, c.id ASC
+LIMIT ? OFFSET ?;
+
+-- name: countChangelogsWithAnalytics :one
+SELECT COUNT(*) FROM changelogs
+WHERE analytics = 1;
+
+-- name: createChangelogToken :exec
+INSERT INTO changelog_tokens (token_hash, changelog_id, workspace_id)
+VALUES (?, ?, ?);
+
+-- name: getChangelogByToken :one
+SELECT sqlc.embed(c), sqlc.embed(cs)
+FROM changelog_tokens t
+JOIN changelogs c ON t.workspace_id = c.workspace_id AND t.changelog_id = c.id
+LEFT JOIN changelog_source cs ON c.workspace_id = cs.workspace_id AND c.source_id = cs.id
+WHERE t.token_hash = ?;
+
+-- name: deleteChangelogToken :execrows
+DELETE FROM changelog_tokens
+WHERE workspace_id = ? AND token_hash = ?;
+
+-- name: createChangelogSnapshot :exec
+INSERT INTO changelog_snapshots (id, workspace_id, changelog_id, snapshot)
+VALUES (?, ?, ?, ?);
+
+-- name: listChangelogSnapshots :many
+SELECT * FROM changelog_snapshots
+WHERE workspace_id = ? AND changelog_id = ?
+ORDER BY created_at DESC, id DESC
+LIMIT ?;
+
+-- name: getChangelogSnapshot :one
+SELECT * FROM changelog_snapshots
+WHERE workspace_id = ? AND changelog_id = ? AND id = ?;
+
+-- name: createAnnouncement :one
+INSERT INTO changelog_announcements (id, workspace_id, changelog_id, message, cta_url, starts_at, ends_at)
+VALUES (?, ?, ?, ?, ?, ?, ?)
+RETURNING *;
+
+-- name: deleteAnnouncement :execrows
+DELETE FROM changelog_announcements
+WHERE workspace_id = ? AND changelog_id = ? AND id = ?;
+
+-- name: listActiveAnnouncements :many
+SELECT * FROM changelog_announcements
+WHERE changelog_id = sqlc.arg(changelog_id) AND starts_at <= sqlc.arg(at) AND ends_at >= sqlc.arg(at)
+ORDER BY starts_at DESC;
+
+-- name: listAllAnnouncements :many
+SELECT * FROM changelog_announcements
+WHERE workspace_id = ? AND changelog_id = ?
+ORDER BY starts_at DESC;
+
+-- name: createSyncLog :exec
+INSERT INTO gh_sync_logs (id, workspace_id, gh_source_id, started_at, finished_at, files_found, files_changed, error)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?);
+
+-- name: listSyncLogs :many
+SELECT sqlc.embed(l)
+FROM gh_sync_logs l
+WHERE l.workspace_id = ? AND l.gh_source_id = ?
+ORDER BY l.started_at DESC
+LIMIT ?;
+
+-- name: purgeSyncLogs :execrows
+DELETE FROM gh_sync_logs
+WHERE started_at < ?;
+
+-- name: getWorkspaceGrowthStats :many
+WITH created AS (
+    SELECT strftime(@period_format, created_at, 'unixepoch') AS period, 1 AS is_workspace, 0 AS is_changelog
+    FROM workspaces
+    WHERE created_at BETWEEN @from_time AND @to_time
+    UNION ALL
+    SELECT strftime(@period_format, created_at, 'unixepoch') AS period, 0 AS is_workspace, 1 AS is_changelog
+    FROM changelogs
+    WHERE created_at BETWEEN @from_time AND @to_time
+)
+SELECT
+    CAST(period AS TEXT) AS period,
+    CAST(SUM(is_workspace) AS INTEGER) AS new_workspaces,
+    CAST(SUM(is_changelog) AS INTEGER) AS new_changelogs
+FROM created
+GROUP BY period
+ORDER BY period;
+
+-- the pattern is bound as a whole, so sqlite can use the NOCASE index on name for the prefix match
+
+-- name: searchWorkspaces :many
+SELECT * FROM workspaces
+WHERE name LIKE sqlc.arg(name_pattern) ESCAPE '\'
+ORDER BY name COLLATE NOCASE
+LIMIT sqlc.arg(max_results);
+
+-- name: getWorkspaceAnalytics :one
+WITH views AS (
+    SELECT visitor_hash FROM changelog_access_log
+    WHERE workspace_id = sqlc.arg(workspace_id) AND accessed_at BETWEEN sqlc.arg(from_time) AND sqlc.arg(to_time)
+)
+SELECT COUNT(*) AS total_views, COUNT(DISTINCT visitor_hash) AS unique_visitors
+FROM views;
+
+-- name: getWorkspaceDashboardSummary :one
+WITH cls AS (
+    SELECT COUNT(*) AS n FROM changelogs
+    WHERE workspace_id = sqlc.arg(workspace_id)
+), views AS (
+    SELECT COUNT(*) AS n FROM changelog_access_log
+    WHERE workspace_id = sqlc.arg(workspace_id) AND accessed_at >= sqlc.arg(views_since)
+), failed AS (
+    -- failed deliveries which weren't followed by another attempt of the same event, see listFailedWebhookDeliveries
+    SELECT COUNT(*) AS n FROM webhook_deliveries d
+    JOIN webhook_endpoints e ON e.id = d.endpoint_id
+    WHERE e.workspace_id = sqlc.arg(workspace_id) AND d.success = 0 AND e.active = 1
+    AND NOT EXISTS (
+        SELECT 1 FROM webhook_deliveries l
+        WHERE l.endpoint_id = d.endpoint_id
+        AND l.event_type = d.event_type
+        AND l.payload_hash = d.payload_hash
+        AND (l.delivered_at > d.delivered_at OR (l.delivered_at = d.delivered_at AND l.id > d.id))
+    )
+), degraded AS (
+    SELECT COUNT(*) AS n FROM gh_sources gh
+    JOIN gh_source_health h ON gh.workspace_id = h.workspace_id AND gh.id = h.gh_source_id
+    WHERE gh.workspace_id = sqlc.arg(workspace_id) AND h.consecutive_failures >= sqlc.arg(degraded_threshold)
+)
+SELECT
+    cls.n AS changelog_count,
+    views.n AS total_views,
+    failed.n AS unprocessed_webhooks,
+    degraded.n AS degraded_sources
+FROM cls, views, failed, degraded;
+
+-- name: getHeatmapByDayOfWeek :many
+SELECT CAST(strftime('%w', accessed_at, 'unixepoch') AS INTEGER) AS day, COUNT(*) AS views
+FROM changelog_access_log
+WHERE workspace_id = sqlc.arg(workspace_id) AND changelog_id = sqlc.arg(changelog_id) AND accessed_at BETWEEN sqlc.arg(from_time) AND sqlc.arg(to_time)
+GROUP BY day;
+
+-- name: getHeatmapByHour :many
+SELECT CAST(strftime('%H', accessed_at, 'unixepoch') AS INTEGER) AS hour, COUNT(*) AS views
+FROM changelog_access_log
+WHERE workspace_id = sqlc.arg(workspace_id) AND changelog_id = sqlc.arg(changelog_id) AND accessed_at BETWEEN sqlc.arg(from_time) AND sqlc.arg(to_time)
+GROUP BY hour;
+
+-- name: getTrafficSources :many
+SELECT CASE WHEN host = '' THEN '(direct)' ELSE host END AS domain, COUNT(*) AS visits
+FROM (
+    SELECT lower(substr(rest, 1, instr(rest, '/') - 1)) AS host
+    FROM (
+        -- strips the scheme and ends the hostname at the first /, ? or #
+        SELECT replace(replace(
+            CASE WHEN instr(referer, '://') > 0 THEN substr(referer, instr(referer, '://') + 3) ELSE COALESCE(referer, '') END,
+            '?', '/'), '#', '/') || '/' AS rest
+        FROM changelog_access_log
+        WHERE workspace_id = sqlc.arg(workspace_id) AND changelog_id = sqlc.arg(changelog_id) AND accessed_at BETWEEN sqlc.arg(from_time) AND sqlc.arg(to_time)
+    )
+)
+GROUP BY domain
+ORDER BY visits DESC, domain
+LIMIT sqlc.arg(max_results);
+
+-- name: listAllSubdomains :many
+SELECT subdomain FROM changelogs
+WHERE subdomain != ''
+ORDER BY subdomain;
+
+-- name: listSubdomainsByWorkspace :many
+SELECT subdomain FROM changelogs
+WHERE workspace_id = ? AND subdomain != ''
+ORDER BY subdomain;
+
+-- name: getWorkspaceByChangelog :one
+SELECT sqlc.embed(w)
+FROM changelogs c
+JOIN workspaces w ON c.workspace_id = w.id
+WHERE c.workspace_id = ? AND c.id = ?;
+
+-- name: createRSSToken :exec
+INSERT INTO changelog_rss_tokens (token_hash, changelog_id, workspace_id)
+VALUES (?, ?, ?);
+
+-- name: getChangelogByRSSToken :one
+SELECT sqlc.embed(c), sqlc.embed(cs)
+FROM changelog_rss_tokens t
+JOIN changelogs c ON t.workspace_id = c.workspace_id AND t.changelog_id = c.id
+LEFT JOIN changelog_source cs ON c.workspace_id = cs.workspace_id AND c.source_id = cs.id
+WHERE t.token_hash = ?;
+
+-- name: deleteRSSToken :execrows
+DELETE FROM changelog_rss_tokens
+WHERE workspace_id = ? AND changelog_id = ? AND token_hash = ?;
+
+-- name: createPreviewToken :exec
+INSERT INTO changelog_preview_tokens (token_hash, changelog_id, workspace_id, expires_at)
+VALUES (?, ?, ?, ?);
+
+-- name: getChangelogByPreviewToken :one
+SELECT sqlc.embed(c), sqlc.embed(cs), t.expires_at
+FROM changelog_preview_tokens t
+JOIN changelogs c ON t.workspace_id = c.workspace_id AND t.changelog_id = c.id
+LEFT JOIN changelog_source cs ON c.workspace_id = cs.workspace_id AND c.source_id = cs.id
+WHERE t.token_hash = ?;
+
+-- name: deletePreviewToken :execrows
+DELETE FROM changelog_preview_tokens
+WHERE workspace_id = ? AND changelog_id = ? AND token_hash = ?;
+
+-- name: getCachedTree :one
+SELECT * FROM gh_source_tree_cache
+WHERE workspace_id = ? AND gh_source_id = ?;
+
+-- name: setCachedTree :exec
+INSERT INTO gh_source_tree_cache (workspace_id, gh_source_id, tree_json, cached_at, etag)
+VALUES (?, ?, ?, unixepoch('now'), ?)
+ON CONFLICT (workspace_id, gh_source_id) DO UPDATE SET
+    tree_json = excluded.tree_json,
+    cached_at = excluded.cached_at,
+    etag = excluded.etag;
+
+-- name: listCachedFiles :many
+SELECT path, sha FROM gh_source_file_cache
+WHERE workspace_id = ? AND gh_source_id = ?;
+
+-- name: deleteCachedFiles :exec
+DELETE FROM gh_source_file_cache
+WHERE workspace_id = ? AND gh_source_id = ?;
+
+-- name: deleteCachedFilesByPath :exec
+DELETE FROM gh_source_file_cache
+WHERE workspace_id = ? AND gh_source_id = ? AND path IN (sqlc.slice(paths));
+
+-- name: createCachedFile :exec
+INSERT INTO gh_source_file_cache (workspace_id, gh_source_id, path, sha)
+VALUES (?, ?, ?, ?);
+
+-- name: recordSearchQuery :exec
+INSERT INTO search_queries (workspace_id, changelog_id, query, result_count)
+VALUES (?, ?, ?, ?);
+
+-- name: getTopSearchQueries :many
+SELECT q.query, COUNT(*) AS count
+FROM search_queries q
+WHERE q.workspace_id = sqlc.arg(workspace_id)
+    AND q.changelog_id = sqlc.arg(changelog_id)
+    AND q.searched_at BETWEEN sqlc.arg(from_time) AND sqlc.arg(to_time)
+GROUP BY q.query
+ORDER BY count DESC, q.query
+LIMIT sqlc.arg(max_results);
+
+-- name: setGHSourceWebhookSecret :execrows
+UPDATE gh_sources
+SET webhook_secret = ?
+WHERE workspace_id = ? AND id = ?;
+
+-- name: setGHSourceFetchInterval :execrows
+UPDATE gh_sources
+SET fetch_interval_seconds = ?
+WHERE workspace_id = ? AND id = ?;
+
+-- name: getGHSourceWebhookSecret :one
+SELECT webhook_secret FROM gh_sources
+WHERE workspace_id = ? AND id = ?;
+
+-- name: getWorkspaceQuotaUsage :one
+SELECT
+    (SELECT COUNT(*) FROM changelogs c WHERE c.workspace_id = ?1) AS changelogs,
+    (SELECT COUNT(*) FROM gh_sources gh WHERE gh.workspace_id = ?1) AS gh_sources,
+    (SELECT CAST(COALESCE(SUM(LENGTH(a.data)), 0) AS INTEGER) FROM changelog_assets a WHERE a.workspace_id = ?1) AS storage_bytes;
+
+-- name: setChangelogSortOrder :execrows
+UPDATE changelogs
+SET sort_order = ?
+WHERE workspace_id = ? AND id = ?;
+
+-- name: setChangelogSocialLinks :execrows
+UPDATE changelogs
+SET social_links = ?
+WHERE workspace_id = ? AND id = ?;
+
+-- name: createWorkspaceInvite :exec
+INSERT INTO workspace_invites (token_hash, workspace_id, email, role, expires_at)
+VALUES (?, ?, ?, ?, ?);
+
+-- name: getWorkspaceInvite :one
+SELECT * FROM workspace_invites
+WHERE token_hash = ?;
+
+-- name: acceptWorkspaceInvite :exec
+UPDATE workspace_invites
+SET accepted_at = unixepoch('now')
+WHERE token_hash = ?;
+
+-- name: deleteWorkspaceInvite :execrows
+DELETE FROM workspace_invites
+WHERE workspace_id = ? AND token_hash = ?;
+
+-- name: addWorkspaceMember :one
+INSERT INTO workspace_members (workspace_id, user_id, role)
+VALUES (?, ?, ?)
+RETURNING *;
+
+-- name: getWorkspaceMember :one
+SELECT * FROM workspace_members
+WHERE workspace_id = ? AND user_id = ?;
+
+-- name: listWorkspaceMembers :many
+SELECT * FROM workspace_members
+WHERE workspace_id = ?
+ORDER BY joined_at ASC;
+
+-- name: updateWorkspaceMemberRole :execrows
+UPDATE workspace_members
+SET role = ?
+WHERE workspace_id = ? AND user_id = ?;
+
+-- name: deleteWorkspaceMember :execrows
+DELETE FROM workspace_members
+WHERE workspace_id = ? AND user_id = ?;
+
+-- name: createAccessLog :exec
+INSERT INTO changelog_access_log (
+    id, workspace_id, changelog_id, visitor_hash, user_agent, referer, accessed_at
+) VALUES (?, ?, ?, ?, ?, ?, ?);
+
+-- name: listAccessLog :many
+SELECT * FROM changelog_access_log
+WHERE workspace_id = ? AND changelog_id = ? AND accessed_at BETWEEN sqlc.arg(from_time) AND sqlc.arg(to_time)
+ORDER BY accessed_at DESC
+LIMIT ? OFFSET ?;
+
+-- name: purgeAccessLog :execrows
+DELETE FROM changelog_access_log
+WHERE accessed_at < ?;
+
+-- restarting a verification resets the result of previous checks
+
+-- name: startDomainVerification :exec
+INSERT INTO domain_verifications (workspace_id, changelog_id, domain, cname_target)
+VALUES (?, ?, ?, ?)
+ON CONFLICT (workspace_id, changelog_id)
+DO UPDATE SET
+    domain = excluded.domain,
+    cname_target = excluded.cname_target,
+    verified_at = NULL,
+    last_checked_at = NULL,
+    check_error = NULL;
+
+-- name: recordDomainVerification :execrows
+UPDATE domain_verifications
+SET
+    verified_at = CASE WHEN sqlc.arg(verified) THEN COALESCE(verified_at, unixepoch('now')) ELSE NULL END,
+    last_checked_at = unixepoch('now'),
+    check_error = sqlc.arg(check_error)
+WHERE workspace_id = sqlc.arg(workspace_id) AND changelog_id = sqlc.arg(changelog_id)
+    AND domain = (SELECT c.domain FROM changelogs c WHERE c.workspace_id = sqlc.arg(workspace_id) AND c.id = sqlc.arg(changelog_id));
+
+-- name: getDomainVerification :one
+SELECT sqlc.embed(v), c.domain AS changelog_domain
+FROM domain_verifications v
+JOIN changelogs c ON v.workspace_id = c.workspace_id AND v.changelog_id = c.id
+WHERE v.workspace_id = ? AND v.changelog_id = ?;
+
+-- name: setChangelogOGImage :exec
+INSERT INTO changelog_og_images (workspace_id, changelog_id, image, etag)
+VALUES (?, ?, ?, ?)
+ON CONFLICT (workspace_id, changelog_id)
+DO UPDATE SET
+    image = excluded.image,
+    etag = excluded.etag,
+    generated_at = unixepoch('now');
+
+-- name: getChangelogOGImage :one
+SELECT * FROM changelog_og_images
+WHERE workspace_id = ? AND changelog_id = ?;
+
+-- name: setChangelogI18nString :exec
+INSERT INTO changelog_i18n (workspace_id, changelog_id, key, value)
+VALUES (?, ?, ?, ?)
+ON CONFLICT (workspace_id, changelog_id, key) DO UPDATE SET
+    value = excluded.value;
+
+-- name: listChangelogI18nStrings :many
+SELECT * FROM changelog_i18n
+WHERE workspace_id = ? AND changelog_id = ?;
+
+-- name: deleteChangelogI18nString :exec
+DELETE FROM changelog_i18n
+WHERE workspace_id = ? AND changelog_id = ? AND key = ?;
+
+-- name: getChangelogTheme :one
+SELECT * FROM changelog_themes
+WHERE workspace_id = ? AND changelog_id = ?;
+
+-- name: setChangelogTheme :exec
+INSERT INTO changelog_themes (workspace_id, changelog_id, font_family, accent_color, background_color, text_color, border_radius_px, custom_css)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+ON CONFLICT (workspace_id, changelog_id) DO UPDATE SET
+    font_family = excluded.font_family,
+    accent_color = excluded.accent_color,
+    background_color = excluded.background_color,
+    text_color = excluded.text_color,
+    border_radius_px = excluded.border_radius_px,
+    custom_css = excluded.custom_css;
+
+-- name: setChangelogContentHash :exec
+INSERT INTO changelog_content_hashes (workspace_id, changelog_id, hash)
+VALUES (?, ?, ?)
+ON CONFLICT (workspace_id, changelog_id)
+DO UPDATE SET
+    hash = excluded.hash,
+    updated_at = unixepoch('now');
+
+-- name: getChangelogContentHash :one
+SELECT hash FROM changelog_content_hashes
+WHERE workspace_id = ? AND changelog_id = ?;
+
+-- name: setChangelogRobotsTxt :execrows
+UPDATE changelogs
+SET robots_txt = ?
+WHERE workspace_id = ? AND id = ?;
+
+-- name: getChangelogRobotsTxt :one
+SELECT robots_txt FROM changelogs
+WHERE domain = ? OR subdomain = ?
+LIMIT 1;
+
+-- name: setChangelogCanonicalURL :execrows
+UPDATE changelogs
+SET canonical_url = ?
+WHERE workspace_id = ? AND id = ?;
+
+-- name: getChangelogCanonicalURL :one
+SELECT canonical_url, domain, subdomain FROM changelogs
+WHERE domain = ? OR subdomain = ?
+LIMIT 1;
+
+-- name: createWebhookEndpoint :one
+INSERT INTO webhook_endpoints (
+    id, changelog_id, workspace_id, url, secret, events, active
+) VALUES (?, ?, ?, ?, ?, ?, ?)
+RETURNING *;
+
+-- name: updateWebhookEndpoint :one
+UPDATE webhook_endpoints
+SET
+    url = sqlc.arg(url),
+    secret = COALESCE(sqlc.narg(secret), secret),
+    events = sqlc.arg(events),
+    active = sqlc.arg(active)
+WHERE workspace_id = sqlc.arg(workspace_id) AND id = sqlc.arg(id)
+RETURNING *;
+
+-- name: deleteWebhookEndpoint :execrows
+DELETE FROM webhook_endpoints
+WHERE workspace_id = ? AND id = ?;
+
+-- name: getWebhookEndpoint :one
+SELECT * FROM webhook_endpoints
+WHERE workspace_id = ? AND id = ?;
+
+-- name: listWebhookEndpoints :many
+SELECT * FROM webhook_endpoints
+WHERE workspace_id = ? AND changelog_id = ?
+ORDER BY created_at ASC;
+
+-- name: createWebhookDelivery :exec
+INSERT INTO webhook_deliveries (
+    id, endpoint_id, event_type, payload, payload_hash, status_code, response_body, delivered_at, duration_ms, success
+) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?);
+
+-- name: listWebhookDeliveries :many
+SELECT d.* FROM webhook_deliveries d
+JOIN webhook_endpoints e ON e.id = d.endpoint_id
+WHERE e.workspace_id = ? AND d.endpoint_id = ?
+ORDER BY d.delivered_at DESC, d.id DESC
+LIMIT ? OFFSET ?;
+
+-- name: listFailedWebhookDeliveries :many
+SELECT d.* FROM webhook_deliveries d
+JOIN webhook_endpoints e ON e.id = d.endpoint_id
+WHERE d.endpoint_id = ? AND d.success = 0 AND e.active = 1
+AND NOT EXISTS (
+    SELECT 1 FROM webhook_deliveries l
+    WHERE l.endpoint_id = d.endpoint_id
+    AND l.event_type = d.event_type
+    AND l.payload_hash = d.payload_hash
+    AND (l.delivered_at > d.delivered_at OR (l.delivered_at = d.delivered_at AND l.id > d.id))
+)
+ORDER BY d.delivered_at ASC
+LIMIT ?;
+
+-- name: getChangelogSEOSignals :one
+SELECT
+    c.title,
+    c.subtitle,
+    c.domain,
+    c.source_id,
+    EXISTS (SELECT 1 FROM changelog_og_images o WHERE o.workspace_id = c.workspace_id AND o.changelog_id = c.id) AS has_og_image
+FROM changelogs c
+WHERE c.workspace_id = ? AND c.id = ?;
+
+-- name: createAPIRequest :exec
+INSERT INTO api_requests (workspace_id, token_key, endpoint, requested_at)
+VALUES (?, ?, ?, ?);
+
+-- name: getAPIUsage :many
+SELECT endpoint, COUNT(*) AS count
+FROM api_requests
+WHERE workspace_id = sqlc.arg(workspace_id)
+    AND requested_at BETWEEN sqlc.arg(from_time) AND sqlc.arg(to_time)
+GROUP BY endpoint;
+
+-- name: purgeAPIRequests :exec
+DELETE FROM api_requests
+WHERE requested_at < ?;
+
+-- name: createCustomDomain :one
+INSERT INTO changelog_custom_domains (id, workspace_id, changelog_id, domain)
+VALUES (?, ?, ?, ?)
+RETURNING *;
+
+-- name: deleteCustomDomain :execrows
+DELETE FROM changelog_custom_domains
+WHERE workspace_id = ? AND changelog_id = ? AND id = ?;
+
+-- name: upsertPrimaryCustomDomain :execrows
+INSERT INTO changelog_custom_domains (id, workspace_id, changelog_id, domain)
+VALUES (?, ?, ?, ?)
+-- only keeps the existing row if it belongs to the same changelog, no rows are affected otherwise
+ON CONFLICT (domain) DO UPDATE SET
+    domain = excluded.domain
+WHERE changelog_custom_domains.workspace_id = excluded.workspace_id
+AND changelog_custom_domains.changelog_id = excluded.changelog_id;
+
+-- name: changelogDomainTaken :one
+SELECT EXISTS(
+    SELECT 1 FROM changelogs
+    WHERE domain = sqlc.arg(domain)
+    AND NOT (workspace_id = sqlc.arg(workspace_id) AND id = sqlc.arg(changelog_id))
+);
+
+-- name: deleteCustomDomainByDomain :exec
+DELETE FROM changelog_custom_domains
+WHERE workspace_id = ? AND changelog_id = ? AND domain = ?;
+
+-- name: setCustomDomainVerified :execrows
+UPDATE changelog_custom_domains
+SET verified = ?
+WHERE workspace_id = ? AND changelog_id = ? AND id = ?;
+
+-- name: listCustomDomains :many
+SELECT * FROM changelog_custom_domains
+WHERE workspace_id = ? AND changelog_id = ?
+ORDER BY created_at ASC;
+
+-- name: getChangelogByCustomDomain :one
+SELECT sqlc.embed(c), sqlc.embed(cs)
+FROM changelog_custom_domains d
+JOIN changelogs c ON d.workspace_id = c.workspace_id AND d.changelog_id = c.id
+LEFT JOIN changelog_source cs ON c.workspace_id = cs.workspace_id AND c.source_id = cs.id
+WHERE d.domain = ? AND d.verified = 1;
+
+-- name: upsertEmailDelivery :execrows
+INSERT INTO email_deliveries (id, workspace_id, changelog_id, to_email, subject, template, sent_at, status, error)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+ON CONFLICT (id) DO UPDATE SET
+    sent_at = excluded.sent_at,
+    status = excluded.status,
+    error = excluded.error
+WHERE email_deliveries.workspace_id = excluded.workspace_id;
+
+-- name: getEmailDelivery :one
+SELECT * FROM email_deliveries
+WHERE workspace_id = ? AND id = ?;
+
+-- name: listEmailDeliveries :many
+SELECT * FROM email_deliveries
+WHERE workspace_id = ? AND changelog_id = ?
+ORDER BY sent_at DESC, id DESC
+LIMIT ? OFFSET ?;
+
+-- name: getWorkspaceEmailSettings :one
+SELECT * FROM workspace_email_settings
+WHERE workspace_id = ?;
+
+-- name: upsertWorkspaceEmailSettings :exec
+INSERT INTO workspace_email_settings (workspace_id, from_email, from_name, smtp_host, smtp_port, smtp_user, smtp_pass_enc, reply_to)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+ON CONFLICT (workspace_id) DO UPDATE SET
+    from_email = excluded.from_email,
+    from_name = excluded.from_name,
+    smtp_host = excluded.smtp_host,
+    smtp_port = excluded.smtp_port,
+    smtp_user = excluded.smtp_user,
+    smtp_pass_enc = COALESCE(excluded.smtp_pass_enc, workspace_email_settings.smtp_pass_enc),
+    reply_to = excluded.reply_to;
+
+-- name: getWorkspaceWebhookSecret :one
+SELECT webhook_secret FROM workspaces
+WHERE id = ?;
+
+-- name: setWorkspaceWebhookSecret :execrows
+UPDATE workspaces
+SET webhook_secret = ?
+WHERE id = ?;
+
+-- name: getWorkspaceSSO :one
+SELECT * FROM workspace_sso
+WHERE workspace_id = ?;
+
+-- name: upsertWorkspaceSSO :exec
+INSERT INTO workspace_sso (workspace_id, provider, issuer_url, client_id, client_secret_enc, certificate, enabled)
+VALUES (?, ?, ?, ?, ?, ?, ?)
+ON CONFLICT (workspace_id) DO UPDATE SET
+    provider = excluded.provider,
+    issuer_url = excluded.issuer_url,
+    client_id = excluded.client_id,
+    client_secret_enc = COALESCE(excluded.client_secret_enc, workspace_sso.client_secret_enc),
+    certificate = excluded.certificate,
+    enabled = excluded.enabled;
+
+-- name: deleteWorkspaceSSO :exec
+DELETE FROM workspace_sso
+WHERE workspace_id = ?;
+
+-- name: getWorkspaceBilling :one
+SELECT * FROM workspace_billing
+WHERE workspace_id = ?;
+
+-- name: upsertWorkspaceBilling :exec
+INSERT INTO workspace_billing (workspace_id, customer_id, subscription_id, subscription_status, current_period_end)
+VALUES (?, ?, ?, ?, ?)
+ON CONFLICT (workspace_id) DO UPDATE SET
+    customer_id = excluded.customer_id,
+    subscription_id = excluded.subscription_id,
+    subscription_status = excluded.subscription_status,
+    current_period_end = excluded.current_period_end;
+
+-- name: createEntryAttachment :one
+INSERT INTO entry_attachments (id, workspace_id, changelog_id, entry_id, filename, mime_type, size_bytes, url)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+RETURNING *;
+
+-- name: deleteEntryAttachment :execrows
+DELETE FROM entry_attachments
+WHERE workspace_id = ? AND changelog_id = ? AND entry_id = ? AND id = ?;
+
+-- name: listEntryAttachments :many
+SELECT * FROM entry_attachments
+WHERE workspace_id = ? AND changelog_id = ? AND entry_id = ?
+ORDER BY created_at ASC, id ASC;
+
+-- name: createEntryComment :one
+INSERT INTO entry_comments (id, workspace_id, changelog_id, entry_id, author_id, body)
+VALUES (?, ?, ?, ?, ?, ?)
+RETURNING *;
+
+-- name: updateEntryComment :one
+UPDATE entry_comments
+SET body = ?, updated_at = unixepoch('now')
+WHERE workspace_id = ? AND changelog_id = ? AND id = ?
+RETURNING *;
+
+-- name: resolveEntryComment :execrows
+UPDATE entry_comments
+SET resolved = ?, updated_at = unixepoch('now')
+WHERE workspace_id = ? AND changelog_id = ? AND id = ?;
+
+-- name: deleteEntryComment :execrows
+DELETE FROM entry_comments
+WHERE workspace_id = ? AND changelog_id = ? AND id = ?;
+
+-- name: listEntryComments :many
+SELECT * FROM entry_comments
+WHERE workspace_id = ? AND changelog_id = ? AND entry_id = ?
+ORDER BY created_at ASC, id ASC;
@@ -8,10 +8,238 @@ package store
 import (
 	"context"
 	"database/sql"
+	"strings"
 
 	"github.com/jonashiltl/openchangelog/apitypes"
 )
 
+const acceptWorkspaceInvite = `-- name: acceptWorkspaceInvite :exec
+UPDATE workspace_invites
+SET accepted_at = unixepoch('now')
+WHERE token_hash = ?
+`
+
+func (q *Queries) acceptWorkspaceInvite(ctx context.Context, tokenHash string) error {
+	_, err := q.db.ExecContext(ctx, acceptWorkspaceInvite, tokenHash)
+	return err
+}
+
+const addWorkspaceMember = `-- name: addWorkspaceMember :one
+INSERT INTO workspace_members (workspace_id, user_id, role)
+VALUES (?, ?, ?)
+RETURNING workspace_id, user_id, role, joined_at
+`
+
+type addWorkspaceMemberParams struct {
+	WorkspaceID string
+	UserID      string
+	Role        string
+}
+
+func (q *Queries) addWorkspaceMember(ctx context.Context, arg addWorkspaceMemberParams) (workspaceMember, error) {
+	row := q.db.QueryRowContext(ctx, addWorkspaceMember, arg.WorkspaceID, arg.UserID, arg.Role)
+	var i workspaceMember
+	err := row.Scan(
+		&i.WorkspaceID,
+		&i.UserID,
+		&i.Role,
+		&i.JoinedAt,
+	)
+	return i, err
+}
+
+const attachLabelToEntry = `-- name: attachLabelToEntry :exec
+INSERT OR IGNORE INTO entry_labels (
+    workspace_id, changelog_id, entry_id, label_name
+) VALUES (?, ?, ?, ?)
+`
+
+type attachLabelToEntryParams struct {
+	WorkspaceID string
+	ChangelogID string
+	EntryID     string
+	LabelName   string
+}
+
+func (q *Queries) attachLabelToEntry(ctx context.Context, arg attachLabelToEntryParams) error {
+	_, err := q.db.ExecContext(ctx, attachLabelToEntry,
+		arg.WorkspaceID,
+		arg.ChangelogID,
+		arg.EntryID,
+		arg.LabelName,
+	)
+	return err
+}
+
+const changelogDomainTaken = `-- name: changelogDomainTaken :one
+SELECT EXISTS(
+    SELECT 1 FROM changelogs
+    WHERE domain = ?1
+    AND NOT (workspace_id = ?2 AND id = ?3)
+)
+`
+
+type changelogDomainTakenParams struct {
+	Domain      apitypes.NullString
+	WorkspaceID string
+	ChangelogID string
+}
+
+func (q *Queries) changelogDomainTaken(ctx context.Context, arg changelogDomainTakenParams) (int64, error) {
+	row := q.db.QueryRowContext(ctx, changelogDomainTaken, arg.Domain, arg.WorkspaceID, arg.ChangelogID)
+	var column_1 int64
+	err := row.Scan(&column_1)
+	return column_1, err
+}
+
+const changelogSlugExists = `-- name: changelogSlugExists :one
+SELECT EXISTS(SELECT 1 FROM changelogs WHERE slug = ?)
+`
+
+func (q *Queries) changelogSlugExists(ctx context.Context, slug apitypes.NullString) (int64, error) {
+	row := q.db.QueryRowContext(ctx, changelogSlugExists, slug)
+	var column_1 int64
+	err := row.Scan(&column_1)
+	return column_1, err
+}
+
+const countChangelogsWithAnalytics = `-- name: countChangelogsWithAnalytics :one
+SELECT COUNT(*) FROM changelogs
+WHERE analytics = 1
+`
+
+func (q *Queries) countChangelogsWithAnalytics(ctx context.Context) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countChangelogsWithAnalytics)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const countPublicChangelogs = `-- name: countPublicChangelogs :one
+SELECT COUNT(*) FROM changelogs
+WHERE protected = 0 AND analytics = 1
+`
+
+func (q *Queries) countPublicChangelogs(ctx context.Context) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countPublicChangelogs)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const createAPIRequest = `-- name: createAPIRequest :exec
+INSERT INTO api_requests (workspace_id, token_key, endpoint, requested_at)
+VALUES (?, ?, ?, ?)
+`
+
+type createAPIRequestParams struct {
+	WorkspaceID string
+	TokenKey    apitypes.NullString
+	Endpoint    string
+	RequestedAt int64
+}
+
+func (q *Queries) createAPIRequest(ctx context.Context, arg createAPIRequestParams) error {
+	_, err := q.db.ExecContext(ctx, createAPIRequest,
+		arg.WorkspaceID,
+		arg.TokenKey,
+		arg.Endpoint,
+		arg.RequestedAt,
+	)
+	return err
+}
+
+const createAccessLog = `-- name: createAccessLog :exec
+INSERT INTO changelog_access_log (
+    id, workspace_id, changelog_id, visitor_hash, user_agent, referer, accessed_at
+) VALUES (?, ?, ?, ?, ?, ?, ?)
+`
+
+type createAccessLogParams struct {
+	ID          string
+	WorkspaceID string
+	ChangelogID string
+	VisitorHash string
+	UserAgent   apitypes.NullString
+	Referer     apitypes.NullString
+	AccessedAt  int64
+}
+
+func (q *Queries) createAccessLog(ctx context.Context, arg createAccessLogParams) error {
+	_, err := q.db.ExecContext(ctx, createAccessLog,
+		arg.ID,
+		arg.WorkspaceID,
+		arg.ChangelogID,
+		arg.VisitorHash,
+		arg.UserAgent,
+		arg.Referer,
+		arg.AccessedAt,
+	)
+	return err
+}
+
+const createAnnouncement = `-- name: createAnnouncement :one
+INSERT INTO changelog_announcements (id, workspace_id, changelog_id, message, cta_url, starts_at, ends_at)
+VALUES (?, ?, ?, ?, ?, ?, ?)
+RETURNING id, workspace_id, changelog_id, message, cta_url, starts_at, ends_at, created_at
+`
+
+type createAnnouncementParams struct {
+	ID          string
+	WorkspaceID string
+	ChangelogID string
+	Message     string
+	CtaUrl      apitypes.NullString
+	StartsAt    int64
+	EndsAt      int64
+}
+
+func (q *Queries) createAnnouncement(ctx context.Context, arg createAnnouncementParams) (changelogAnnouncement, error) {
+	row := q.db.QueryRowContext(ctx, createAnnouncement,
+		arg.ID,
+		arg.WorkspaceID,
+		arg.ChangelogID,
+		arg.Message,
+		arg.CtaUrl,
+		arg.StartsAt,
+		arg.EndsAt,
+	)
+	var i changelogAnnouncement
+	err := row.Scan(
+		&i.ID,
+		&i.WorkspaceID,
+		&i.ChangelogID,
+		&i.Message,
+		&i.CtaUrl,
+		&i.StartsAt,
+		&i.EndsAt,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const createCachedFile = `-- name: createCachedFile :exec
+INSERT INTO gh_source_file_cache (workspace_id, gh_source_id, path, sha)
+VALUES (?, ?, ?, ?)
+`
+
+type createCachedFileParams struct {
+	WorkspaceID string
+	GhSourceID  string
+	Path        string
+	Sha         string
+}
+
+func (q *Queries) createCachedFile(ctx context.Context, arg createCachedFileParams) error {
+	_, err := q.db.ExecContext(ctx, createCachedFile,
+		arg.WorkspaceID,
+		arg.GhSourceID,
+		arg.Path,
+		arg.Sha,
+	)
+	return err
+}
+
 const createChangelog = `-- name: createChangelog :one
 INSERT INTO changelogs (
     workspace_id,
@@ -30,9 +258,10 @@ INSERT INTO changelogs (
     protected,
     analytics,
     searchable,
-    password_hash
-) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
-RETURNING id, workspace_id, subdomain, title, subtitle, source_id, logo_src, logo_link, logo_alt, logo_height, logo_width, created_at, domain, color_scheme, hide_powered_by, protected, password_hash, analytics, searchable
+    password_hash,
+    slug
+) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+RETURNING id, workspace_id, subdomain, title, subtitle, source_id, logo_src, logo_link, logo_alt, logo_height, logo_width, created_at, domain, color_scheme, hide_powered_by, protected, password_hash, analytics, searchable, favicon_src, sort_order, social_links, robots_txt, slug, canonical_url
 `
 
 type createChangelogParams struct {
@@ -53,6 +282,7 @@ type createChangelogParams struct {
 	Analytics     int64
 	Searchable    int64
 	PasswordHash  apitypes.NullString
+	Slug          apitypes.NullString
 }
 
 func (q *Queries) createChangelog(ctx context.Context, arg createChangelogParams) (changelog, error) {
@@ -74,6 +304,7 @@ func (q *Queries) createChangelog(ctx context.Context, arg createChangelogParams
 		arg.Analytics,
 		arg.Searchable,
 		arg.PasswordHash,
+		arg.Slug,
 	)
 	var i changelog
 	err := row.Scan(
@@ -96,15 +327,200 @@ func (q *Queries) createChangelog(ctx context.Context, arg createChangelogParams
 		&i.PasswordHash,
 		&i.Analytics,
 		&i.Searchable,
+		&i.FaviconSrc,
+		&i.SortOrder,
+		&i.SocialLinks,
+		&i.RobotsTxt,
+		&i.Slug,
+		&i.CanonicalUrl,
+	)
+	return i, err
+}
+
+const createChangelogAsset = `-- name: createChangelogAsset :exec
+INSERT INTO changelog_assets (
+    id, workspace_id, changelog_id, asset_type, content_type, data
+) VALUES (?, ?, ?, ?, ?, ?)
+`
+
+type createChangelogAssetParams struct {
+	ID          string
+	WorkspaceID string
+	ChangelogID string
+	AssetType   string
+	ContentType string
+	Data        []byte
+}
+
+func (q *Queries) createChangelogAsset(ctx context.Context, arg createChangelogAssetParams) error {
+	_, err := q.db.ExecContext(ctx, createChangelogAsset,
+		arg.ID,
+		arg.WorkspaceID,
+		arg.ChangelogID,
+		arg.AssetType,
+		arg.ContentType,
+		arg.Data,
+	)
+	return err
+}
+
+const createChangelogSnapshot = `-- name: createChangelogSnapshot :exec
+INSERT INTO changelog_snapshots (id, workspace_id, changelog_id, snapshot)
+VALUES (?, ?, ?, ?)
+`
+
+type createChangelogSnapshotParams struct {
+	ID          string
+	WorkspaceID string
+	ChangelogID string
+	Snapshot    []byte
+}
+
+func (q *Queries) createChangelogSnapshot(ctx context.Context, arg createChangelogSnapshotParams) error {
+	_, err := q.db.ExecContext(ctx, createChangelogSnapshot,
+		arg.ID,
+		arg.WorkspaceID,
+		arg.ChangelogID,
+		arg.Snapshot,
+	)
+	return err
+}
+
+const createChangelogToken = `-- name: createChangelogToken :exec
+INSERT INTO changelog_tokens (token_hash, changelog_id, workspace_id)
+VALUES (?, ?, ?)
+`
+
+type createChangelogTokenParams struct {
+	TokenHash   string
+	ChangelogID string
+	WorkspaceID string
+}
+
+func (q *Queries) createChangelogToken(ctx context.Context, arg createChangelogTokenParams) error {
+	_, err := q.db.ExecContext(ctx, createChangelogToken, arg.TokenHash, arg.ChangelogID, arg.WorkspaceID)
+	return err
+}
+
+const createCustomDomain = `-- name: createCustomDomain :one
+INSERT INTO changelog_custom_domains (id, workspace_id, changelog_id, domain)
+VALUES (?, ?, ?, ?)
+RETURNING id, workspace_id, changelog_id, domain, verified, created_at
+`
+
+type createCustomDomainParams struct {
+	ID          string
+	WorkspaceID string
+	ChangelogID string
+	Domain      string
+}
+
+func (q *Queries) createCustomDomain(ctx context.Context, arg createCustomDomainParams) (changelogCustomDomain, error) {
+	row := q.db.QueryRowContext(ctx, createCustomDomain,
+		arg.ID,
+		arg.WorkspaceID,
+		arg.ChangelogID,
+		arg.Domain,
+	)
+	var i changelogCustomDomain
+	err := row.Scan(
+		&i.ID,
+		&i.WorkspaceID,
+		&i.ChangelogID,
+		&i.Domain,
+		&i.Verified,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const createEntryAttachment = `-- name: createEntryAttachment :one
+INSERT INTO entry_attachments (id, workspace_id, changelog_id, entry_id, filename, mime_type, size_bytes, url)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+RETURNING id, workspace_id, changelog_id, entry_id, filename, mime_type, size_bytes, url, created_at
+`
+
+type createEntryAttachmentParams struct {
+	ID          string
+	WorkspaceID string
+	ChangelogID string
+	EntryID     string
+	Filename    string
+	MimeType    string
+	SizeBytes   int64
+	Url         string
+}
+
+func (q *Queries) createEntryAttachment(ctx context.Context, arg createEntryAttachmentParams) (entryAttachment, error) {
+	row := q.db.QueryRowContext(ctx, createEntryAttachment,
+		arg.ID,
+		arg.WorkspaceID,
+		arg.ChangelogID,
+		arg.EntryID,
+		arg.Filename,
+		arg.MimeType,
+		arg.SizeBytes,
+		arg.Url,
+	)
+	var i entryAttachment
+	err := row.Scan(
+		&i.ID,
+		&i.WorkspaceID,
+		&i.ChangelogID,
+		&i.EntryID,
+		&i.Filename,
+		&i.MimeType,
+		&i.SizeBytes,
+		&i.Url,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const createEntryComment = `-- name: createEntryComment :one
+INSERT INTO entry_comments (id, workspace_id, changelog_id, entry_id, author_id, body)
+VALUES (?, ?, ?, ?, ?, ?)
+RETURNING id, workspace_id, changelog_id, entry_id, author_id, body, created_at, updated_at, resolved
+`
+
+type createEntryCommentParams struct {
+	ID          string
+	WorkspaceID string
+	ChangelogID string
+	EntryID     string
+	AuthorID    string
+	Body        string
+}
+
+func (q *Queries) createEntryComment(ctx context.Context, arg createEntryCommentParams) (entryComment, error) {
+	row := q.db.QueryRowContext(ctx, createEntryComment,
+		arg.ID,
+		arg.WorkspaceID,
+		arg.ChangelogID,
+		arg.EntryID,
+		arg.AuthorID,
+		arg.Body,
+	)
+	var i entryComment
+	err := row.Scan(
+		&i.ID,
+		&i.WorkspaceID,
+		&i.ChangelogID,
+		&i.EntryID,
+		&i.AuthorID,
+		&i.Body,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Resolved,
 	)
 	return i, err
 }
 
 const createGHSource = `-- name: createGHSource :one
 INSERT INTO gh_sources (
-    id, workspace_id, owner, repo, path, installation_id
-) VALUES (?, ?, ?, ?, ?, ?)
-RETURNING id, workspace_id, owner, repo, path, installation_id
+    id, workspace_id, owner, repo, path, installation_id, private_key_id, private_key_blob
+) VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+RETURNING id, workspace_id, owner, repo, path, installation_id, last_fetched_at, fetch_interval_seconds, private_key_id, private_key_blob, webhook_secret
 `
 
 type createGHSourceParams struct {
@@ -114,6 +530,8 @@ type createGHSourceParams struct {
 	Repo           string
 	Path           string
 	InstallationID int64
+	PrivateKeyID   apitypes.NullString
+	PrivateKeyBlob []byte
 }
 
 func (q *Queries) createGHSource(ctx context.Context, arg createGHSourceParams) (ghSource, error) {
@@ -124,6 +542,8 @@ func (q *Queries) createGHSource(ctx context.Context, arg createGHSourceParams)
 		arg.Repo,
 		arg.Path,
 		arg.InstallationID,
+		arg.PrivateKeyID,
+		arg.PrivateKeyBlob,
 	)
 	var i ghSource
 	err := row.Scan(
@@ -133,308 +553,4056 @@ func (q *Queries) createGHSource(ctx context.Context, arg createGHSourceParams)
 		&i.Repo,
 		&i.Path,
 		&i.InstallationID,
+		&i.LastFetchedAt,
+		&i.FetchIntervalSeconds,
+		&i.PrivateKeyID,
+		&i.PrivateKeyBlob,
+		&i.WebhookSecret,
 	)
 	return i, err
 }
 
-const createToken = `-- name: createToken :exec
-INSERT INTO tokens (
-    key, workspace_id
-) VALUES (
-    ?, ?
-)
+const createLabel = `-- name: createLabel :one
+INSERT INTO labels (
+    workspace_id, name
+) VALUES (?, ?)
+RETURNING workspace_id, name, created_at
 `
 
-type createTokenParams struct {
-	Key         string
+type createLabelParams struct {
 	WorkspaceID string
+	Name        string
 }
 
-func (q *Queries) createToken(ctx context.Context, arg createTokenParams) error {
-	_, err := q.db.ExecContext(ctx, createToken, arg.Key, arg.WorkspaceID)
-	return err
+func (q *Queries) createLabel(ctx context.Context, arg createLabelParams) (label, error) {
+	row := q.db.QueryRowContext(ctx, createLabel, arg.WorkspaceID, arg.Name)
+	var i label
+	err := row.Scan(&i.WorkspaceID, &i.Name, &i.CreatedAt)
+	return i, err
 }
 
-const deleteChangelog = `-- name: deleteChangelog :exec
-DELETE FROM changelogs
-WHERE workspace_id = ? AND id = ?
+const createPreviewToken = `-- name: createPreviewToken :exec
+INSERT INTO changelog_preview_tokens (token_hash, changelog_id, workspace_id, expires_at)
+VALUES (?, ?, ?, ?)
 `
 
-type deleteChangelogParams struct {
+type createPreviewTokenParams struct {
+	TokenHash   string
+	ChangelogID string
 	WorkspaceID string
-	ID          string
+	ExpiresAt   int64
 }
 
-func (q *Queries) deleteChangelog(ctx context.Context, arg deleteChangelogParams) error {
-	_, err := q.db.ExecContext(ctx, deleteChangelog, arg.WorkspaceID, arg.ID)
+func (q *Queries) createPreviewToken(ctx context.Context, arg createPreviewTokenParams) error {
+	_, err := q.db.ExecContext(ctx, createPreviewToken,
+		arg.TokenHash,
+		arg.ChangelogID,
+		arg.WorkspaceID,
+		arg.ExpiresAt,
+	)
 	return err
 }
 
-const deleteChangelogSource = `-- name: deleteChangelogSource :exec
-UPDATE changelogs
-SET source_id = NULL
-WHERE workspace_id = ? AND id = ?
+const createRSSToken = `-- name: createRSSToken :exec
+INSERT INTO changelog_rss_tokens (token_hash, changelog_id, workspace_id)
+VALUES (?, ?, ?)
 `
 
-type deleteChangelogSourceParams struct {
+type createRSSTokenParams struct {
+	TokenHash   string
+	ChangelogID string
 	WorkspaceID string
-	ID          string
 }
 
-func (q *Queries) deleteChangelogSource(ctx context.Context, arg deleteChangelogSourceParams) error {
-	_, err := q.db.ExecContext(ctx, deleteChangelogSource, arg.WorkspaceID, arg.ID)
+func (q *Queries) createRSSToken(ctx context.Context, arg createRSSTokenParams) error {
+	_, err := q.db.ExecContext(ctx, createRSSToken, arg.TokenHash, arg.ChangelogID, arg.WorkspaceID)
 	return err
 }
 
-const deleteGHSource = `-- name: deleteGHSource :exec
-DELETE FROM gh_sources
-WHERE workspace_id = ? AND id = ?
+const createSyncLog = `-- name: createSyncLog :exec
+INSERT INTO gh_sync_logs (id, workspace_id, gh_source_id, started_at, finished_at, files_found, files_changed, error)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?)
 `
 
-type deleteGHSourceParams struct {
-	WorkspaceID string
-	ID          string
+type createSyncLogParams struct {
+	ID           string
+	WorkspaceID  string
+	GhSourceID   string
+	StartedAt    int64
+	FinishedAt   int64
+	FilesFound   int64
+	FilesChanged int64
+	Error        apitypes.NullString
 }
 
-func (q *Queries) deleteGHSource(ctx context.Context, arg deleteGHSourceParams) error {
-	_, err := q.db.ExecContext(ctx, deleteGHSource, arg.WorkspaceID, arg.ID)
+func (q *Queries) createSyncLog(ctx context.Context, arg createSyncLogParams) error {
+	_, err := q.db.ExecContext(ctx, createSyncLog,
+		arg.ID,
+		arg.WorkspaceID,
+		arg.GhSourceID,
+		arg.StartedAt,
+		arg.FinishedAt,
+		arg.FilesFound,
+		arg.FilesChanged,
+		arg.Error,
+	)
 	return err
 }
 
-const deleteWorkspace = `-- name: deleteWorkspace :exec
-DELETE FROM workspaces
-WHERE id = ?
+const createToken = `-- name: createToken :exec
+INSERT INTO tokens (
+    id, key, workspace_id, created_at, expires_at
+) VALUES (
+    ?, ?, ?, unixepoch('now'), ?
+)
 `
 
-func (q *Queries) deleteWorkspace(ctx context.Context, id string) error {
-	_, err := q.db.ExecContext(ctx, deleteWorkspace, id)
+type createTokenParams struct {
+	ID          apitypes.NullString
+	Key         string
+	WorkspaceID string
+	ExpiresAt   sql.NullInt64
+}
+
+func (q *Queries) createToken(ctx context.Context, arg createTokenParams) error {
+	_, err := q.db.ExecContext(ctx, createToken,
+		arg.ID,
+		arg.Key,
+		arg.WorkspaceID,
+		arg.ExpiresAt,
+	)
 	return err
 }
 
-const getChangelog = `-- name: getChangelog :one
-SELECT c.id, c.workspace_id, c.subdomain, c.title, c.subtitle, c.source_id, c.logo_src, c.logo_link, c.logo_alt, c.logo_height, c.logo_width, c.created_at, c.domain, c.color_scheme, c.hide_powered_by, c.protected, c.password_hash, c.analytics, c.searchable, cs.id, cs.workspace_id, cs.owner, cs.repo, cs.path, cs.installation_id
-FROM changelogs c
-LEFT JOIN changelog_source cs ON c.workspace_id = cs.workspace_id AND c.source_id = cs.id
-WHERE c.workspace_id = ? AND c.id = ?
+const createWebhookDelivery = `-- name: createWebhookDelivery :exec
+INSERT INTO webhook_deliveries (
+    id, endpoint_id, event_type, payload, payload_hash, status_code, response_body, delivered_at, duration_ms, success
+) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 `
 
-type getChangelogParams struct {
-	WorkspaceID string
-	ID          string
+type createWebhookDeliveryParams struct {
+	ID           string
+	EndpointID   string
+	EventType    string
+	Payload      []byte
+	PayloadHash  string
+	StatusCode   sql.NullInt64
+	ResponseBody apitypes.NullString
+	DeliveredAt  int64
+	DurationMs   int64
+	Success      int64
 }
 
-type getChangelogRow struct {
-	changelog       changelog
-	ChangelogSource changelogSource
+func (q *Queries) createWebhookDelivery(ctx context.Context, arg createWebhookDeliveryParams) error {
+	_, err := q.db.ExecContext(ctx, createWebhookDelivery,
+		arg.ID,
+		arg.EndpointID,
+		arg.EventType,
+		arg.Payload,
+		arg.PayloadHash,
+		arg.StatusCode,
+		arg.ResponseBody,
+		arg.DeliveredAt,
+		arg.DurationMs,
+		arg.Success,
+	)
+	return err
 }
 
-func (q *Queries) getChangelog(ctx context.Context, arg getChangelogParams) (getChangelogRow, error) {
-	row := q.db.QueryRowContext(ctx, getChangelog, arg.WorkspaceID, arg.ID)
-	var i getChangelogRow
-	err := row.Scan(
-		&i.changelog.ID,
-		&i.changelog.WorkspaceID,
-		&i.changelog.Subdomain,
-		&i.changelog.Title,
-		&i.changelog.Subtitle,
-		&i.changelog.SourceID,
-		&i.changelog.LogoSrc,
-		&i.changelog.LogoLink,
-		&i.changelog.LogoAlt,
-		&i.changelog.LogoHeight,
-		&i.changelog.LogoWidth,
-		&i.changelog.CreatedAt,
-		&i.changelog.Domain,
-		&i.changelog.ColorScheme,
-		&i.changelog.HidePoweredBy,
-		&i.changelog.Protected,
-		&i.changelog.PasswordHash,
-		&i.changelog.Analytics,
-		&i.changelog.Searchable,
-		&i.ChangelogSource.ID,
-		&i.ChangelogSource.WorkspaceID,
-		&i.ChangelogSource.Owner,
-		&i.ChangelogSource.Repo,
-		&i.ChangelogSource.Path,
-		&i.ChangelogSource.InstallationID,
+const createWebhookEndpoint = `-- name: createWebhookEndpoint :one
+INSERT INTO webhook_endpoints (
+    id, changelog_id, workspace_id, url, secret, events, active
+) VALUES (?, ?, ?, ?, ?, ?, ?)
+RETURNING id, changelog_id, workspace_id, url, secret, events, active, created_at
+`
+
+type createWebhookEndpointParams struct {
+	ID          string
+	ChangelogID string
+	WorkspaceID string
+	Url         string
+	Secret      string
+	Events      string
+	Active      int64
+}
+
+func (q *Queries) createWebhookEndpoint(ctx context.Context, arg createWebhookEndpointParams) (webhookEndpoint, error) {
+	row := q.db.QueryRowContext(ctx, createWebhookEndpoint,
+		arg.ID,
+		arg.ChangelogID,
+		arg.WorkspaceID,
+		arg.Url,
+		arg.Secret,
+		arg.Events,
+		arg.Active,
+	)
+	var i webhookEndpoint
+	err := row.Scan(
+		&i.ID,
+		&i.ChangelogID,
+		&i.WorkspaceID,
+		&i.Url,
+		&i.Secret,
+		&i.Events,
+		&i.Active,
+		&i.CreatedAt,
 	)
 	return i, err
 }
 
-const getChangelogByDomainOrSubdomain = `-- name: getChangelogByDomainOrSubdomain :one
-SELECT c.id, c.workspace_id, c.subdomain, c.title, c.subtitle, c.source_id, c.logo_src, c.logo_link, c.logo_alt, c.logo_height, c.logo_width, c.created_at, c.domain, c.color_scheme, c.hide_powered_by, c.protected, c.password_hash, c.analytics, c.searchable, cs.id, cs.workspace_id, cs.owner, cs.repo, cs.path, cs.installation_id
-FROM changelogs c
-LEFT JOIN changelog_source cs ON c.workspace_id = cs.workspace_id AND c.source_id = cs.id
-WHERE c.domain = ? OR c.subdomain = ?
-LIMIT 1
+const createWorkspaceIfNotExists = `-- name: createWorkspaceIfNotExists :execrows
+INSERT OR IGNORE INTO workspaces (
+    id, name, created_at
+) VALUES (?, ?, unixepoch('now'))
 `
 
-type getChangelogByDomainOrSubdomainParams struct {
-	Domain    apitypes.NullString
-	Subdomain string
+type createWorkspaceIfNotExistsParams struct {
+	ID   string
+	Name string
+}
+
+func (q *Queries) createWorkspaceIfNotExists(ctx context.Context, arg createWorkspaceIfNotExistsParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, createWorkspaceIfNotExists, arg.ID, arg.Name)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const createWorkspaceInvite = `-- name: createWorkspaceInvite :exec
+INSERT INTO workspace_invites (token_hash, workspace_id, email, role, expires_at)
+VALUES (?, ?, ?, ?, ?)
+`
+
+type createWorkspaceInviteParams struct {
+	TokenHash   string
+	WorkspaceID string
+	Email       string
+	Role        string
+	ExpiresAt   int64
+}
+
+func (q *Queries) createWorkspaceInvite(ctx context.Context, arg createWorkspaceInviteParams) error {
+	_, err := q.db.ExecContext(ctx, createWorkspaceInvite,
+		arg.TokenHash,
+		arg.WorkspaceID,
+		arg.Email,
+		arg.Role,
+		arg.ExpiresAt,
+	)
+	return err
+}
+
+const deleteAnnouncement = `-- name: deleteAnnouncement :execrows
+DELETE FROM changelog_announcements
+WHERE workspace_id = ? AND changelog_id = ? AND id = ?
+`
+
+type deleteAnnouncementParams struct {
+	WorkspaceID string
+	ChangelogID string
+	ID          string
+}
+
+func (q *Queries) deleteAnnouncement(ctx context.Context, arg deleteAnnouncementParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, deleteAnnouncement, arg.WorkspaceID, arg.ChangelogID, arg.ID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const deleteCachedFiles = `-- name: deleteCachedFiles :exec
+DELETE FROM gh_source_file_cache
+WHERE workspace_id = ? AND gh_source_id = ?
+`
+
+type deleteCachedFilesParams struct {
+	WorkspaceID string
+	GhSourceID  string
+}
+
+func (q *Queries) deleteCachedFiles(ctx context.Context, arg deleteCachedFilesParams) error {
+	_, err := q.db.ExecContext(ctx, deleteCachedFiles, arg.WorkspaceID, arg.GhSourceID)
+	return err
+}
+
+const deleteCachedFilesByPath = `-- name: deleteCachedFilesByPath :exec
+DELETE FROM gh_source_file_cache
+WHERE workspace_id = ? AND gh_source_id = ? AND path IN (/*SLICE:paths*/?)
+`
+
+type deleteCachedFilesByPathParams struct {
+	WorkspaceID string
+	GhSourceID  string
+	Paths       []string
+}
+
+func (q *Queries) deleteCachedFilesByPath(ctx context.Context, arg deleteCachedFilesByPathParams) error {
+	query := deleteCachedFilesByPath
+	var queryParams []interface{}
+	queryParams = append(queryParams, arg.WorkspaceID)
+	queryParams = append(queryParams, arg.GhSourceID)
+	if len(arg.Paths) > 0 {
+		for _, v := range arg.Paths {
+			queryParams = append(queryParams, v)
+		}
+		query = strings.Replace(query, "/*SLICE:paths*/?", strings.Repeat(",?", len(arg.Paths))[1:], 1)
+	} else {
+		query = strings.Replace(query, "/*SLICE:paths*/?", "NULL", 1)
+	}
+	_, err := q.db.ExecContext(ctx, query, queryParams...)
+	return err
+}
+
+const deleteChangelog = `-- name: deleteChangelog :exec
+DELETE FROM changelogs
+WHERE workspace_id = ? AND id = ?
+`
+
+type deleteChangelogParams struct {
+	WorkspaceID string
+	ID          string
+}
+
+func (q *Queries) deleteChangelog(ctx context.Context, arg deleteChangelogParams) error {
+	_, err := q.db.ExecContext(ctx, deleteChangelog, arg.WorkspaceID, arg.ID)
+	return err
+}
+
+const deleteChangelogAssetsByType = `-- name: deleteChangelogAssetsByType :exec
+DELETE FROM changelog_assets
+WHERE workspace_id = ? AND changelog_id = ? AND asset_type = ?
+`
+
+type deleteChangelogAssetsByTypeParams struct {
+	WorkspaceID string
+	ChangelogID string
+	AssetType   string
+}
+
+func (q *Queries) deleteChangelogAssetsByType(ctx context.Context, arg deleteChangelogAssetsByTypeParams) error {
+	_, err := q.db.ExecContext(ctx, deleteChangelogAssetsByType, arg.WorkspaceID, arg.ChangelogID, arg.AssetType)
+	return err
+}
+
+const deleteChangelogI18nString = `-- name: deleteChangelogI18nString :exec
+DELETE FROM changelog_i18n
+WHERE workspace_id = ? AND changelog_id = ? AND key = ?
+`
+
+type deleteChangelogI18nStringParams struct {
+	WorkspaceID string
+	ChangelogID string
+	Key         string
+}
+
+func (q *Queries) deleteChangelogI18nString(ctx context.Context, arg deleteChangelogI18nStringParams) error {
+	_, err := q.db.ExecContext(ctx, deleteChangelogI18nString, arg.WorkspaceID, arg.ChangelogID, arg.Key)
+	return err
+}
+
+const deleteChangelogSource = `-- name: deleteChangelogSource :exec
+UPDATE changelogs
+SET source_id = NULL
+WHERE workspace_id = ? AND id = ?
+`
+
+type deleteChangelogSourceParams struct {
+	WorkspaceID string
+	ID          string
+}
+
+func (q *Queries) deleteChangelogSource(ctx context.Context, arg deleteChangelogSourceParams) error {
+	_, err := q.db.ExecContext(ctx, deleteChangelogSource, arg.WorkspaceID, arg.ID)
+	return err
+}
+
+const deleteChangelogToken = `-- name: deleteChangelogToken :execrows
+DELETE FROM changelog_tokens
+WHERE workspace_id = ? AND token_hash = ?
+`
+
+type deleteChangelogTokenParams struct {
+	WorkspaceID string
+	TokenHash   string
+}
+
+func (q *Queries) deleteChangelogToken(ctx context.Context, arg deleteChangelogTokenParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, deleteChangelogToken, arg.WorkspaceID, arg.TokenHash)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const deleteCustomDomain = `-- name: deleteCustomDomain :execrows
+DELETE FROM changelog_custom_domains
+WHERE workspace_id = ? AND changelog_id = ? AND id = ?
+`
+
+type deleteCustomDomainParams struct {
+	WorkspaceID string
+	ChangelogID string
+	ID          string
+}
+
+func (q *Queries) deleteCustomDomain(ctx context.Context, arg deleteCustomDomainParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, deleteCustomDomain, arg.WorkspaceID, arg.ChangelogID, arg.ID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const deleteCustomDomainByDomain = `-- name: deleteCustomDomainByDomain :exec
+DELETE FROM changelog_custom_domains
+WHERE workspace_id = ? AND changelog_id = ? AND domain = ?
+`
+
+type deleteCustomDomainByDomainParams struct {
+	WorkspaceID string
+	ChangelogID string
+	Domain      string
+}
+
+func (q *Queries) deleteCustomDomainByDomain(ctx context.Context, arg deleteCustomDomainByDomainParams) error {
+	_, err := q.db.ExecContext(ctx, deleteCustomDomainByDomain, arg.WorkspaceID, arg.ChangelogID, arg.Domain)
+	return err
+}
+
+const deleteEntryAttachment = `-- name: deleteEntryAttachment :execrows
+DELETE FROM entry_attachments
+WHERE workspace_id = ? AND changelog_id = ? AND entry_id = ? AND id = ?
+`
+
+type deleteEntryAttachmentParams struct {
+	WorkspaceID string
+	ChangelogID string
+	EntryID     string
+	ID          string
+}
+
+func (q *Queries) deleteEntryAttachment(ctx context.Context, arg deleteEntryAttachmentParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, deleteEntryAttachment,
+		arg.WorkspaceID,
+		arg.ChangelogID,
+		arg.EntryID,
+		arg.ID,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const deleteEntryComment = `-- name: deleteEntryComment :execrows
+DELETE FROM entry_comments
+WHERE workspace_id = ? AND changelog_id = ? AND id = ?
+`
+
+type deleteEntryCommentParams struct {
+	WorkspaceID string
+	ChangelogID string
+	ID          string
+}
+
+func (q *Queries) deleteEntryComment(ctx context.Context, arg deleteEntryCommentParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, deleteEntryComment, arg.WorkspaceID, arg.ChangelogID, arg.ID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const deleteEntryLabelsByLabel = `-- name: deleteEntryLabelsByLabel :execrows
+DELETE FROM entry_labels
+WHERE workspace_id = ? AND label_name = ?
+`
+
+type deleteEntryLabelsByLabelParams struct {
+	WorkspaceID string
+	LabelName   string
+}
+
+func (q *Queries) deleteEntryLabelsByLabel(ctx context.Context, arg deleteEntryLabelsByLabelParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, deleteEntryLabelsByLabel, arg.WorkspaceID, arg.LabelName)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const deleteGHSource = `-- name: deleteGHSource :exec
+DELETE FROM gh_sources
+WHERE workspace_id = ? AND id = ?
+`
+
+type deleteGHSourceParams struct {
+	WorkspaceID string
+	ID          string
+}
+
+func (q *Queries) deleteGHSource(ctx context.Context, arg deleteGHSourceParams) error {
+	_, err := q.db.ExecContext(ctx, deleteGHSource, arg.WorkspaceID, arg.ID)
+	return err
+}
+
+const deleteLabel = `-- name: deleteLabel :exec
+DELETE FROM labels
+WHERE workspace_id = ? AND name = ?
+`
+
+type deleteLabelParams struct {
+	WorkspaceID string
+	Name        string
+}
+
+func (q *Queries) deleteLabel(ctx context.Context, arg deleteLabelParams) error {
+	_, err := q.db.ExecContext(ctx, deleteLabel, arg.WorkspaceID, arg.Name)
+	return err
+}
+
+const deletePreviewToken = `-- name: deletePreviewToken :execrows
+DELETE FROM changelog_preview_tokens
+WHERE workspace_id = ? AND changelog_id = ? AND token_hash = ?
+`
+
+type deletePreviewTokenParams struct {
+	WorkspaceID string
+	ChangelogID string
+	TokenHash   string
+}
+
+func (q *Queries) deletePreviewToken(ctx context.Context, arg deletePreviewTokenParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, deletePreviewToken, arg.WorkspaceID, arg.ChangelogID, arg.TokenHash)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const deleteRSSToken = `-- name: deleteRSSToken :execrows
+DELETE FROM changelog_rss_tokens
+WHERE workspace_id = ? AND changelog_id = ? AND token_hash = ?
+`
+
+type deleteRSSTokenParams struct {
+	WorkspaceID string
+	ChangelogID string
+	TokenHash   string
+}
+
+func (q *Queries) deleteRSSToken(ctx context.Context, arg deleteRSSTokenParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, deleteRSSToken, arg.WorkspaceID, arg.ChangelogID, arg.TokenHash)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const deleteToken = `-- name: deleteToken :execrows
+DELETE FROM tokens
+WHERE workspace_id = ? AND id = ?
+`
+
+type deleteTokenParams struct {
+	WorkspaceID string
+	ID          apitypes.NullString
+}
+
+func (q *Queries) deleteToken(ctx context.Context, arg deleteTokenParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, deleteToken, arg.WorkspaceID, arg.ID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const deleteWebhookEndpoint = `-- name: deleteWebhookEndpoint :execrows
+DELETE FROM webhook_endpoints
+WHERE workspace_id = ? AND id = ?
+`
+
+type deleteWebhookEndpointParams struct {
+	WorkspaceID string
+	ID          string
+}
+
+func (q *Queries) deleteWebhookEndpoint(ctx context.Context, arg deleteWebhookEndpointParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, deleteWebhookEndpoint, arg.WorkspaceID, arg.ID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const deleteWorkspace = `-- name: deleteWorkspace :exec
+DELETE FROM workspaces
+WHERE id = ?
+`
+
+func (q *Queries) deleteWorkspace(ctx context.Context, id string) error {
+	_, err := q.db.ExecContext(ctx, deleteWorkspace, id)
+	return err
+}
+
+const deleteWorkspaceInvite = `-- name: deleteWorkspaceInvite :execrows
+DELETE FROM workspace_invites
+WHERE workspace_id = ? AND token_hash = ?
+`
+
+type deleteWorkspaceInviteParams struct {
+	WorkspaceID string
+	TokenHash   string
+}
+
+func (q *Queries) deleteWorkspaceInvite(ctx context.Context, arg deleteWorkspaceInviteParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, deleteWorkspaceInvite, arg.WorkspaceID, arg.TokenHash)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const deleteWorkspaceMember = `-- name: deleteWorkspaceMember :execrows
+DELETE FROM workspace_members
+WHERE workspace_id = ? AND user_id = ?
+`
+
+type deleteWorkspaceMemberParams struct {
+	WorkspaceID string
+	UserID      string
+}
+
+func (q *Queries) deleteWorkspaceMember(ctx context.Context, arg deleteWorkspaceMemberParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, deleteWorkspaceMember, arg.WorkspaceID, arg.UserID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const deleteWorkspaceSSO = `-- name: deleteWorkspaceSSO :exec
+DELETE FROM workspace_sso
+WHERE workspace_id = ?
+`
+
+func (q *Queries) deleteWorkspaceSSO(ctx context.Context, workspaceID string) error {
+	_, err := q.db.ExecContext(ctx, deleteWorkspaceSSO, workspaceID)
+	return err
+}
+
+const detachLabelFromEntry = `-- name: detachLabelFromEntry :exec
+DELETE FROM entry_labels
+WHERE workspace_id = ? AND changelog_id = ? AND entry_id = ? AND label_name = ?
+`
+
+type detachLabelFromEntryParams struct {
+	WorkspaceID string
+	ChangelogID string
+	EntryID     string
+	LabelName   string
+}
+
+func (q *Queries) detachLabelFromEntry(ctx context.Context, arg detachLabelFromEntryParams) error {
+	_, err := q.db.ExecContext(ctx, detachLabelFromEntry,
+		arg.WorkspaceID,
+		arg.ChangelogID,
+		arg.EntryID,
+		arg.LabelName,
+	)
+	return err
+}
+
+const getAPIUsage = `-- name: getAPIUsage :many
+SELECT endpoint, COUNT(*) AS count
+FROM api_requests
+WHERE workspace_id = ?1
+    AND requested_at BETWEEN ?2 AND ?3
+GROUP BY endpoint
+`
+
+type getAPIUsageParams struct {
+	WorkspaceID string
+	FromTime    int64
+	ToTime      int64
+}
+
+type getAPIUsageRow struct {
+	Endpoint string
+	Count    int64
+}
+
+func (q *Queries) getAPIUsage(ctx context.Context, arg getAPIUsageParams) ([]getAPIUsageRow, error) {
+	rows, err := q.db.QueryContext(ctx, getAPIUsage, arg.WorkspaceID, arg.FromTime, arg.ToTime)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []getAPIUsageRow
+	for rows.Next() {
+		var i getAPIUsageRow
+		if err := rows.Scan(&i.Endpoint, &i.Count); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getCachedTree = `-- name: getCachedTree :one
+SELECT workspace_id, gh_source_id, tree_json, cached_at, etag FROM gh_source_tree_cache
+WHERE workspace_id = ? AND gh_source_id = ?
+`
+
+type getCachedTreeParams struct {
+	WorkspaceID string
+	GhSourceID  string
+}
+
+func (q *Queries) getCachedTree(ctx context.Context, arg getCachedTreeParams) (ghSourceTreeCache, error) {
+	row := q.db.QueryRowContext(ctx, getCachedTree, arg.WorkspaceID, arg.GhSourceID)
+	var i ghSourceTreeCache
+	err := row.Scan(
+		&i.WorkspaceID,
+		&i.GhSourceID,
+		&i.TreeJson,
+		&i.CachedAt,
+		&i.Etag,
+	)
+	return i, err
+}
+
+const getChangelog = `-- name: getChangelog :one
+SELECT c.id, c.workspace_id, c.subdomain, c.title, c.subtitle, c.source_id, c.logo_src, c.logo_link, c.logo_alt, c.logo_height, c.logo_width, c.created_at, c.domain, c.color_scheme, c.hide_powered_by, c.protected, c.password_hash, c.analytics, c.searchable, c.favicon_src, c.sort_order, c.social_links, c.robots_txt, c.slug, c.canonical_url, cs.id, cs.workspace_id, cs.owner, cs.repo, cs.path, cs.installation_id, cs.last_fetched_at, cs.fetch_interval_seconds, cs.private_key_id, cs.private_key_blob, cs.webhook_secret
+FROM changelogs c
+LEFT JOIN changelog_source cs ON c.workspace_id = cs.workspace_id AND c.source_id = cs.id
+WHERE c.workspace_id = ? AND c.id = ?
+`
+
+type getChangelogParams struct {
+	WorkspaceID string
+	ID          string
+}
+
+type getChangelogRow struct {
+	changelog       changelog
+	ChangelogSource changelogSource
+}
+
+func (q *Queries) getChangelog(ctx context.Context, arg getChangelogParams) (getChangelogRow, error) {
+	row := q.db.QueryRowContext(ctx, getChangelog, arg.WorkspaceID, arg.ID)
+	var i getChangelogRow
+	err := row.Scan(
+		&i.changelog.ID,
+		&i.changelog.WorkspaceID,
+		&i.changelog.Subdomain,
+		&i.changelog.Title,
+		&i.changelog.Subtitle,
+		&i.changelog.SourceID,
+		&i.changelog.LogoSrc,
+		&i.changelog.LogoLink,
+		&i.changelog.LogoAlt,
+		&i.changelog.LogoHeight,
+		&i.changelog.LogoWidth,
+		&i.changelog.CreatedAt,
+		&i.changelog.Domain,
+		&i.changelog.ColorScheme,
+		&i.changelog.HidePoweredBy,
+		&i.changelog.Protected,
+		&i.changelog.PasswordHash,
+		&i.changelog.Analytics,
+		&i.changelog.Searchable,
+		&i.changelog.FaviconSrc,
+		&i.changelog.SortOrder,
+		&i.changelog.SocialLinks,
+		&i.changelog.RobotsTxt,
+		&i.changelog.Slug,
+		&i.changelog.CanonicalUrl,
+		&i.ChangelogSource.ID,
+		&i.ChangelogSource.WorkspaceID,
+		&i.ChangelogSource.Owner,
+		&i.ChangelogSource.Repo,
+		&i.ChangelogSource.Path,
+		&i.ChangelogSource.InstallationID,
+		&i.ChangelogSource.LastFetchedAt,
+		&i.ChangelogSource.FetchIntervalSeconds,
+		&i.ChangelogSource.PrivateKeyID,
+		&i.ChangelogSource.PrivateKeyBlob,
+		&i.ChangelogSource.WebhookSecret,
+	)
+	return i, err
+}
+
+const getChangelogAsset = `-- name: getChangelogAsset :one
+SELECT id, workspace_id, changelog_id, asset_type, content_type, data, created_at FROM changelog_assets
+WHERE id = ?
+`
+
+func (q *Queries) getChangelogAsset(ctx context.Context, id string) (changelogAsset, error) {
+	row := q.db.QueryRowContext(ctx, getChangelogAsset, id)
+	var i changelogAsset
+	err := row.Scan(
+		&i.ID,
+		&i.WorkspaceID,
+		&i.ChangelogID,
+		&i.AssetType,
+		&i.ContentType,
+		&i.Data,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getChangelogBrandKit = `-- name: getChangelogBrandKit :one
+SELECT c.logo_src, c.logo_link, c.logo_alt, c.logo_height, c.logo_width, c.color_scheme, c.favicon_src, c.social_links
+FROM changelogs c
+WHERE c.domain = ?1 OR c.subdomain = ?2 OR EXISTS (
+    SELECT 1 FROM changelog_custom_domains d
+    WHERE d.workspace_id = c.workspace_id AND d.changelog_id = c.id AND d.domain = ?1 AND d.verified = 1
+)
+LIMIT 1
+`
+
+type getChangelogBrandKitParams struct {
+	Domain    apitypes.NullString
+	Subdomain string
+}
+
+type getChangelogBrandKitRow struct {
+	LogoSrc     apitypes.NullString
+	LogoLink    apitypes.NullString
+	LogoAlt     apitypes.NullString
+	LogoHeight  apitypes.NullString
+	LogoWidth   apitypes.NullString
+	ColorScheme ColorScheme
+	FaviconSrc  apitypes.NullString
+	SocialLinks apitypes.NullString
+}
+
+func (q *Queries) getChangelogBrandKit(ctx context.Context, arg getChangelogBrandKitParams) (getChangelogBrandKitRow, error) {
+	row := q.db.QueryRowContext(ctx, getChangelogBrandKit, arg.Domain, arg.Subdomain)
+	var i getChangelogBrandKitRow
+	err := row.Scan(
+		&i.LogoSrc,
+		&i.LogoLink,
+		&i.LogoAlt,
+		&i.LogoHeight,
+		&i.LogoWidth,
+		&i.ColorScheme,
+		&i.FaviconSrc,
+		&i.SocialLinks,
+	)
+	return i, err
+}
+
+const getChangelogByCustomDomain = `-- name: getChangelogByCustomDomain :one
+SELECT c.id, c.workspace_id, c.subdomain, c.title, c.subtitle, c.source_id, c.logo_src, c.logo_link, c.logo_alt, c.logo_height, c.logo_width, c.created_at, c.domain, c.color_scheme, c.hide_powered_by, c.protected, c.password_hash, c.analytics, c.searchable, c.favicon_src, c.sort_order, c.social_links, c.robots_txt, c.slug, c.canonical_url, cs.id, cs.workspace_id, cs.owner, cs.repo, cs.path, cs.installation_id, cs.last_fetched_at, cs.fetch_interval_seconds, cs.private_key_id, cs.private_key_blob, cs.webhook_secret
+FROM changelog_custom_domains d
+JOIN changelogs c ON d.workspace_id = c.workspace_id AND d.changelog_id = c.id
+LEFT JOIN changelog_source cs ON c.workspace_id = cs.workspace_id AND c.source_id = cs.id
+WHERE d.domain = ? AND d.verified = 1
+`
+
+type getChangelogByCustomDomainRow struct {
+	changelog       changelog
+	ChangelogSource changelogSource
+}
+
+// first search by domain, if not found by subdomain
+func (q *Queries) getChangelogByCustomDomain(ctx context.Context, domain string) (getChangelogByCustomDomainRow, error) {
+	row := q.db.QueryRowContext(ctx, getChangelogByCustomDomain, domain)
+	var i getChangelogByCustomDomainRow
+	err := row.Scan(
+		&i.changelog.ID,
+		&i.changelog.WorkspaceID,
+		&i.changelog.Subdomain,
+		&i.changelog.Title,
+		&i.changelog.Subtitle,
+		&i.changelog.SourceID,
+		&i.changelog.LogoSrc,
+		&i.changelog.LogoLink,
+		&i.changelog.LogoAlt,
+		&i.changelog.LogoHeight,
+		&i.changelog.LogoWidth,
+		&i.changelog.CreatedAt,
+		&i.changelog.Domain,
+		&i.changelog.ColorScheme,
+		&i.changelog.HidePoweredBy,
+		&i.changelog.Protected,
+		&i.changelog.PasswordHash,
+		&i.changelog.Analytics,
+		&i.changelog.Searchable,
+		&i.changelog.FaviconSrc,
+		&i.changelog.SortOrder,
+		&i.changelog.SocialLinks,
+		&i.changelog.RobotsTxt,
+		&i.changelog.Slug,
+		&i.changelog.CanonicalUrl,
+		&i.ChangelogSource.ID,
+		&i.ChangelogSource.WorkspaceID,
+		&i.ChangelogSource.Owner,
+		&i.ChangelogSource.Repo,
+		&i.ChangelogSource.Path,
+		&i.ChangelogSource.InstallationID,
+		&i.ChangelogSource.LastFetchedAt,
+		&i.ChangelogSource.FetchIntervalSeconds,
+		&i.ChangelogSource.PrivateKeyID,
+		&i.ChangelogSource.PrivateKeyBlob,
+		&i.ChangelogSource.WebhookSecret,
+	)
+	return i, err
+}
+
+const getChangelogByDomainOrSubdomain = `-- name: getChangelogByDomainOrSubdomain :one
+SELECT c.id, c.workspace_id, c.subdomain, c.title, c.subtitle, c.source_id, c.logo_src, c.logo_link, c.logo_alt, c.logo_height, c.logo_width, c.created_at, c.domain, c.color_scheme, c.hide_powered_by, c.protected, c.password_hash, c.analytics, c.searchable, c.favicon_src, c.sort_order, c.social_links, c.robots_txt, c.slug, c.canonical_url, cs.id, cs.workspace_id, cs.owner, cs.repo, cs.path, cs.installation_id, cs.last_fetched_at, cs.fetch_interval_seconds, cs.private_key_id, cs.private_key_blob, cs.webhook_secret
+FROM changelogs c
+LEFT JOIN changelog_source cs ON c.workspace_id = cs.workspace_id AND c.source_id = cs.id
+WHERE c.domain = ? OR c.subdomain = ?
+LIMIT 1
+`
+
+type getChangelogByDomainOrSubdomainParams struct {
+	Domain    apitypes.NullString
+	Subdomain string
+}
+
+type getChangelogByDomainOrSubdomainRow struct {
+	changelog       changelog
+	ChangelogSource changelogSource
+}
+
+// first search by domain, if not found by subdomain
+func (q *Queries) getChangelogByDomainOrSubdomain(ctx context.Context, arg getChangelogByDomainOrSubdomainParams) (getChangelogByDomainOrSubdomainRow, error) {
+	row := q.db.QueryRowContext(ctx, getChangelogByDomainOrSubdomain, arg.Domain, arg.Subdomain)
+	var i getChangelogByDomainOrSubdomainRow
+	err := row.Scan(
+		&i.changelog.ID,
+		&i.changelog.WorkspaceID,
+		&i.changelog.Subdomain,
+		&i.changelog.Title,
+		&i.changelog.Subtitle,
+		&i.changelog.SourceID,
+		&i.changelog.LogoSrc,
+		&i.changelog.LogoLink,
+		&i.changelog.LogoAlt,
+		&i.changelog.LogoHeight,
+		&i.changelog.LogoWidth,
+		&i.changelog.CreatedAt,
+		&i.changelog.Domain,
+		&i.changelog.ColorScheme,
+		&i.changelog.HidePoweredBy,
+		&i.changelog.Protected,
+		&i.changelog.PasswordHash,
+		&i.changelog.Analytics,
+		&i.changelog.Searchable,
+		&i.changelog.FaviconSrc,
+		&i.changelog.SortOrder,
+		&i.changelog.SocialLinks,
+		&i.changelog.RobotsTxt,
+		&i.changelog.Slug,
+		&i.changelog.CanonicalUrl,
+		&i.ChangelogSource.ID,
+		&i.ChangelogSource.WorkspaceID,
+		&i.ChangelogSource.Owner,
+		&i.ChangelogSource.Repo,
+		&i.ChangelogSource.Path,
+		&i.ChangelogSource.InstallationID,
+		&i.ChangelogSource.LastFetchedAt,
+		&i.ChangelogSource.FetchIntervalSeconds,
+		&i.ChangelogSource.PrivateKeyID,
+		&i.ChangelogSource.PrivateKeyBlob,
+		&i.ChangelogSource.WebhookSecret,
+	)
+	return i, err
+}
+
+const getChangelogByPreviewToken = `-- name: getChangelogByPreviewToken :one
+SELECT c.id, c.workspace_id, c.subdomain, c.title, c.subtitle, c.source_id, c.logo_src, c.logo_link, c.logo_alt, c.logo_height, c.logo_width, c.created_at, c.domain, c.color_scheme, c.hide_powered_by, c.protected, c.password_hash, c.analytics, c.searchable, c.favicon_src, c.sort_order, c.social_links, c.robots_txt, c.slug, c.canonical_url, cs.id, cs.workspace_id, cs.owner, cs.repo, cs.path, cs.installation_id, cs.last_fetched_at, cs.fetch_interval_seconds, cs.private_key_id, cs.private_key_blob, cs.webhook_secret, t.expires_at
+FROM changelog_preview_tokens t
+JOIN changelogs c ON t.workspace_id = c.workspace_id AND t.changelog_id = c.id
+LEFT JOIN changelog_source cs ON c.workspace_id = cs.workspace_id AND c.source_id = cs.id
+WHERE t.token_hash = ?
+`
+
+type getChangelogByPreviewTokenRow struct {
+	changelog       changelog
+	ChangelogSource changelogSource
+	ExpiresAt       int64
+}
+
+func (q *Queries) getChangelogByPreviewToken(ctx context.Context, tokenHash string) (getChangelogByPreviewTokenRow, error) {
+	row := q.db.QueryRowContext(ctx, getChangelogByPreviewToken, tokenHash)
+	var i getChangelogByPreviewTokenRow
+	err := row.Scan(
+		&i.changelog.ID,
+		&i.changelog.WorkspaceID,
+		&i.changelog.Subdomain,
+		&i.changelog.Title,
+		&i.changelog.Subtitle,
+		&i.changelog.SourceID,
+		&i.changelog.LogoSrc,
+		&i.changelog.LogoLink,
+		&i.changelog.LogoAlt,
+		&i.changelog.LogoHeight,
+		&i.changelog.LogoWidth,
+		&i.changelog.CreatedAt,
+		&i.changelog.Domain,
+		&i.changelog.ColorScheme,
+		&i.changelog.HidePoweredBy,
+		&i.changelog.Protected,
+		&i.changelog.PasswordHash,
+		&i.changelog.Analytics,
+		&i.changelog.Searchable,
+		&i.changelog.FaviconSrc,
+		&i.changelog.SortOrder,
+		&i.changelog.SocialLinks,
+		&i.changelog.RobotsTxt,
+		&i.changelog.Slug,
+		&i.changelog.CanonicalUrl,
+		&i.ChangelogSource.ID,
+		&i.ChangelogSource.WorkspaceID,
+		&i.ChangelogSource.Owner,
+		&i.ChangelogSource.Repo,
+		&i.ChangelogSource.Path,
+		&i.ChangelogSource.InstallationID,
+		&i.ChangelogSource.LastFetchedAt,
+		&i.ChangelogSource.FetchIntervalSeconds,
+		&i.ChangelogSource.PrivateKeyID,
+		&i.ChangelogSource.PrivateKeyBlob,
+		&i.ChangelogSource.WebhookSecret,
+		&i.ExpiresAt,
+	)
+	return i, err
+}
+
+const getChangelogByRSSToken = `-- name: getChangelogByRSSToken :one
+SELECT c.id, c.workspace_id, c.subdomain, c.title, c.subtitle, c.source_id, c.logo_src, c.logo_link, c.logo_alt, c.logo_height, c.logo_width, c.created_at, c.domain, c.color_scheme, c.hide_powered_by, c.protected, c.password_hash, c.analytics, c.searchable, c.favicon_src, c.sort_order, c.social_links, c.robots_txt, c.slug, c.canonical_url, cs.id, cs.workspace_id, cs.owner, cs.repo, cs.path, cs.installation_id, cs.last_fetched_at, cs.fetch_interval_seconds, cs.private_key_id, cs.private_key_blob, cs.webhook_secret
+FROM changelog_rss_tokens t
+JOIN changelogs c ON t.workspace_id = c.workspace_id AND t.changelog_id = c.id
+LEFT JOIN changelog_source cs ON c.workspace_id = cs.workspace_id AND c.source_id = cs.id
+WHERE t.token_hash = ?
+`
+
+type getChangelogByRSSTokenRow struct {
+	changelog       changelog
+	ChangelogSource changelogSource
+}
+
+func (q *Queries) getChangelogByRSSToken(ctx context.Context, tokenHash string) (getChangelogByRSSTokenRow, error) {
+	row := q.db.QueryRowContext(ctx, getChangelogByRSSToken, tokenHash)
+	var i getChangelogByRSSTokenRow
+	err := row.Scan(
+		&i.changelog.ID,
+		&i.changelog.WorkspaceID,
+		&i.changelog.Subdomain,
+		&i.changelog.Title,
+		&i.changelog.Subtitle,
+		&i.changelog.SourceID,
+		&i.changelog.LogoSrc,
+		&i.changelog.LogoLink,
+		&i.changelog.LogoAlt,
+		&i.changelog.LogoHeight,
+		&i.changelog.LogoWidth,
+		&i.changelog.CreatedAt,
+		&i.changelog.Domain,
+		&i.changelog.ColorScheme,
+		&i.changelog.HidePoweredBy,
+		&i.changelog.Protected,
+		&i.changelog.PasswordHash,
+		&i.changelog.Analytics,
+		&i.changelog.Searchable,
+		&i.changelog.FaviconSrc,
+		&i.changelog.SortOrder,
+		&i.changelog.SocialLinks,
+		&i.changelog.RobotsTxt,
+		&i.changelog.Slug,
+		&i.changelog.CanonicalUrl,
+		&i.ChangelogSource.ID,
+		&i.ChangelogSource.WorkspaceID,
+		&i.ChangelogSource.Owner,
+		&i.ChangelogSource.Repo,
+		&i.ChangelogSource.Path,
+		&i.ChangelogSource.InstallationID,
+		&i.ChangelogSource.LastFetchedAt,
+		&i.ChangelogSource.FetchIntervalSeconds,
+		&i.ChangelogSource.PrivateKeyID,
+		&i.ChangelogSource.PrivateKeyBlob,
+		&i.ChangelogSource.WebhookSecret,
+	)
+	return i, err
+}
+
+const getChangelogBySlug = `-- name: getChangelogBySlug :one
+SELECT c.id, c.workspace_id, c.subdomain, c.title, c.subtitle, c.source_id, c.logo_src, c.logo_link, c.logo_alt, c.logo_height, c.logo_width, c.created_at, c.domain, c.color_scheme, c.hide_powered_by, c.protected, c.password_hash, c.analytics, c.searchable, c.favicon_src, c.sort_order, c.social_links, c.robots_txt, c.slug, c.canonical_url, cs.id, cs.workspace_id, cs.owner, cs.repo, cs.path, cs.installation_id, cs.last_fetched_at, cs.fetch_interval_seconds, cs.private_key_id, cs.private_key_blob, cs.webhook_secret
+FROM changelogs c
+LEFT JOIN changelog_source cs ON c.workspace_id = cs.workspace_id AND c.source_id = cs.id
+WHERE c.workspace_id = ? AND c.slug = ?
+`
+
+type getChangelogBySlugParams struct {
+	WorkspaceID string
+	Slug        apitypes.NullString
+}
+
+type getChangelogBySlugRow struct {
+	changelog       changelog
+	ChangelogSource changelogSource
+}
+
+func (q *Queries) getChangelogBySlug(ctx context.Context, arg getChangelogBySlugParams) (getChangelogBySlugRow, error) {
+	row := q.db.QueryRowContext(ctx, getChangelogBySlug, arg.WorkspaceID, arg.Slug)
+	var i getChangelogBySlugRow
+	err := row.Scan(
+		&i.changelog.ID,
+		&i.changelog.WorkspaceID,
+		&i.changelog.Subdomain,
+		&i.changelog.Title,
+		&i.changelog.Subtitle,
+		&i.changelog.SourceID,
+		&i.changelog.LogoSrc,
+		&i.changelog.LogoLink,
+		&i.changelog.LogoAlt,
+		&i.changelog.LogoHeight,
+		&i.changelog.LogoWidth,
+		&i.changelog.CreatedAt,
+		&i.changelog.Domain,
+		&i.changelog.ColorScheme,
+		&i.changelog.HidePoweredBy,
+		&i.changelog.Protected,
+		&i.changelog.PasswordHash,
+		&i.changelog.Analytics,
+		&i.changelog.Searchable,
+		&i.changelog.FaviconSrc,
+		&i.changelog.SortOrder,
+		&i.changelog.SocialLinks,
+		&i.changelog.RobotsTxt,
+		&i.changelog.Slug,
+		&i.changelog.CanonicalUrl,
+		&i.ChangelogSource.ID,
+		&i.ChangelogSource.WorkspaceID,
+		&i.ChangelogSource.Owner,
+		&i.ChangelogSource.Repo,
+		&i.ChangelogSource.Path,
+		&i.ChangelogSource.InstallationID,
+		&i.ChangelogSource.LastFetchedAt,
+		&i.ChangelogSource.FetchIntervalSeconds,
+		&i.ChangelogSource.PrivateKeyID,
+		&i.ChangelogSource.PrivateKeyBlob,
+		&i.ChangelogSource.WebhookSecret,
+	)
+	return i, err
+}
+
+const getChangelogBySubdomainOrSlug = `-- name: getChangelogBySubdomainOrSlug :one
+SELECT c.id, c.workspace_id, c.subdomain, c.title, c.subtitle, c.source_id, c.logo_src, c.logo_link, c.logo_alt, c.logo_height, c.logo_width, c.created_at, c.domain, c.color_scheme, c.hide_powered_by, c.protected, c.password_hash, c.analytics, c.searchable, c.favicon_src, c.sort_order, c.social_links, c.robots_txt, c.slug, c.canonical_url, cs.id, cs.workspace_id, cs.owner, cs.repo, cs.path, cs.installation_id, cs.last_fetched_at, cs.fetch_interval_seconds, cs.private_key_id, cs.private_key_blob, cs.webhook_secret
+FROM changelogs c
+LEFT JOIN changelog_source cs ON c.workspace_id = cs.workspace_id AND c.source_id = cs.id
+-- prefer the changelog with the subdomain over the one with the slug
+WHERE c.subdomain = ?1 OR c.slug = ?2
+ORDER BY c.subdomain = ?1 DESC
+LIMIT 1
+`
+
+type getChangelogBySubdomainOrSlugParams struct {
+	Subdomain string
+	Slug      apitypes.NullString
+}
+
+type getChangelogBySubdomainOrSlugRow struct {
+	changelog       changelog
+	ChangelogSource changelogSource
+}
+
+func (q *Queries) getChangelogBySubdomainOrSlug(ctx context.Context, arg getChangelogBySubdomainOrSlugParams) (getChangelogBySubdomainOrSlugRow, error) {
+	row := q.db.QueryRowContext(ctx, getChangelogBySubdomainOrSlug, arg.Subdomain, arg.Slug)
+	var i getChangelogBySubdomainOrSlugRow
+	err := row.Scan(
+		&i.changelog.ID,
+		&i.changelog.WorkspaceID,
+		&i.changelog.Subdomain,
+		&i.changelog.Title,
+		&i.changelog.Subtitle,
+		&i.changelog.SourceID,
+		&i.changelog.LogoSrc,
+		&i.changelog.LogoLink,
+		&i.changelog.LogoAlt,
+		&i.changelog.LogoHeight,
+		&i.changelog.LogoWidth,
+		&i.changelog.CreatedAt,
+		&i.changelog.Domain,
+		&i.changelog.ColorScheme,
+		&i.changelog.HidePoweredBy,
+		&i.changelog.Protected,
+		&i.changelog.PasswordHash,
+		&i.changelog.Analytics,
+		&i.changelog.Searchable,
+		&i.changelog.FaviconSrc,
+		&i.changelog.SortOrder,
+		&i.changelog.SocialLinks,
+		&i.changelog.RobotsTxt,
+		&i.changelog.Slug,
+		&i.changelog.CanonicalUrl,
+		&i.ChangelogSource.ID,
+		&i.ChangelogSource.WorkspaceID,
+		&i.ChangelogSource.Owner,
+		&i.ChangelogSource.Repo,
+		&i.ChangelogSource.Path,
+		&i.ChangelogSource.InstallationID,
+		&i.ChangelogSource.LastFetchedAt,
+		&i.ChangelogSource.FetchIntervalSeconds,
+		&i.ChangelogSource.PrivateKeyID,
+		&i.ChangelogSource.PrivateKeyBlob,
+		&i.ChangelogSource.WebhookSecret,
+	)
+	return i, err
+}
+
+const getChangelogByToken = `-- name: getChangelogByToken :one
+SELECT c.id, c.workspace_id, c.subdomain, c.title, c.subtitle, c.source_id, c.logo_src, c.logo_link, c.logo_alt, c.logo_height, c.logo_width, c.created_at, c.domain, c.color_scheme, c.hide_powered_by, c.protected, c.password_hash, c.analytics, c.searchable, c.favicon_src, c.sort_order, c.social_links, c.robots_txt, c.slug, c.canonical_url, cs.id, cs.workspace_id, cs.owner, cs.repo, cs.path, cs.installation_id, cs.last_fetched_at, cs.fetch_interval_seconds, cs.private_key_id, cs.private_key_blob, cs.webhook_secret
+FROM changelog_tokens t
+JOIN changelogs c ON t.workspace_id = c.workspace_id AND t.changelog_id = c.id
+LEFT JOIN changelog_source cs ON c.workspace_id = cs.workspace_id AND c.source_id = cs.id
+WHERE t.token_hash = ?
+`
+
+type getChangelogByTokenRow struct {
+	changelog       changelog
+	ChangelogSource changelogSource
+}
+
+func (q *Queries) getChangelogByToken(ctx context.Context, tokenHash string) (getChangelogByTokenRow, error) {
+	row := q.db.QueryRowContext(ctx, getChangelogByToken, tokenHash)
+	var i getChangelogByTokenRow
+	err := row.Scan(
+		&i.changelog.ID,
+		&i.changelog.WorkspaceID,
+		&i.changelog.Subdomain,
+		&i.changelog.Title,
+		&i.changelog.Subtitle,
+		&i.changelog.SourceID,
+		&i.changelog.LogoSrc,
+		&i.changelog.LogoLink,
+		&i.changelog.LogoAlt,
+		&i.changelog.LogoHeight,
+		&i.changelog.LogoWidth,
+		&i.changelog.CreatedAt,
+		&i.changelog.Domain,
+		&i.changelog.ColorScheme,
+		&i.changelog.HidePoweredBy,
+		&i.changelog.Protected,
+		&i.changelog.PasswordHash,
+		&i.changelog.Analytics,
+		&i.changelog.Searchable,
+		&i.changelog.FaviconSrc,
+		&i.changelog.SortOrder,
+		&i.changelog.SocialLinks,
+		&i.changelog.RobotsTxt,
+		&i.changelog.Slug,
+		&i.changelog.CanonicalUrl,
+		&i.ChangelogSource.ID,
+		&i.ChangelogSource.WorkspaceID,
+		&i.ChangelogSource.Owner,
+		&i.ChangelogSource.Repo,
+		&i.ChangelogSource.Path,
+		&i.ChangelogSource.InstallationID,
+		&i.ChangelogSource.LastFetchedAt,
+		&i.ChangelogSource.FetchIntervalSeconds,
+		&i.ChangelogSource.PrivateKeyID,
+		&i.ChangelogSource.PrivateKeyBlob,
+		&i.ChangelogSource.WebhookSecret,
+	)
+	return i, err
+}
+
+const getChangelogCanonicalURL = `-- name: getChangelogCanonicalURL :one
+SELECT canonical_url, domain, subdomain FROM changelogs
+WHERE domain = ? OR subdomain = ?
+LIMIT 1
+`
+
+type getChangelogCanonicalURLParams struct {
+	Domain    apitypes.NullString
+	Subdomain string
+}
+
+type getChangelogCanonicalURLRow struct {
+	CanonicalUrl apitypes.NullString
+	Domain       apitypes.NullString
+	Subdomain    string
+}
+
+func (q *Queries) getChangelogCanonicalURL(ctx context.Context, arg getChangelogCanonicalURLParams) (getChangelogCanonicalURLRow, error) {
+	row := q.db.QueryRowContext(ctx, getChangelogCanonicalURL, arg.Domain, arg.Subdomain)
+	var i getChangelogCanonicalURLRow
+	err := row.Scan(&i.CanonicalUrl, &i.Domain, &i.Subdomain)
+	return i, err
+}
+
+const getChangelogContentHash = `-- name: getChangelogContentHash :one
+SELECT hash FROM changelog_content_hashes
+WHERE workspace_id = ? AND changelog_id = ?
+`
+
+type getChangelogContentHashParams struct {
+	WorkspaceID string
+	ChangelogID string
+}
+
+func (q *Queries) getChangelogContentHash(ctx context.Context, arg getChangelogContentHashParams) (string, error) {
+	row := q.db.QueryRowContext(ctx, getChangelogContentHash, arg.WorkspaceID, arg.ChangelogID)
+	var hash string
+	err := row.Scan(&hash)
+	return hash, err
+}
+
+const getChangelogHost = `-- name: getChangelogHost :one
+SELECT domain, subdomain FROM changelogs
+WHERE workspace_id = ? AND id = ?
+`
+
+type getChangelogHostParams struct {
+	WorkspaceID string
+	ID          string
+}
+
+type getChangelogHostRow struct {
+	Domain    apitypes.NullString
+	Subdomain string
+}
+
+func (q *Queries) getChangelogHost(ctx context.Context, arg getChangelogHostParams) (getChangelogHostRow, error) {
+	row := q.db.QueryRowContext(ctx, getChangelogHost, arg.WorkspaceID, arg.ID)
+	var i getChangelogHostRow
+	err := row.Scan(&i.Domain, &i.Subdomain)
+	return i, err
+}
+
+const getChangelogOGImage = `-- name: getChangelogOGImage :one
+SELECT workspace_id, changelog_id, image, generated_at, etag FROM changelog_og_images
+WHERE workspace_id = ? AND changelog_id = ?
+`
+
+type getChangelogOGImageParams struct {
+	WorkspaceID string
+	ChangelogID string
+}
+
+func (q *Queries) getChangelogOGImage(ctx context.Context, arg getChangelogOGImageParams) (changelogOgImage, error) {
+	row := q.db.QueryRowContext(ctx, getChangelogOGImage, arg.WorkspaceID, arg.ChangelogID)
+	var i changelogOgImage
+	err := row.Scan(
+		&i.WorkspaceID,
+		&i.ChangelogID,
+		&i.Image,
+		&i.GeneratedAt,
+		&i.Etag,
+	)
+	return i, err
+}
+
+const getChangelogRobotsTxt = `-- name: getChangelogRobotsTxt :one
+SELECT robots_txt FROM changelogs
+WHERE domain = ? OR subdomain = ?
+LIMIT 1
+`
+
+type getChangelogRobotsTxtParams struct {
+	Domain    apitypes.NullString
+	Subdomain string
+}
+
+func (q *Queries) getChangelogRobotsTxt(ctx context.Context, arg getChangelogRobotsTxtParams) (apitypes.NullString, error) {
+	row := q.db.QueryRowContext(ctx, getChangelogRobotsTxt, arg.Domain, arg.Subdomain)
+	var robots_txt apitypes.NullString
+	err := row.Scan(&robots_txt)
+	return robots_txt, err
+}
+
+const getChangelogSEOSignals = `-- name: getChangelogSEOSignals :one
+SELECT
+    c.title,
+    c.subtitle,
+    c.domain,
+    c.source_id,
+    EXISTS (SELECT 1 FROM changelog_og_images o WHERE o.workspace_id = c.workspace_id AND o.changelog_id = c.id) AS has_og_image
+FROM changelogs c
+WHERE c.workspace_id = ? AND c.id = ?
+`
+
+type getChangelogSEOSignalsParams struct {
+	WorkspaceID string
+	ID          string
+}
+
+type getChangelogSEOSignalsRow struct {
+	Title      apitypes.NullString
+	Subtitle   apitypes.NullString
+	Domain     apitypes.NullString
+	SourceID   apitypes.NullString
+	HasOgImage int64
+}
+
+func (q *Queries) getChangelogSEOSignals(ctx context.Context, arg getChangelogSEOSignalsParams) (getChangelogSEOSignalsRow, error) {
+	row := q.db.QueryRowContext(ctx, getChangelogSEOSignals, arg.WorkspaceID, arg.ID)
+	var i getChangelogSEOSignalsRow
+	err := row.Scan(
+		&i.Title,
+		&i.Subtitle,
+		&i.Domain,
+		&i.SourceID,
+		&i.HasOgImage,
+	)
+	return i, err
+}
+
+const getChangelogSnapshot = `-- name: getChangelogSnapshot :one
+SELECT id, workspace_id, changelog_id, snapshot, created_at FROM changelog_snapshots
+WHERE workspace_id = ? AND changelog_id = ? AND id = ?
+`
+
+type getChangelogSnapshotParams struct {
+	WorkspaceID string
+	ChangelogID string
+	ID          string
+}
+
+func (q *Queries) getChangelogSnapshot(ctx context.Context, arg getChangelogSnapshotParams) (changelogSnapshot, error) {
+	row := q.db.QueryRowContext(ctx, getChangelogSnapshot, arg.WorkspaceID, arg.ChangelogID, arg.ID)
+	var i changelogSnapshot
+	err := row.Scan(
+		&i.ID,
+		&i.WorkspaceID,
+		&i.ChangelogID,
+		&i.Snapshot,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getChangelogTheme = `-- name: getChangelogTheme :one
+SELECT workspace_id, changelog_id, font_family, accent_color, background_color, text_color, border_radius_px, custom_css FROM changelog_themes
+WHERE workspace_id = ? AND changelog_id = ?
+`
+
+type getChangelogThemeParams struct {
+	WorkspaceID string
+	ChangelogID string
+}
+
+func (q *Queries) getChangelogTheme(ctx context.Context, arg getChangelogThemeParams) (changelogTheme, error) {
+	row := q.db.QueryRowContext(ctx, getChangelogTheme, arg.WorkspaceID, arg.ChangelogID)
+	var i changelogTheme
+	err := row.Scan(
+		&i.WorkspaceID,
+		&i.ChangelogID,
+		&i.FontFamily,
+		&i.AccentColor,
+		&i.BackgroundColor,
+		&i.TextColor,
+		&i.BorderRadiusPx,
+		&i.CustomCss,
+	)
+	return i, err
+}
+
+const getDomainVerification = `-- name: getDomainVerification :one
+SELECT v.workspace_id, v.changelog_id, v.domain, v.cname_target, v.verified_at, v.last_checked_at, v.check_error, c.domain AS changelog_domain
+FROM domain_verifications v
+JOIN changelogs c ON v.workspace_id = c.workspace_id AND v.changelog_id = c.id
+WHERE v.workspace_id = ? AND v.changelog_id = ?
+`
+
+type getDomainVerificationParams struct {
+	WorkspaceID string
+	ChangelogID string
+}
+
+type getDomainVerificationRow struct {
+	domainVerification domainVerification
+	ChangelogDomain    apitypes.NullString
+}
+
+func (q *Queries) getDomainVerification(ctx context.Context, arg getDomainVerificationParams) (getDomainVerificationRow, error) {
+	row := q.db.QueryRowContext(ctx, getDomainVerification, arg.WorkspaceID, arg.ChangelogID)
+	var i getDomainVerificationRow
+	err := row.Scan(
+		&i.domainVerification.WorkspaceID,
+		&i.domainVerification.ChangelogID,
+		&i.domainVerification.Domain,
+		&i.domainVerification.CnameTarget,
+		&i.domainVerification.VerifiedAt,
+		&i.domainVerification.LastCheckedAt,
+		&i.domainVerification.CheckError,
+		&i.ChangelogDomain,
+	)
+	return i, err
+}
+
+const getEmailDelivery = `-- name: getEmailDelivery :one
+SELECT id, workspace_id, changelog_id, to_email, subject, template, sent_at, status, error FROM email_deliveries
+WHERE workspace_id = ? AND id = ?
+`
+
+type getEmailDeliveryParams struct {
+	WorkspaceID string
+	ID          string
+}
+
+func (q *Queries) getEmailDelivery(ctx context.Context, arg getEmailDeliveryParams) (emailDelivery, error) {
+	row := q.db.QueryRowContext(ctx, getEmailDelivery, arg.WorkspaceID, arg.ID)
+	var i emailDelivery
+	err := row.Scan(
+		&i.ID,
+		&i.WorkspaceID,
+		&i.ChangelogID,
+		&i.ToEmail,
+		&i.Subject,
+		&i.Template,
+		&i.SentAt,
+		&i.Status,
+		&i.Error,
+	)
+	return i, err
+}
+
+const getGHSource = `-- name: getGHSource :one
+SELECT gh.id, gh.workspace_id, gh.owner, gh.repo, gh.path, gh.installation_id, gh.last_fetched_at, gh.fetch_interval_seconds, gh.private_key_id, gh.private_key_blob, gh.webhook_secret, COALESCE(h.consecutive_failures, 0) AS consecutive_failures
+FROM gh_sources gh
+LEFT JOIN gh_source_health h ON gh.workspace_id = h.workspace_id AND gh.id = h.gh_source_id
+WHERE gh.workspace_id = ? AND gh.id = ?
+`
+
+type getGHSourceParams struct {
+	WorkspaceID string
+	ID          string
+}
+
+type getGHSourceRow struct {
+	ghSource            ghSource
+	ConsecutiveFailures int64
+}
+
+func (q *Queries) getGHSource(ctx context.Context, arg getGHSourceParams) (getGHSourceRow, error) {
+	row := q.db.QueryRowContext(ctx, getGHSource, arg.WorkspaceID, arg.ID)
+	var i getGHSourceRow
+	err := row.Scan(
+		&i.ghSource.ID,
+		&i.ghSource.WorkspaceID,
+		&i.ghSource.Owner,
+		&i.ghSource.Repo,
+		&i.ghSource.Path,
+		&i.ghSource.InstallationID,
+		&i.ghSource.LastFetchedAt,
+		&i.ghSource.FetchIntervalSeconds,
+		&i.ghSource.PrivateKeyID,
+		&i.ghSource.PrivateKeyBlob,
+		&i.ghSource.WebhookSecret,
+		&i.ConsecutiveFailures,
+	)
+	return i, err
+}
+
+const getGHSourceByChangelog = `-- name: getGHSourceByChangelog :one
+SELECT gh.id, gh.workspace_id, gh.owner, gh.repo, gh.path, gh.installation_id, gh.last_fetched_at, gh.fetch_interval_seconds, gh.private_key_id, gh.private_key_blob, gh.webhook_secret, COALESCE(h.consecutive_failures, 0) AS consecutive_failures
+FROM changelogs c
+JOIN gh_sources gh ON c.workspace_id = gh.workspace_id AND c.source_id = gh.id
+LEFT JOIN gh_source_health h ON gh.workspace_id = h.workspace_id AND gh.id = h.gh_source_id
+WHERE c.workspace_id = ? AND c.id = ?
+`
+
+type getGHSourceByChangelogParams struct {
+	WorkspaceID string
+	ID          string
+}
+
+type getGHSourceByChangelogRow struct {
+	ghSource            ghSource
+	ConsecutiveFailures int64
+}
+
+func (q *Queries) getGHSourceByChangelog(ctx context.Context, arg getGHSourceByChangelogParams) (getGHSourceByChangelogRow, error) {
+	row := q.db.QueryRowContext(ctx, getGHSourceByChangelog, arg.WorkspaceID, arg.ID)
+	var i getGHSourceByChangelogRow
+	err := row.Scan(
+		&i.ghSource.ID,
+		&i.ghSource.WorkspaceID,
+		&i.ghSource.Owner,
+		&i.ghSource.Repo,
+		&i.ghSource.Path,
+		&i.ghSource.InstallationID,
+		&i.ghSource.LastFetchedAt,
+		&i.ghSource.FetchIntervalSeconds,
+		&i.ghSource.PrivateKeyID,
+		&i.ghSource.PrivateKeyBlob,
+		&i.ghSource.WebhookSecret,
+		&i.ConsecutiveFailures,
+	)
+	return i, err
+}
+
+const getGHSourceHealth = `-- name: getGHSourceHealth :one
+SELECT h.workspace_id, h.gh_source_id, h.last_error, h.consecutive_failures, h.last_checked_at
+FROM gh_source_health h
+WHERE h.workspace_id = ? AND h.gh_source_id = ?
+`
+
+type getGHSourceHealthParams struct {
+	WorkspaceID string
+	GhSourceID  string
+}
+
+type getGHSourceHealthRow struct {
+	ghSourceHealth ghSourceHealth
+}
+
+func (q *Queries) getGHSourceHealth(ctx context.Context, arg getGHSourceHealthParams) (getGHSourceHealthRow, error) {
+	row := q.db.QueryRowContext(ctx, getGHSourceHealth, arg.WorkspaceID, arg.GhSourceID)
+	var i getGHSourceHealthRow
+	err := row.Scan(
+		&i.ghSourceHealth.WorkspaceID,
+		&i.ghSourceHealth.GhSourceID,
+		&i.ghSourceHealth.LastError,
+		&i.ghSourceHealth.ConsecutiveFailures,
+		&i.ghSourceHealth.LastCheckedAt,
+	)
+	return i, err
+}
+
+const getGHSourceWebhookSecret = `-- name: getGHSourceWebhookSecret :one
+SELECT webhook_secret FROM gh_sources
+WHERE workspace_id = ? AND id = ?
+`
+
+type getGHSourceWebhookSecretParams struct {
+	WorkspaceID string
+	ID          string
+}
+
+func (q *Queries) getGHSourceWebhookSecret(ctx context.Context, arg getGHSourceWebhookSecretParams) (apitypes.NullString, error) {
+	row := q.db.QueryRowContext(ctx, getGHSourceWebhookSecret, arg.WorkspaceID, arg.ID)
+	var webhook_secret apitypes.NullString
+	err := row.Scan(&webhook_secret)
+	return webhook_secret, err
+}
+
+const getHeatmapByDayOfWeek = `-- name: getHeatmapByDayOfWeek :many
+SELECT CAST(strftime('%w', accessed_at, 'unixepoch') AS INTEGER) AS day, COUNT(*) AS views
+FROM changelog_access_log
+WHERE workspace_id = ?1 AND changelog_id = ?2 AND accessed_at BETWEEN ?3 AND ?4
+GROUP BY day
+`
+
+type getHeatmapByDayOfWeekParams struct {
+	WorkspaceID string
+	ChangelogID string
+	FromTime    int64
+	ToTime      int64
+}
+
+type getHeatmapByDayOfWeekRow struct {
+	Day   int64
+	Views int64
+}
+
+func (q *Queries) getHeatmapByDayOfWeek(ctx context.Context, arg getHeatmapByDayOfWeekParams) ([]getHeatmapByDayOfWeekRow, error) {
+	rows, err := q.db.QueryContext(ctx, getHeatmapByDayOfWeek,
+		arg.WorkspaceID,
+		arg.ChangelogID,
+		arg.FromTime,
+		arg.ToTime,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []getHeatmapByDayOfWeekRow
+	for rows.Next() {
+		var i getHeatmapByDayOfWeekRow
+		if err := rows.Scan(&i.Day, &i.Views); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getHeatmapByHour = `-- name: getHeatmapByHour :many
+SELECT CAST(strftime('%H', accessed_at, 'unixepoch') AS INTEGER) AS hour, COUNT(*) AS views
+FROM changelog_access_log
+WHERE workspace_id = ?1 AND changelog_id = ?2 AND accessed_at BETWEEN ?3 AND ?4
+GROUP BY hour
+`
+
+type getHeatmapByHourParams struct {
+	WorkspaceID string
+	ChangelogID string
+	FromTime    int64
+	ToTime      int64
+}
+
+type getHeatmapByHourRow struct {
+	Hour  int64
+	Views int64
+}
+
+func (q *Queries) getHeatmapByHour(ctx context.Context, arg getHeatmapByHourParams) ([]getHeatmapByHourRow, error) {
+	rows, err := q.db.QueryContext(ctx, getHeatmapByHour,
+		arg.WorkspaceID,
+		arg.ChangelogID,
+		arg.FromTime,
+		arg.ToTime,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []getHeatmapByHourRow
+	for rows.Next() {
+		var i getHeatmapByHourRow
+		if err := rows.Scan(&i.Hour, &i.Views); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getInstallationIDByWorkspace = `-- name: getInstallationIDByWorkspace :one
+SELECT installation_id FROM workspace_gh_installations
+WHERE workspace_id = ?
+`
+
+func (q *Queries) getInstallationIDByWorkspace(ctx context.Context, workspaceID string) (int64, error) {
+	row := q.db.QueryRowContext(ctx, getInstallationIDByWorkspace, workspaceID)
+	var installation_id int64
+	err := row.Scan(&installation_id)
+	return installation_id, err
+}
+
+const getInstallationToken = `-- name: getInstallationToken :one
+SELECT token FROM gh_installation_tokens
+WHERE installation_id = ? AND expires_at > ?
+`
+
+type getInstallationTokenParams struct {
+	InstallationID int64
+	ExpiresAt      int64
+}
+
+func (q *Queries) getInstallationToken(ctx context.Context, arg getInstallationTokenParams) (string, error) {
+	row := q.db.QueryRowContext(ctx, getInstallationToken, arg.InstallationID, arg.ExpiresAt)
+	var token string
+	err := row.Scan(&token)
+	return token, err
+}
+
+const getToken = `-- name: getToken :one
+SELECT "key", workspace_id, created_at, expires_at, scopes, label, last_used_at, id FROM tokens
+WHERE key = ?
+`
+
+func (q *Queries) getToken(ctx context.Context, key string) (token, error) {
+	row := q.db.QueryRowContext(ctx, getToken, key)
+	var i token
+	err := row.Scan(
+		&i.Key,
+		&i.WorkspaceID,
+		&i.CreatedAt,
+		&i.ExpiresAt,
+		&i.Scopes,
+		&i.Label,
+		&i.LastUsedAt,
+		&i.ID,
+	)
+	return i, err
+}
+
+const getTokenByWorkspace = `-- name: getTokenByWorkspace :one
+
+SELECT key FROM tokens
+WHERE workspace_id = ?
+ORDER BY created_at DESC, key DESC
+LIMIT 1
+`
+
+// technically a workspace can have multiple tokens, return the most recently created one
+// tokens created in the same second are ordered by key, so the same token is returned every time
+func (q *Queries) getTokenByWorkspace(ctx context.Context, workspaceID string) (string, error) {
+	row := q.db.QueryRowContext(ctx, getTokenByWorkspace, workspaceID)
+	var key string
+	err := row.Scan(&key)
+	return key, err
+}
+
+const getTopSearchQueries = `-- name: getTopSearchQueries :many
+SELECT q.query, COUNT(*) AS count
+FROM search_queries q
+WHERE q.workspace_id = ?1
+    AND q.changelog_id = ?2
+    AND q.searched_at BETWEEN ?3 AND ?4
+GROUP BY q.query
+ORDER BY count DESC, q.query
+LIMIT ?5
+`
+
+type getTopSearchQueriesParams struct {
+	WorkspaceID string
+	ChangelogID string
+	FromTime    int64
+	ToTime      int64
+	MaxResults  int64
+}
+
+type getTopSearchQueriesRow struct {
+	Query string
+	Count int64
+}
+
+func (q *Queries) getTopSearchQueries(ctx context.Context, arg getTopSearchQueriesParams) ([]getTopSearchQueriesRow, error) {
+	rows, err := q.db.QueryContext(ctx, getTopSearchQueries,
+		arg.WorkspaceID,
+		arg.ChangelogID,
+		arg.FromTime,
+		arg.ToTime,
+		arg.MaxResults,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []getTopSearchQueriesRow
+	for rows.Next() {
+		var i getTopSearchQueriesRow
+		if err := rows.Scan(&i.Query, &i.Count); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getTrafficSources = `-- name: getTrafficSources :many
+SELECT CASE WHEN host = '' THEN '(direct)' ELSE host END AS domain, COUNT(*) AS visits
+FROM (
+    SELECT lower(substr(rest, 1, instr(rest, '/') - 1)) AS host
+    FROM (
+        -- strips the scheme and ends the hostname at the first /, ? or #
+        SELECT replace(replace(
+            CASE WHEN instr(referer, '://') > 0 THEN substr(referer, instr(referer, '://') + 3) ELSE COALESCE(referer, '') END,
+            '?', '/'), '#', '/') || '/' AS rest
+        FROM changelog_access_log
+        WHERE workspace_id = ?1 AND changelog_id = ?2 AND accessed_at BETWEEN ?3 AND ?4
+    )
+)
+GROUP BY domain
+ORDER BY visits DESC, domain
+LIMIT ?5
+`
+
+type getTrafficSourcesParams struct {
+	WorkspaceID string
+	ChangelogID string
+	FromTime    int64
+	ToTime      int64
+	MaxResults  int64
+}
+
+type getTrafficSourcesRow struct {
+	Domain string
+	Visits int64
+}
+
+func (q *Queries) getTrafficSources(ctx context.Context, arg getTrafficSourcesParams) ([]getTrafficSourcesRow, error) {
+	rows, err := q.db.QueryContext(ctx, getTrafficSources,
+		arg.WorkspaceID,
+		arg.ChangelogID,
+		arg.FromTime,
+		arg.ToTime,
+		arg.MaxResults,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []getTrafficSourcesRow
+	for rows.Next() {
+		var i getTrafficSourcesRow
+		if err := rows.Scan(&i.Domain, &i.Visits); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getWebhookEndpoint = `-- name: getWebhookEndpoint :one
+SELECT id, changelog_id, workspace_id, url, secret, events, active, created_at FROM webhook_endpoints
+WHERE workspace_id = ? AND id = ?
+`
+
+type getWebhookEndpointParams struct {
+	WorkspaceID string
+	ID          string
+}
+
+func (q *Queries) getWebhookEndpoint(ctx context.Context, arg getWebhookEndpointParams) (webhookEndpoint, error) {
+	row := q.db.QueryRowContext(ctx, getWebhookEndpoint, arg.WorkspaceID, arg.ID)
+	var i webhookEndpoint
+	err := row.Scan(
+		&i.ID,
+		&i.ChangelogID,
+		&i.WorkspaceID,
+		&i.Url,
+		&i.Secret,
+		&i.Events,
+		&i.Active,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getWorkspace = `-- name: getWorkspace :one
+SELECT w.id, w.name, w.created_at, w.webhook_secret, t."key", t.workspace_id, t.created_at, t.expires_at, t.scopes, t.label, t.last_used_at, t.id
+FROM workspaces w
+LEFT JOIN tokens t ON w.id = t.workspace_id
+WHERE id = ?
+`
+
+type getWorkspaceRow struct {
+	workspace workspace
+	token     token
+}
+
+func (q *Queries) getWorkspace(ctx context.Context, id string) (getWorkspaceRow, error) {
+	row := q.db.QueryRowContext(ctx, getWorkspace, id)
+	var i getWorkspaceRow
+	err := row.Scan(
+		&i.workspace.ID,
+		&i.workspace.Name,
+		&i.workspace.CreatedAt,
+		&i.workspace.WebhookSecret,
+		&i.token.Key,
+		&i.token.WorkspaceID,
+		&i.token.CreatedAt,
+		&i.token.ExpiresAt,
+		&i.token.Scopes,
+		&i.token.Label,
+		&i.token.LastUsedAt,
+		&i.token.ID,
+	)
+	return i, err
+}
+
+const getWorkspaceAnalytics = `-- name: getWorkspaceAnalytics :one
+WITH views AS (
+    SELECT visitor_hash FROM changelog_access_log
+    WHERE workspace_id = ?1 AND accessed_at BETWEEN ?2 AND ?3
+)
+SELECT COUNT(*) AS total_views, COUNT(DISTINCT visitor_hash) AS unique_visitors
+FROM views
+`
+
+type getWorkspaceAnalyticsParams struct {
+	WorkspaceID string
+	FromTime    int64
+	ToTime      int64
+}
+
+type getWorkspaceAnalyticsRow struct {
+	TotalViews     int64
+	UniqueVisitors int64
+}
+
+func (q *Queries) getWorkspaceAnalytics(ctx context.Context, arg getWorkspaceAnalyticsParams) (getWorkspaceAnalyticsRow, error) {
+	row := q.db.QueryRowContext(ctx, getWorkspaceAnalytics, arg.WorkspaceID, arg.FromTime, arg.ToTime)
+	var i getWorkspaceAnalyticsRow
+	err := row.Scan(&i.TotalViews, &i.UniqueVisitors)
+	return i, err
+}
+
+const getWorkspaceBilling = `-- name: getWorkspaceBilling :one
+SELECT workspace_id, customer_id, subscription_id, subscription_status, current_period_end FROM workspace_billing
+WHERE workspace_id = ?
+`
+
+func (q *Queries) getWorkspaceBilling(ctx context.Context, workspaceID string) (workspaceBilling, error) {
+	row := q.db.QueryRowContext(ctx, getWorkspaceBilling, workspaceID)
+	var i workspaceBilling
+	err := row.Scan(
+		&i.WorkspaceID,
+		&i.CustomerID,
+		&i.SubscriptionID,
+		&i.SubscriptionStatus,
+		&i.CurrentPeriodEnd,
+	)
+	return i, err
+}
+
+const getWorkspaceByChangelog = `-- name: getWorkspaceByChangelog :one
+SELECT w.id, w.name, w.created_at, w.webhook_secret
+FROM changelogs c
+JOIN workspaces w ON c.workspace_id = w.id
+WHERE c.workspace_id = ? AND c.id = ?
+`
+
+type getWorkspaceByChangelogParams struct {
+	WorkspaceID string
+	ID          string
+}
+
+type getWorkspaceByChangelogRow struct {
+	workspace workspace
+}
+
+func (q *Queries) getWorkspaceByChangelog(ctx context.Context, arg getWorkspaceByChangelogParams) (getWorkspaceByChangelogRow, error) {
+	row := q.db.QueryRowContext(ctx, getWorkspaceByChangelog, arg.WorkspaceID, arg.ID)
+	var i getWorkspaceByChangelogRow
+	err := row.Scan(
+		&i.workspace.ID,
+		&i.workspace.Name,
+		&i.workspace.CreatedAt,
+		&i.workspace.WebhookSecret,
+	)
+	return i, err
+}
+
+const getWorkspaceByName = `-- name: getWorkspaceByName :one
+SELECT id, name, created_at, webhook_secret FROM workspaces
+WHERE name = ?
+`
+
+func (q *Queries) getWorkspaceByName(ctx context.Context, name string) (workspace, error) {
+	row := q.db.QueryRowContext(ctx, getWorkspaceByName, name)
+	var i workspace
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.CreatedAt,
+		&i.WebhookSecret,
+	)
+	return i, err
+}
+
+const getWorkspaceDashboardSummary = `-- name: getWorkspaceDashboardSummary :one
+WITH cls AS (
+    SELECT COUNT(*) AS n FROM changelogs
+    WHERE workspace_id = ?1
+), views AS (
+    SELECT COUNT(*) AS n FROM changelog_access_log
+    WHERE workspace_id = ?1 AND accessed_at >= ?2
+), failed AS (
+    -- failed deliveries which weren't followed by another attempt of the same event, see listFailedWebhookDeliveries
+    SELECT COUNT(*) AS n FROM webhook_deliveries d
+    JOIN webhook_endpoints e ON e.id = d.endpoint_id
+    WHERE e.workspace_id = ?1 AND d.success = 0 AND e.active = 1
+    AND NOT EXISTS (
+        SELECT 1 FROM webhook_deliveries l
+        WHERE l.endpoint_id = d.endpoint_id
+        AND l.event_type = d.event_type
+        AND l.payload_hash = d.payload_hash
+        AND (l.delivered_at > d.delivered_at OR (l.delivered_at = d.delivered_at AND l.id > d.id))
+    )
+), degraded AS (
+    SELECT COUNT(*) AS n FROM gh_sources gh
+    JOIN gh_source_health h ON gh.workspace_id = h.workspace_id AND gh.id = h.gh_source_id
+    WHERE gh.workspace_id = ?1 AND h.consecutive_failures >= ?3
+)
+SELECT
+    cls.n AS changelog_count,
+    views.n AS total_views,
+    failed.n AS unprocessed_webhooks,
+    degraded.n AS degraded_sources
+FROM cls, views, failed, degraded
+`
+
+type getWorkspaceDashboardSummaryParams struct {
+	WorkspaceID       string
+	ViewsSince        int64
+	DegradedThreshold int64
+}
+
+type getWorkspaceDashboardSummaryRow struct {
+	ChangelogCount      int64
+	TotalViews          int64
+	UnprocessedWebhooks int64
+	DegradedSources     int64
+}
+
+func (q *Queries) getWorkspaceDashboardSummary(ctx context.Context, arg getWorkspaceDashboardSummaryParams) (getWorkspaceDashboardSummaryRow, error) {
+	row := q.db.QueryRowContext(ctx, getWorkspaceDashboardSummary, arg.WorkspaceID, arg.ViewsSince, arg.DegradedThreshold)
+	var i getWorkspaceDashboardSummaryRow
+	err := row.Scan(
+		&i.ChangelogCount,
+		&i.TotalViews,
+		&i.UnprocessedWebhooks,
+		&i.DegradedSources,
+	)
+	return i, err
+}
+
+const getWorkspaceEmailSettings = `-- name: getWorkspaceEmailSettings :one
+SELECT workspace_id, from_email, from_name, smtp_host, smtp_port, smtp_user, smtp_pass_enc, reply_to FROM workspace_email_settings
+WHERE workspace_id = ?
+`
+
+func (q *Queries) getWorkspaceEmailSettings(ctx context.Context, workspaceID string) (workspaceEmailSetting, error) {
+	row := q.db.QueryRowContext(ctx, getWorkspaceEmailSettings, workspaceID)
+	var i workspaceEmailSetting
+	err := row.Scan(
+		&i.WorkspaceID,
+		&i.FromEmail,
+		&i.FromName,
+		&i.SmtpHost,
+		&i.SmtpPort,
+		&i.SmtpUser,
+		&i.SmtpPassEnc,
+		&i.ReplyTo,
+	)
+	return i, err
+}
+
+const getWorkspaceGrowthStats = `-- name: getWorkspaceGrowthStats :many
+WITH created AS (
+    SELECT strftime(?1, created_at, 'unixepoch') AS period, 1 AS is_workspace, 0 AS is_changelog
+    FROM workspaces
+    WHERE created_at BETWEEN ?2 AND ?3
+    UNION ALL
+    SELECT strftime(?1, created_at, 'unixepoch') AS period, 0 AS is_workspace, 1 AS is_changelog
+    FROM changelogs
+    WHERE created_at BETWEEN ?2 AND ?3
+)
+SELECT
+    CAST(period AS TEXT) AS period,
+    CAST(SUM(is_workspace) AS INTEGER) AS new_workspaces,
+    CAST(SUM(is_changelog) AS INTEGER) AS new_changelogs
+FROM created
+GROUP BY period
+ORDER BY period
+`
+
+type getWorkspaceGrowthStatsParams struct {
+	PeriodFormat interface{}
+	FromTime     int64
+	ToTime       int64
+}
+
+type getWorkspaceGrowthStatsRow struct {
+	Period        string
+	NewWorkspaces int64
+	NewChangelogs int64
+}
+
+func (q *Queries) getWorkspaceGrowthStats(ctx context.Context, arg getWorkspaceGrowthStatsParams) ([]getWorkspaceGrowthStatsRow, error) {
+	rows, err := q.db.QueryContext(ctx, getWorkspaceGrowthStats, arg.PeriodFormat, arg.FromTime, arg.ToTime)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []getWorkspaceGrowthStatsRow
+	for rows.Next() {
+		var i getWorkspaceGrowthStatsRow
+		if err := rows.Scan(&i.Period, &i.NewWorkspaces, &i.NewChangelogs); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getWorkspaceInvite = `-- name: getWorkspaceInvite :one
+SELECT token_hash, workspace_id, email, role, created_at, expires_at, accepted_at FROM workspace_invites
+WHERE token_hash = ?
+`
+
+func (q *Queries) getWorkspaceInvite(ctx context.Context, tokenHash string) (workspaceInvite, error) {
+	row := q.db.QueryRowContext(ctx, getWorkspaceInvite, tokenHash)
+	var i workspaceInvite
+	err := row.Scan(
+		&i.TokenHash,
+		&i.WorkspaceID,
+		&i.Email,
+		&i.Role,
+		&i.CreatedAt,
+		&i.ExpiresAt,
+		&i.AcceptedAt,
+	)
+	return i, err
+}
+
+const getWorkspaceMember = `-- name: getWorkspaceMember :one
+SELECT workspace_id, user_id, role, joined_at FROM workspace_members
+WHERE workspace_id = ? AND user_id = ?
+`
+
+type getWorkspaceMemberParams struct {
+	WorkspaceID string
+	UserID      string
+}
+
+func (q *Queries) getWorkspaceMember(ctx context.Context, arg getWorkspaceMemberParams) (workspaceMember, error) {
+	row := q.db.QueryRowContext(ctx, getWorkspaceMember, arg.WorkspaceID, arg.UserID)
+	var i workspaceMember
+	err := row.Scan(
+		&i.WorkspaceID,
+		&i.UserID,
+		&i.Role,
+		&i.JoinedAt,
+	)
+	return i, err
+}
+
+const getWorkspaceQuotaUsage = `-- name: getWorkspaceQuotaUsage :one
+SELECT
+    (SELECT COUNT(*) FROM changelogs c WHERE c.workspace_id = ?1) AS changelogs,
+    (SELECT COUNT(*) FROM gh_sources gh WHERE gh.workspace_id = ?1) AS gh_sources,
+    (SELECT CAST(COALESCE(SUM(LENGTH(a.data)), 0) AS INTEGER) FROM changelog_assets a WHERE a.workspace_id = ?1) AS storage_bytes
+`
+
+type getWorkspaceQuotaUsageRow struct {
+	Changelogs   int64
+	GhSources    int64
+	StorageBytes int64
+}
+
+func (q *Queries) getWorkspaceQuotaUsage(ctx context.Context, workspaceID string) (getWorkspaceQuotaUsageRow, error) {
+	row := q.db.QueryRowContext(ctx, getWorkspaceQuotaUsage, workspaceID)
+	var i getWorkspaceQuotaUsageRow
+	err := row.Scan(&i.Changelogs, &i.GhSources, &i.StorageBytes)
+	return i, err
+}
+
+const getWorkspaceSSO = `-- name: getWorkspaceSSO :one
+SELECT workspace_id, provider, issuer_url, client_id, client_secret_enc, certificate, enabled, created_at FROM workspace_sso
+WHERE workspace_id = ?
+`
+
+func (q *Queries) getWorkspaceSSO(ctx context.Context, workspaceID string) (workspaceSso, error) {
+	row := q.db.QueryRowContext(ctx, getWorkspaceSSO, workspaceID)
+	var i workspaceSso
+	err := row.Scan(
+		&i.WorkspaceID,
+		&i.Provider,
+		&i.IssuerUrl,
+		&i.ClientID,
+		&i.ClientSecretEnc,
+		&i.Certificate,
+		&i.Enabled,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getWorkspaceWebhookSecret = `-- name: getWorkspaceWebhookSecret :one
+SELECT webhook_secret FROM workspaces
+WHERE id = ?
+`
+
+func (q *Queries) getWorkspaceWebhookSecret(ctx context.Context, id string) (apitypes.NullString, error) {
+	row := q.db.QueryRowContext(ctx, getWorkspaceWebhookSecret, id)
+	var webhook_secret apitypes.NullString
+	err := row.Scan(&webhook_secret)
+	return webhook_secret, err
+}
+
+const labelExists = `-- name: labelExists :one
+SELECT EXISTS(SELECT 1 FROM labels WHERE workspace_id = ? AND name = ?)
+`
+
+type labelExistsParams struct {
+	WorkspaceID string
+	Name        string
+}
+
+func (q *Queries) labelExists(ctx context.Context, arg labelExistsParams) (int64, error) {
+	row := q.db.QueryRowContext(ctx, labelExists, arg.WorkspaceID, arg.Name)
+	var column_1 int64
+	err := row.Scan(&column_1)
+	return column_1, err
+}
+
+const listAccessLog = `-- name: listAccessLog :many
+SELECT id, workspace_id, changelog_id, visitor_hash, user_agent, referer, accessed_at FROM changelog_access_log
+WHERE workspace_id = ?1 AND changelog_id = ?2 AND accessed_at BETWEEN ?3 AND ?4
+ORDER BY accessed_at DESC
+LIMIT ?5 OFFSET ?6
+`
+
+type listAccessLogParams struct {
+	WorkspaceID string
+	ChangelogID string
+	FromTime    int64
+	ToTime      int64
+	Limit       int64
+	Offset      int64
+}
+
+func (q *Queries) listAccessLog(ctx context.Context, arg listAccessLogParams) ([]changelogAccessLog, error) {
+	rows, err := q.db.QueryContext(ctx, listAccessLog,
+		arg.WorkspaceID,
+		arg.ChangelogID,
+		arg.FromTime,
+		arg.ToTime,
+		arg.Limit,
+		arg.Offset,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []changelogAccessLog
+	for rows.Next() {
+		var i changelogAccessLog
+		if err := rows.Scan(
+			&i.ID,
+			&i.WorkspaceID,
+			&i.ChangelogID,
+			&i.VisitorHash,
+			&i.UserAgent,
+			&i.Referer,
+			&i.AccessedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listActiveAnnouncements = `-- name: listActiveAnnouncements :many
+SELECT id, workspace_id, changelog_id, message, cta_url, starts_at, ends_at, created_at FROM changelog_announcements
+WHERE changelog_id = ?1 AND starts_at <= ?2 AND ends_at >= ?2
+ORDER BY starts_at DESC
+`
+
+type listActiveAnnouncementsParams struct {
+	ChangelogID string
+	At          int64
+}
+
+func (q *Queries) listActiveAnnouncements(ctx context.Context, arg listActiveAnnouncementsParams) ([]changelogAnnouncement, error) {
+	rows, err := q.db.QueryContext(ctx, listActiveAnnouncements, arg.ChangelogID, arg.At)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []changelogAnnouncement
+	for rows.Next() {
+		var i changelogAnnouncement
+		if err := rows.Scan(
+			&i.ID,
+			&i.WorkspaceID,
+			&i.ChangelogID,
+			&i.Message,
+			&i.CtaUrl,
+			&i.StartsAt,
+			&i.EndsAt,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listAllAnnouncements = `-- name: listAllAnnouncements :many
+SELECT id, workspace_id, changelog_id, message, cta_url, starts_at, ends_at, created_at FROM changelog_announcements
+WHERE workspace_id = ? AND changelog_id = ?
+ORDER BY starts_at DESC
+`
+
+type listAllAnnouncementsParams struct {
+	WorkspaceID string
+	ChangelogID string
+}
+
+func (q *Queries) listAllAnnouncements(ctx context.Context, arg listAllAnnouncementsParams) ([]changelogAnnouncement, error) {
+	rows, err := q.db.QueryContext(ctx, listAllAnnouncements, arg.WorkspaceID, arg.ChangelogID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []changelogAnnouncement
+	for rows.Next() {
+		var i changelogAnnouncement
+		if err := rows.Scan(
+			&i.ID,
+			&i.WorkspaceID,
+			&i.ChangelogID,
+			&i.Message,
+			&i.CtaUrl,
+			&i.StartsAt,
+			&i.EndsAt,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listAllSubdomains = `-- name: listAllSubdomains :many
+SELECT subdomain FROM changelogs
+WHERE subdomain != ''
+ORDER BY subdomain
+`
+
+func (q *Queries) listAllSubdomains(ctx context.Context) ([]string, error) {
+	rows, err := q.db.QueryContext(ctx, listAllSubdomains)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []string
+	for rows.Next() {
+		var subdomain string
+		if err := rows.Scan(&subdomain); err != nil {
+			return nil, err
+		}
+		items = append(items, subdomain)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listCachedFiles = `-- name: listCachedFiles :many
+SELECT path, sha FROM gh_source_file_cache
+WHERE workspace_id = ? AND gh_source_id = ?
+`
+
+type listCachedFilesParams struct {
+	WorkspaceID string
+	GhSourceID  string
+}
+
+type listCachedFilesRow struct {
+	Path string
+	Sha  string
+}
+
+func (q *Queries) listCachedFiles(ctx context.Context, arg listCachedFilesParams) ([]listCachedFilesRow, error) {
+	rows, err := q.db.QueryContext(ctx, listCachedFiles, arg.WorkspaceID, arg.GhSourceID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []listCachedFilesRow
+	for rows.Next() {
+		var i listCachedFilesRow
+		if err := rows.Scan(&i.Path, &i.Sha); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listChangelogI18nStrings = `-- name: listChangelogI18nStrings :many
+SELECT workspace_id, changelog_id, "key", value FROM changelog_i18n
+WHERE workspace_id = ? AND changelog_id = ?
+`
+
+type listChangelogI18nStringsParams struct {
+	WorkspaceID string
+	ChangelogID string
+}
+
+func (q *Queries) listChangelogI18nStrings(ctx context.Context, arg listChangelogI18nStringsParams) ([]changelogI18n, error) {
+	rows, err := q.db.QueryContext(ctx, listChangelogI18nStrings, arg.WorkspaceID, arg.ChangelogID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []changelogI18n
+	for rows.Next() {
+		var i changelogI18n
+		if err := rows.Scan(
+			&i.WorkspaceID,
+			&i.ChangelogID,
+			&i.Key,
+			&i.Value,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listChangelogSnapshots = `-- name: listChangelogSnapshots :many
+SELECT id, workspace_id, changelog_id, snapshot, created_at FROM changelog_snapshots
+WHERE workspace_id = ? AND changelog_id = ?
+ORDER BY created_at DESC, id DESC
+LIMIT ?
+`
+
+type listChangelogSnapshotsParams struct {
+	WorkspaceID string
+	ChangelogID string
+	Limit       int64
+}
+
+func (q *Queries) listChangelogSnapshots(ctx context.Context, arg listChangelogSnapshotsParams) ([]changelogSnapshot, error) {
+	rows, err := q.db.QueryContext(ctx, listChangelogSnapshots, arg.WorkspaceID, arg.ChangelogID, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []changelogSnapshot
+	for rows.Next() {
+		var i changelogSnapshot
+		if err := rows.Scan(
+			&i.ID,
+			&i.WorkspaceID,
+			&i.ChangelogID,
+			&i.Snapshot,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listChangelogs = `-- name: listChangelogs :many
+SELECT c.id, c.workspace_id, c.subdomain, c.title, c.subtitle, c.source_id, c.logo_src, c.logo_link, c.logo_alt, c.logo_height, c.logo_width, c.created_at, c.domain, c.color_scheme, c.hide_powered_by, c.protected, c.password_hash, c.analytics, c.searchable, c.favicon_src, c.sort_order, c.social_links, c.robots_txt, c.slug, c.canonical_url, cs.id, cs.workspace_id, cs.owner, cs.repo, cs.path, cs.installation_id, cs.last_fetched_at, cs.fetch_interval_seconds, cs.private_key_id, cs.private_key_blob, cs.webhook_secret
+FROM changelogs c
+LEFT JOIN changelog_source cs ON c.workspace_id = cs.workspace_id AND c.source_id = cs.id
+WHERE c.workspace_id = ?
+ORDER BY c.sort_order ASC, c.created_at DESC
+`
+
+type listChangelogsRow struct {
+	changelog       changelog
+	ChangelogSource changelogSource
+}
+
+func (q *Queries) listChangelogs(ctx context.Context, workspaceID string) ([]listChangelogsRow, error) {
+	rows, err := q.db.QueryContext(ctx, listChangelogs, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []listChangelogsRow
+	for rows.Next() {
+		var i listChangelogsRow
+		if err := rows.Scan(
+			&i.changelog.ID,
+			&i.changelog.WorkspaceID,
+			&i.changelog.Subdomain,
+			&i.changelog.Title,
+			&i.changelog.Subtitle,
+			&i.changelog.SourceID,
+			&i.changelog.LogoSrc,
+			&i.changelog.LogoLink,
+			&i.changelog.LogoAlt,
+			&i.changelog.LogoHeight,
+			&i.changelog.LogoWidth,
+			&i.changelog.CreatedAt,
+			&i.changelog.Domain,
+			&i.changelog.ColorScheme,
+			&i.changelog.HidePoweredBy,
+			&i.changelog.Protected,
+			&i.changelog.PasswordHash,
+			&i.changelog.Analytics,
+			&i.changelog.Searchable,
+			&i.changelog.FaviconSrc,
+			&i.changelog.SortOrder,
+			&i.changelog.SocialLinks,
+			&i.changelog.RobotsTxt,
+			&i.changelog.Slug,
+			&i.changelog.CanonicalUrl,
+			&i.ChangelogSource.ID,
+			&i.ChangelogSource.WorkspaceID,
+			&i.ChangelogSource.Owner,
+			&i.ChangelogSource.Repo,
+			&i.ChangelogSource.Path,
+			&i.ChangelogSource.InstallationID,
+			&i.ChangelogSource.LastFetchedAt,
+			&i.ChangelogSource.FetchIntervalSeconds,
+			&i.ChangelogSource.PrivateKeyID,
+			&i.ChangelogSource.PrivateKeyBlob,
+			&i.ChangelogSource.WebhookSecret,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listChangelogsByIDs = `-- name: listChangelogsByIDs :many
+SELECT c.id, c.workspace_id, c.subdomain, c.title, c.subtitle, c.source_id, c.logo_src, c.logo_link, c.logo_alt, c.logo_height, c.logo_width, c.created_at, c.domain, c.color_scheme, c.hide_powered_by, c.protected, c.password_hash, c.analytics, c.searchable, c.favicon_src, c.sort_order, c.social_links, c.robots_txt, c.slug, c.canonical_url, cs.id, cs.workspace_id, cs.owner, cs.repo, cs.path, cs.installation_id, cs.last_fetched_at, cs.fetch_interval_seconds, cs.private_key_id, cs.private_key_blob, cs.webhook_secret
+FROM changelogs c
+LEFT JOIN changelog_source cs ON c.workspace_id = cs.workspace_id AND c.source_id = cs.id
+WHERE c.workspace_id = ? AND c.id IN (/*SLICE:ids*/?)
+`
+
+type listChangelogsByIDsRow struct {
+	changelog       changelog
+	ChangelogSource changelogSource
+}
+
+type listChangelogsByIDsParams struct {
+	WorkspaceID string
+	Ids         []string
+}
+
+func (q *Queries) listChangelogsByIDs(ctx context.Context, arg listChangelogsByIDsParams) ([]listChangelogsByIDsRow, error) {
+	query := listChangelogsByIDs
+	var queryParams []interface{}
+	queryParams = append(queryParams, arg.WorkspaceID)
+	if len(arg.Ids) > 0 {
+		for _, v := range arg.Ids {
+			queryParams = append(queryParams, v)
+		}
+		query = strings.Replace(query, "/*SLICE:ids*/?", strings.Repeat(",?", len(arg.Ids))[1:], 1)
+	} else {
+		query = strings.Replace(query, "/*SLICE:ids*/?", "NULL", 1)
+	}
+	rows, err := q.db.QueryContext(ctx, query, queryParams...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []listChangelogsByIDsRow
+	for rows.Next() {
+		var i listChangelogsByIDsRow
+		if err := rows.Scan(
+			&i.changelog.ID,
+			&i.changelog.WorkspaceID,
+			&i.changelog.Subdomain,
+			&i.changelog.Title,
+			&i.changelog.Subtitle,
+			&i.changelog.SourceID,
+			&i.changelog.LogoSrc,
+			&i.changelog.LogoLink,
+			&i.changelog.LogoAlt,
+			&i.changelog.LogoHeight,
+			&i.changelog.LogoWidth,
+			&i.changelog.CreatedAt,
+			&i.changelog.Domain,
+			&i.changelog.ColorScheme,
+			&i.changelog.HidePoweredBy,
+			&i.changelog.Protected,
+			&i.changelog.PasswordHash,
+			&i.changelog.Analytics,
+			&i.changelog.Searchable,
+			&i.changelog.FaviconSrc,
+			&i.changelog.SortOrder,
+			&i.changelog.SocialLinks,
+			&i.changelog.RobotsTxt,
+			&i.changelog.Slug,
+			&i.changelog.CanonicalUrl,
+			&i.ChangelogSource.ID,
+			&i.ChangelogSource.WorkspaceID,
+			&i.ChangelogSource.Owner,
+			&i.ChangelogSource.Repo,
+			&i.ChangelogSource.Path,
+			&i.ChangelogSource.InstallationID,
+			&i.ChangelogSource.LastFetchedAt,
+			&i.ChangelogSource.FetchIntervalSeconds,
+			&i.ChangelogSource.PrivateKeyID,
+			&i.ChangelogSource.PrivateKeyBlob,
+			&i.ChangelogSource.WebhookSecret,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listChangelogsByInstallationID = `-- name: listChangelogsByInstallationID :many
+SELECT c.id, c.workspace_id, c.subdomain, c.title, c.subtitle, c.source_id, c.logo_src, c.logo_link, c.logo_alt, c.logo_height, c.logo_width, c.created_at, c.domain, c.color_scheme, c.hide_powered_by, c.protected, c.password_hash, c.analytics, c.searchable, c.favicon_src, c.sort_order, c.social_links, c.robots_txt, c.slug, c.canonical_url, gh.id, gh.workspace_id, gh.owner, gh.repo, gh.path, gh.installation_id, gh.last_fetched_at, gh.fetch_interval_seconds, gh.private_key_id, gh.private_key_blob, gh.webhook_secret, COALESCE(h.consecutive_failures, 0) AS consecutive_failures
+FROM changelogs c
+JOIN gh_sources gh ON c.workspace_id = gh.workspace_id AND c.source_id = gh.id
+LEFT JOIN gh_source_health h ON gh.workspace_id = h.workspace_id AND gh.id = h.gh_source_id
+WHERE gh.installation_id = ?
+ORDER BY c.workspace_id, c.created_at DESC
+`
+
+type listChangelogsByInstallationIDRow struct {
+	changelog           changelog
+	ghSource            ghSource
+	ConsecutiveFailures int64
+}
+
+func (q *Queries) listChangelogsByInstallationID(ctx context.Context, installationID int64) ([]listChangelogsByInstallationIDRow, error) {
+	rows, err := q.db.QueryContext(ctx, listChangelogsByInstallationID, installationID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []listChangelogsByInstallationIDRow
+	for rows.Next() {
+		var i listChangelogsByInstallationIDRow
+		if err := rows.Scan(
+			&i.changelog.ID,
+			&i.changelog.WorkspaceID,
+			&i.changelog.Subdomain,
+			&i.changelog.Title,
+			&i.changelog.Subtitle,
+			&i.changelog.SourceID,
+			&i.changelog.LogoSrc,
+			&i.changelog.LogoLink,
+			&i.changelog.LogoAlt,
+			&i.changelog.LogoHeight,
+			&i.changelog.LogoWidth,
+			&i.changelog.CreatedAt,
+			&i.changelog.Domain,
+			&i.changelog.ColorScheme,
+			&i.changelog.HidePoweredBy,
+			&i.changelog.Protected,
+			&i.changelog.PasswordHash,
+			&i.changelog.Analytics,
+			&i.changelog.Searchable,
+			&i.changelog.FaviconSrc,
+			&i.changelog.SortOrder,
+			&i.changelog.SocialLinks,
+			&i.changelog.RobotsTxt,
+			&i.changelog.Slug,
+			&i.changelog.CanonicalUrl,
+			&i.ghSource.ID,
+			&i.ghSource.WorkspaceID,
+			&i.ghSource.Owner,
+			&i.ghSource.Repo,
+			&i.ghSource.Path,
+			&i.ghSource.InstallationID,
+			&i.ghSource.LastFetchedAt,
+			&i.ghSource.FetchIntervalSeconds,
+			&i.ghSource.PrivateKeyID,
+			&i.ghSource.PrivateKeyBlob,
+			&i.ghSource.WebhookSecret,
+			&i.ConsecutiveFailures,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listChangelogsWithAnalytics = `-- name: listChangelogsWithAnalytics :many
+SELECT c.id, c.workspace_id, c.subdomain, c.title, c.subtitle, c.source_id, c.logo_src, c.logo_link, c.logo_alt, c.logo_height, c.logo_width, c.created_at, c.domain, c.color_scheme, c.hide_powered_by, c.protected, c.password_hash, c.analytics, c.searchable, c.favicon_src, c.sort_order, c.social_links, c.robots_txt, c.slug, c.canonical_url, cs.id, cs.workspace_id, cs.owner, cs.repo, cs.path, cs.installation_id, cs.last_fetched_at, cs.fetch_interval_seconds, cs.private_key_id, cs.private_key_blob, cs.webhook_secret
+FROM changelogs c
+LEFT JOIN changelog_source cs ON c.workspace_id = cs.workspace_id AND c.source_id = cs.id
+WHERE c.analytics = 1
+ORDER BY c.created_at ASC, c.id ASC
+LIMIT ? OFFSET ?
+`
+
+type listChangelogsWithAnalyticsParams struct {
+	Limit  int64
+	Offset int64
+}
+
+type listChangelogsWithAnalyticsRow struct {
+	changelog       changelog
+	ChangelogSource changelogSource
+}
+
+func (q *Queries) listChangelogsWithAnalytics(ctx context.Context, arg listChangelogsWithAnalyticsParams) ([]listChangelogsWithAnalyticsRow, error) {
+	rows, err := q.db.QueryContext(ctx, listChangelogsWithAnalytics, arg.Limit, arg.Offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []listChangelogsWithAnalyticsRow
+	for rows.Next() {
+		var i listChangelogsWithAnalyticsRow
+		if err := rows.Scan(
+			&i.changelog.ID,
+			&i.changelog.WorkspaceID,
+			&i.changelog.Subdomain,
+			&i.changelog.Title,
+			&i.changelog.Subtitle,
+			&i.changelog.SourceID,
+			&i.changelog.LogoSrc,
+			&i.changelog.LogoLink,
+			&i.changelog.LogoAlt,
+			&i.changelog.LogoHeight,
+			&i.changelog.LogoWidth,
+			&i.changelog.CreatedAt,
+			&i.changelog.Domain,
+			&i.changelog.ColorScheme,
+			&i.changelog.HidePoweredBy,
+			&i.changelog.Protected,
+			&i.changelog.PasswordHash,
+			&i.changelog.Analytics,
+			&i.changelog.Searchable,
+			&i.changelog.FaviconSrc,
+			&i.changelog.SortOrder,
+			&i.changelog.SocialLinks,
+			&i.changelog.RobotsTxt,
+			&i.changelog.Slug,
+			&i.changelog.CanonicalUrl,
+			&i.ChangelogSource.ID,
+			&i.ChangelogSource.WorkspaceID,
+			&i.ChangelogSource.Owner,
+			&i.ChangelogSource.Repo,
+			&i.ChangelogSource.Path,
+			&i.ChangelogSource.InstallationID,
+			&i.ChangelogSource.LastFetchedAt,
+			&i.ChangelogSource.FetchIntervalSeconds,
+			&i.ChangelogSource.PrivateKeyID,
+			&i.ChangelogSource.PrivateKeyBlob,
+			&i.ChangelogSource.WebhookSecret,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listChangelogsWithoutSource = `-- name: listChangelogsWithoutSource :many
+
+SELECT c.id, c.workspace_id, c.subdomain, c.title, c.subtitle, c.source_id, c.logo_src, c.logo_link, c.logo_alt, c.logo_height, c.logo_width, c.created_at, c.domain, c.color_scheme, c.hide_powered_by, c.protected, c.password_hash, c.analytics, c.searchable, c.favicon_src, c.sort_order, c.social_links, c.robots_txt, c.slug, c.canonical_url FROM changelogs c
+LEFT JOIN gh_sources gh ON c.workspace_id = gh.workspace_id AND c.source_id = gh.id
+WHERE c.workspace_id = ? AND gh.id IS NULL
+`
+
+// changelogs pointing to a deleted source are treated as changelogs without source
+func (q *Queries) listChangelogsWithoutSource(ctx context.Context, workspaceID string) ([]changelog, error) {
+	rows, err := q.db.QueryContext(ctx, listChangelogsWithoutSource, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []changelog
+	for rows.Next() {
+		var i changelog
+		if err := rows.Scan(
+			&i.ID,
+			&i.WorkspaceID,
+			&i.Subdomain,
+			&i.Title,
+			&i.Subtitle,
+			&i.SourceID,
+			&i.LogoSrc,
+			&i.LogoLink,
+			&i.LogoAlt,
+			&i.LogoHeight,
+			&i.LogoWidth,
+			&i.CreatedAt,
+			&i.Domain,
+			&i.ColorScheme,
+			&i.HidePoweredBy,
+			&i.Protected,
+			&i.PasswordHash,
+			&i.Analytics,
+			&i.Searchable,
+			&i.FaviconSrc,
+			&i.SortOrder,
+			&i.SocialLinks,
+			&i.RobotsTxt,
+			&i.Slug,
+			&i.CanonicalUrl,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listCustomDomains = `-- name: listCustomDomains :many
+SELECT id, workspace_id, changelog_id, domain, verified, created_at FROM changelog_custom_domains
+WHERE workspace_id = ? AND changelog_id = ?
+ORDER BY created_at ASC
+`
+
+type listCustomDomainsParams struct {
+	WorkspaceID string
+	ChangelogID string
+}
+
+func (q *Queries) listCustomDomains(ctx context.Context, arg listCustomDomainsParams) ([]changelogCustomDomain, error) {
+	rows, err := q.db.QueryContext(ctx, listCustomDomains, arg.WorkspaceID, arg.ChangelogID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []changelogCustomDomain
+	for rows.Next() {
+		var i changelogCustomDomain
+		if err := rows.Scan(
+			&i.ID,
+			&i.WorkspaceID,
+			&i.ChangelogID,
+			&i.Domain,
+			&i.Verified,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listEmailDeliveries = `-- name: listEmailDeliveries :many
+SELECT id, workspace_id, changelog_id, to_email, subject, template, sent_at, status, error FROM email_deliveries
+WHERE workspace_id = ? AND changelog_id = ?
+ORDER BY sent_at DESC, id DESC
+LIMIT ? OFFSET ?
+`
+
+type listEmailDeliveriesParams struct {
+	WorkspaceID string
+	ChangelogID string
+	Limit       int64
+	Offset      int64
+}
+
+func (q *Queries) listEmailDeliveries(ctx context.Context, arg listEmailDeliveriesParams) ([]emailDelivery, error) {
+	rows, err := q.db.QueryContext(ctx, listEmailDeliveries,
+		arg.WorkspaceID,
+		arg.ChangelogID,
+		arg.Limit,
+		arg.Offset,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []emailDelivery
+	for rows.Next() {
+		var i emailDelivery
+		if err := rows.Scan(
+			&i.ID,
+			&i.WorkspaceID,
+			&i.ChangelogID,
+			&i.ToEmail,
+			&i.Subject,
+			&i.Template,
+			&i.SentAt,
+			&i.Status,
+			&i.Error,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listEntryAttachments = `-- name: listEntryAttachments :many
+SELECT id, workspace_id, changelog_id, entry_id, filename, mime_type, size_bytes, url, created_at FROM entry_attachments
+WHERE workspace_id = ? AND changelog_id = ? AND entry_id = ?
+ORDER BY created_at ASC, id ASC
+`
+
+type listEntryAttachmentsParams struct {
+	WorkspaceID string
+	ChangelogID string
+	EntryID     string
+}
+
+func (q *Queries) listEntryAttachments(ctx context.Context, arg listEntryAttachmentsParams) ([]entryAttachment, error) {
+	rows, err := q.db.QueryContext(ctx, listEntryAttachments, arg.WorkspaceID, arg.ChangelogID, arg.EntryID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []entryAttachment
+	for rows.Next() {
+		var i entryAttachment
+		if err := rows.Scan(
+			&i.ID,
+			&i.WorkspaceID,
+			&i.ChangelogID,
+			&i.EntryID,
+			&i.Filename,
+			&i.MimeType,
+			&i.SizeBytes,
+			&i.Url,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listEntryComments = `-- name: listEntryComments :many
+SELECT id, workspace_id, changelog_id, entry_id, author_id, body, created_at, updated_at, resolved FROM entry_comments
+WHERE workspace_id = ? AND changelog_id = ? AND entry_id = ?
+ORDER BY created_at ASC, id ASC
+`
+
+type listEntryCommentsParams struct {
+	WorkspaceID string
+	ChangelogID string
+	EntryID     string
+}
+
+func (q *Queries) listEntryComments(ctx context.Context, arg listEntryCommentsParams) ([]entryComment, error) {
+	rows, err := q.db.QueryContext(ctx, listEntryComments, arg.WorkspaceID, arg.ChangelogID, arg.EntryID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []entryComment
+	for rows.Next() {
+		var i entryComment
+		if err := rows.Scan(
+			&i.ID,
+			&i.WorkspaceID,
+			&i.ChangelogID,
+			&i.EntryID,
+			&i.AuthorID,
+			&i.Body,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.Resolved,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listEntryIDsByLabels = `-- name: listEntryIDsByLabels :many
+SELECT entry_id FROM entry_labels
+WHERE workspace_id = ? AND changelog_id = ? AND label_name IN (/*SLICE:labels*/?)
+GROUP BY entry_id
+HAVING COUNT(*) >= ?
+`
+
+type listEntryIDsByLabelsParams struct {
+	WorkspaceID string
+	ChangelogID string
+	Labels      []string
+	MinMatches  int64
+}
+
+func (q *Queries) listEntryIDsByLabels(ctx context.Context, arg listEntryIDsByLabelsParams) ([]string, error) {
+	query := listEntryIDsByLabels
+	var queryParams []interface{}
+	queryParams = append(queryParams, arg.WorkspaceID)
+	queryParams = append(queryParams, arg.ChangelogID)
+	if len(arg.Labels) > 0 {
+		for _, v := range arg.Labels {
+			queryParams = append(queryParams, v)
+		}
+		query = strings.Replace(query, "/*SLICE:labels*/?", strings.Repeat(",?", len(arg.Labels))[1:], 1)
+	} else {
+		query = strings.Replace(query, "/*SLICE:labels*/?", "NULL", 1)
+	}
+	queryParams = append(queryParams, arg.MinMatches)
+	rows, err := q.db.QueryContext(ctx, query, queryParams...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []string
+	for rows.Next() {
+		var entry_id string
+		if err := rows.Scan(&entry_id); err != nil {
+			return nil, err
+		}
+		items = append(items, entry_id)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listFailedWebhookDeliveries = `-- name: listFailedWebhookDeliveries :many
+SELECT d.id, d.endpoint_id, d.event_type, d.payload, d.payload_hash, d.status_code, d.response_body, d.delivered_at, d.duration_ms, d.success FROM webhook_deliveries d
+JOIN webhook_endpoints e ON e.id = d.endpoint_id
+WHERE d.endpoint_id = ? AND d.success = 0 AND e.active = 1
+AND NOT EXISTS (
+    SELECT 1 FROM webhook_deliveries l
+    WHERE l.endpoint_id = d.endpoint_id
+    AND l.event_type = d.event_type
+    AND l.payload_hash = d.payload_hash
+    AND (l.delivered_at > d.delivered_at OR (l.delivered_at = d.delivered_at AND l.id > d.id))
+)
+ORDER BY d.delivered_at ASC
+LIMIT ?
+`
+
+type listFailedWebhookDeliveriesParams struct {
+	EndpointID string
+	Limit      int64
+}
+
+func (q *Queries) listFailedWebhookDeliveries(ctx context.Context, arg listFailedWebhookDeliveriesParams) ([]webhookDelivery, error) {
+	rows, err := q.db.QueryContext(ctx, listFailedWebhookDeliveries, arg.EndpointID, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []webhookDelivery
+	for rows.Next() {
+		var i webhookDelivery
+		if err := rows.Scan(
+			&i.ID,
+			&i.EndpointID,
+			&i.EventType,
+			&i.Payload,
+			&i.PayloadHash,
+			&i.StatusCode,
+			&i.ResponseBody,
+			&i.DeliveredAt,
+			&i.DurationMs,
+			&i.Success,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listGHSources = `-- name: listGHSources :many
+SELECT id, workspace_id, owner, repo, path, installation_id, last_fetched_at, fetch_interval_seconds, private_key_id, private_key_blob, webhook_secret FROM gh_sources
+WHERE workspace_id = ?
+`
+
+func (q *Queries) listGHSources(ctx context.Context, workspaceID string) ([]ghSource, error) {
+	rows, err := q.db.QueryContext(ctx, listGHSources, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ghSource
+	for rows.Next() {
+		var i ghSource
+		if err := rows.Scan(
+			&i.ID,
+			&i.WorkspaceID,
+			&i.Owner,
+			&i.Repo,
+			&i.Path,
+			&i.InstallationID,
+			&i.LastFetchedAt,
+			&i.FetchIntervalSeconds,
+			&i.PrivateKeyID,
+			&i.PrivateKeyBlob,
+			&i.WebhookSecret,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listGHSourcesNeedingRefresh = `-- name: listGHSourcesNeedingRefresh :many
+SELECT id, workspace_id, owner, repo, path, installation_id, last_fetched_at, fetch_interval_seconds, private_key_id, private_key_blob, webhook_secret FROM gh_sources
+WHERE last_fetched_at + fetch_interval_seconds <= unixepoch('now')
+ORDER BY last_fetched_at ASC
+LIMIT ?
+`
+
+func (q *Queries) listGHSourcesNeedingRefresh(ctx context.Context, limit int64) ([]ghSource, error) {
+	rows, err := q.db.QueryContext(ctx, listGHSourcesNeedingRefresh, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ghSource
+	for rows.Next() {
+		var i ghSource
+		if err := rows.Scan(
+			&i.ID,
+			&i.WorkspaceID,
+			&i.Owner,
+			&i.Repo,
+			&i.Path,
+			&i.InstallationID,
+			&i.LastFetchedAt,
+			&i.FetchIntervalSeconds,
+			&i.PrivateKeyID,
+			&i.PrivateKeyBlob,
+			&i.WebhookSecret,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listLabelsByEntry = `-- name: listLabelsByEntry :many
+SELECT l.workspace_id, l.name, l.created_at FROM labels l
+JOIN entry_labels el ON l.workspace_id = el.workspace_id AND l.name = el.label_name
+WHERE el.workspace_id = ? AND el.changelog_id = ? AND el.entry_id = ?
+ORDER BY l.name
+`
+
+type listLabelsByEntryParams struct {
+	WorkspaceID string
+	ChangelogID string
+	EntryID     string
+}
+
+func (q *Queries) listLabelsByEntry(ctx context.Context, arg listLabelsByEntryParams) ([]label, error) {
+	rows, err := q.db.QueryContext(ctx, listLabelsByEntry, arg.WorkspaceID, arg.ChangelogID, arg.EntryID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []label
+	for rows.Next() {
+		var i label
+		if err := rows.Scan(&i.WorkspaceID, &i.Name, &i.CreatedAt); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listOrphanedGHSources = `-- name: listOrphanedGHSources :many
+SELECT gh.id, gh.workspace_id, gh.owner, gh.repo, gh.path, gh.installation_id, gh.last_fetched_at, gh.fetch_interval_seconds, gh.private_key_id, gh.private_key_blob, gh.webhook_secret FROM gh_sources gh
+WHERE NOT EXISTS (
+    SELECT 1 FROM changelogs c
+    WHERE c.workspace_id = gh.workspace_id AND c.source_id = gh.id
+)
+`
+
+func (q *Queries) listOrphanedGHSources(ctx context.Context) ([]ghSource, error) {
+	rows, err := q.db.QueryContext(ctx, listOrphanedGHSources)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ghSource
+	for rows.Next() {
+		var i ghSource
+		if err := rows.Scan(
+			&i.ID,
+			&i.WorkspaceID,
+			&i.Owner,
+			&i.Repo,
+			&i.Path,
+			&i.InstallationID,
+			&i.LastFetchedAt,
+			&i.FetchIntervalSeconds,
+			&i.PrivateKeyID,
+			&i.PrivateKeyBlob,
+			&i.WebhookSecret,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listPublicChangelogs = `-- name: listPublicChangelogs :many
+SELECT c.id, c.workspace_id, c.subdomain, c.title, c.subtitle, c.source_id, c.logo_src, c.logo_link, c.logo_alt, c.logo_height, c.logo_width, c.created_at, c.domain, c.color_scheme, c.hide_powered_by, c.protected, c.password_hash, c.analytics, c.searchable, c.favicon_src, c.sort_order, c.social_links, c.robots_txt, c.slug, c.canonical_url, cs.id, cs.workspace_id, cs.owner, cs.repo, cs.path, cs.installation_id, cs.last_fetched_at, cs.fetch_interval_seconds, cs.private_key_id, cs.private_key_blob, cs.webhook_secret
+FROM changelogs c
+LEFT JOIN changelog_source cs ON c.workspace_id = cs.workspace_id AND c.source_id = cs.id
+WHERE c.protected = 0 AND c.analytics = 1
+ORDER BY c.created_at DESC
+LIMIT ? OFFSET ?
+`
+
+type listPublicChangelogsParams struct {
+	Limit  int64
+	Offset int64
+}
+
+type listPublicChangelogsRow struct {
+	changelog       changelog
+	ChangelogSource changelogSource
+}
+
+func (q *Queries) listPublicChangelogs(ctx context.Context, arg listPublicChangelogsParams) ([]listPublicChangelogsRow, error) {
+	rows, err := q.db.QueryContext(ctx, listPublicChangelogs, arg.Limit, arg.Offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []listPublicChangelogsRow
+	for rows.Next() {
+		var i listPublicChangelogsRow
+		if err := rows.Scan(
+			&i.changelog.ID,
+			&i.changelog.WorkspaceID,
+			&i.changelog.Subdomain,
+			&i.changelog.Title,
+			&i.changelog.Subtitle,
+			&i.changelog.SourceID,
+			&i.changelog.LogoSrc,
+			&i.changelog.LogoLink,
+			&i.changelog.LogoAlt,
+			&i.changelog.LogoHeight,
+			&i.changelog.LogoWidth,
+			&i.changelog.CreatedAt,
+			&i.changelog.Domain,
+			&i.changelog.ColorScheme,
+			&i.changelog.HidePoweredBy,
+			&i.changelog.Protected,
+			&i.changelog.PasswordHash,
+			&i.changelog.Analytics,
+			&i.changelog.Searchable,
+			&i.changelog.FaviconSrc,
+			&i.changelog.SortOrder,
+			&i.changelog.SocialLinks,
+			&i.changelog.RobotsTxt,
+			&i.changelog.Slug,
+			&i.changelog.CanonicalUrl,
+			&i.ChangelogSource.ID,
+			&i.ChangelogSource.WorkspaceID,
+			&i.ChangelogSource.Owner,
+			&i.ChangelogSource.Repo,
+			&i.ChangelogSource.Path,
+			&i.ChangelogSource.InstallationID,
+			&i.ChangelogSource.LastFetchedAt,
+			&i.ChangelogSource.FetchIntervalSeconds,
+			&i.ChangelogSource.PrivateKeyID,
+			&i.ChangelogSource.PrivateKeyBlob,
+			&i.ChangelogSource.WebhookSecret,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listSubdomainsByWorkspace = `-- name: listSubdomainsByWorkspace :many
+SELECT subdomain FROM changelogs
+WHERE workspace_id = ? AND subdomain != ''
+ORDER BY subdomain
+`
+
+func (q *Queries) listSubdomainsByWorkspace(ctx context.Context, workspaceID string) ([]string, error) {
+	rows, err := q.db.QueryContext(ctx, listSubdomainsByWorkspace, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []string
+	for rows.Next() {
+		var subdomain string
+		if err := rows.Scan(&subdomain); err != nil {
+			return nil, err
+		}
+		items = append(items, subdomain)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listSyncLogs = `-- name: listSyncLogs :many
+SELECT l.id, l.workspace_id, l.gh_source_id, l.started_at, l.finished_at, l.files_found, l.files_changed, l.error
+FROM gh_sync_logs l
+WHERE l.workspace_id = ? AND l.gh_source_id = ?
+ORDER BY l.started_at DESC
+LIMIT ?
+`
+
+type listSyncLogsParams struct {
+	WorkspaceID string
+	GhSourceID  string
+	Limit       int64
+}
+
+type listSyncLogsRow struct {
+	ghSyncLog ghSyncLog
+}
+
+func (q *Queries) listSyncLogs(ctx context.Context, arg listSyncLogsParams) ([]listSyncLogsRow, error) {
+	rows, err := q.db.QueryContext(ctx, listSyncLogs, arg.WorkspaceID, arg.GhSourceID, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []listSyncLogsRow
+	for rows.Next() {
+		var i listSyncLogsRow
+		if err := rows.Scan(
+			&i.ghSyncLog.ID,
+			&i.ghSyncLog.WorkspaceID,
+			&i.ghSyncLog.GhSourceID,
+			&i.ghSyncLog.StartedAt,
+			&i.ghSyncLog.FinishedAt,
+			&i.ghSyncLog.FilesFound,
+			&i.ghSyncLog.FilesChanged,
+			&i.ghSyncLog.Error,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listTokens = `-- name: listTokens :many
+SELECT "key", workspace_id, created_at, expires_at, scopes, label, last_used_at, id FROM tokens
+WHERE workspace_id = ?
+ORDER BY created_at DESC, id DESC
+`
+
+func (q *Queries) listTokens(ctx context.Context, workspaceID string) ([]token, error) {
+	rows, err := q.db.QueryContext(ctx, listTokens, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []token
+	for rows.Next() {
+		var i token
+		if err := rows.Scan(
+			&i.Key,
+			&i.WorkspaceID,
+			&i.CreatedAt,
+			&i.ExpiresAt,
+			&i.Scopes,
+			&i.Label,
+			&i.LastUsedAt,
+			&i.ID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listWebhookDeliveries = `-- name: listWebhookDeliveries :many
+SELECT d.id, d.endpoint_id, d.event_type, d.payload, d.payload_hash, d.status_code, d.response_body, d.delivered_at, d.duration_ms, d.success FROM webhook_deliveries d
+JOIN webhook_endpoints e ON e.id = d.endpoint_id
+WHERE e.workspace_id = ? AND d.endpoint_id = ?
+ORDER BY d.delivered_at DESC, d.id DESC
+LIMIT ? OFFSET ?
+`
+
+type listWebhookDeliveriesParams struct {
+	WorkspaceID string
+	EndpointID  string
+	Limit       int64
+	Offset      int64
+}
+
+func (q *Queries) listWebhookDeliveries(ctx context.Context, arg listWebhookDeliveriesParams) ([]webhookDelivery, error) {
+	rows, err := q.db.QueryContext(ctx, listWebhookDeliveries,
+		arg.WorkspaceID,
+		arg.EndpointID,
+		arg.Limit,
+		arg.Offset,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []webhookDelivery
+	for rows.Next() {
+		var i webhookDelivery
+		if err := rows.Scan(
+			&i.ID,
+			&i.EndpointID,
+			&i.EventType,
+			&i.Payload,
+			&i.PayloadHash,
+			&i.StatusCode,
+			&i.ResponseBody,
+			&i.DeliveredAt,
+			&i.DurationMs,
+			&i.Success,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listWebhookEndpoints = `-- name: listWebhookEndpoints :many
+SELECT id, changelog_id, workspace_id, url, secret, events, active, created_at FROM webhook_endpoints
+WHERE workspace_id = ? AND changelog_id = ?
+ORDER BY created_at ASC
+`
+
+type listWebhookEndpointsParams struct {
+	WorkspaceID string
+	ChangelogID string
+}
+
+func (q *Queries) listWebhookEndpoints(ctx context.Context, arg listWebhookEndpointsParams) ([]webhookEndpoint, error) {
+	rows, err := q.db.QueryContext(ctx, listWebhookEndpoints, arg.WorkspaceID, arg.ChangelogID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []webhookEndpoint
+	for rows.Next() {
+		var i webhookEndpoint
+		if err := rows.Scan(
+			&i.ID,
+			&i.ChangelogID,
+			&i.WorkspaceID,
+			&i.Url,
+			&i.Secret,
+			&i.Events,
+			&i.Active,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listWorkspaceMembers = `-- name: listWorkspaceMembers :many
+SELECT workspace_id, user_id, role, joined_at FROM workspace_members
+WHERE workspace_id = ?
+ORDER BY joined_at ASC
+`
+
+func (q *Queries) listWorkspaceMembers(ctx context.Context, workspaceID string) ([]workspaceMember, error) {
+	rows, err := q.db.QueryContext(ctx, listWorkspaceMembers, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []workspaceMember
+	for rows.Next() {
+		var i workspaceMember
+		if err := rows.Scan(
+			&i.WorkspaceID,
+			&i.UserID,
+			&i.Role,
+			&i.JoinedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listWorkspacesChangelogCount = `-- name: listWorkspacesChangelogCount :many
+SELECT w.id, w.name, w.created_at, w.webhook_secret, COUNT(c.id) AS changelog_count
+FROM workspaces w
+LEFT JOIN changelogs c ON w.id = c.workspace_id
+GROUP BY w.id, w.name
+ORDER BY changelog_count DESC
+`
+
+type listWorkspacesChangelogCountRow struct {
+	workspace      workspace
+	ChangelogCount int64
+}
+
+func (q *Queries) listWorkspacesChangelogCount(ctx context.Context) ([]listWorkspacesChangelogCountRow, error) {
+	rows, err := q.db.QueryContext(ctx, listWorkspacesChangelogCount)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []listWorkspacesChangelogCountRow
+	for rows.Next() {
+		var i listWorkspacesChangelogCountRow
+		if err := rows.Scan(
+			&i.workspace.ID,
+			&i.workspace.Name,
+			&i.workspace.CreatedAt,
+			&i.workspace.WebhookSecret,
+			&i.ChangelogCount,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listWorkspacesWithExpiredTokens = `-- name: listWorkspacesWithExpiredTokens :many
+SELECT DISTINCT workspace_id FROM tokens
+WHERE expires_at IS NOT NULL AND expires_at < ?
+`
+
+func (q *Queries) listWorkspacesWithExpiredTokens(ctx context.Context, expiresAt sql.NullInt64) ([]string, error) {
+	rows, err := q.db.QueryContext(ctx, listWorkspacesWithExpiredTokens, expiresAt)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []string
+	for rows.Next() {
+		var workspace_id string
+		if err := rows.Scan(&workspace_id); err != nil {
+			return nil, err
+		}
+		items = append(items, workspace_id)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const moveEntryLabels = `-- name: moveEntryLabels :execrows
+
+UPDATE OR IGNORE entry_labels
+SET label_name = ?1
+WHERE workspace_id = ?2 AND label_name = ?3
+`
+
+type moveEntryLabelsParams struct {
+	ToLabel     string
+	WorkspaceID string
+	FromLabel   string
+}
+
+// entries which already have the new label keep their old one, it's deleted afterwards
+func (q *Queries) moveEntryLabels(ctx context.Context, arg moveEntryLabelsParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, moveEntryLabels, arg.ToLabel, arg.WorkspaceID, arg.FromLabel)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const purgeAPIRequests = `-- name: purgeAPIRequests :exec
+DELETE FROM api_requests
+WHERE requested_at < ?
+`
+
+func (q *Queries) purgeAPIRequests(ctx context.Context, requestedAt int64) error {
+	_, err := q.db.ExecContext(ctx, purgeAPIRequests, requestedAt)
+	return err
+}
+
+const purgeAccessLog = `-- name: purgeAccessLog :execrows
+DELETE FROM changelog_access_log
+WHERE accessed_at < ?
+`
+
+func (q *Queries) purgeAccessLog(ctx context.Context, accessedAt int64) (int64, error) {
+	result, err := q.db.ExecContext(ctx, purgeAccessLog, accessedAt)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const purgeExpiredTokens = `-- name: purgeExpiredTokens :execrows
+DELETE FROM tokens
+WHERE expires_at IS NOT NULL AND expires_at < ?
+`
+
+func (q *Queries) purgeExpiredTokens(ctx context.Context, expiresAt sql.NullInt64) (int64, error) {
+	result, err := q.db.ExecContext(ctx, purgeExpiredTokens, expiresAt)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const purgeSyncLogs = `-- name: purgeSyncLogs :execrows
+DELETE FROM gh_sync_logs
+WHERE started_at < ?
+`
+
+func (q *Queries) purgeSyncLogs(ctx context.Context, startedAt int64) (int64, error) {
+	result, err := q.db.ExecContext(ctx, purgeSyncLogs, startedAt)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const recordDomainVerification = `-- name: recordDomainVerification :execrows
+UPDATE domain_verifications
+SET
+    verified_at = CASE WHEN ?1 THEN COALESCE(verified_at, unixepoch('now')) ELSE NULL END,
+    last_checked_at = unixepoch('now'),
+    check_error = ?2
+WHERE workspace_id = ?3 AND changelog_id = ?4
+    AND domain = (SELECT c.domain FROM changelogs c WHERE c.workspace_id = ?3 AND c.id = ?4)
+`
+
+type recordDomainVerificationParams struct {
+	Verified    interface{}
+	CheckError  apitypes.NullString
+	WorkspaceID string
+	ChangelogID string
+}
+
+func (q *Queries) recordDomainVerification(ctx context.Context, arg recordDomainVerificationParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, recordDomainVerification,
+		arg.Verified,
+		arg.CheckError,
+		arg.WorkspaceID,
+		arg.ChangelogID,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
 }
 
-type getChangelogByDomainOrSubdomainRow struct {
-	changelog       changelog
-	ChangelogSource changelogSource
+const recordGHSourceFailure = `-- name: recordGHSourceFailure :exec
+INSERT INTO gh_source_health (workspace_id, gh_source_id, last_error, consecutive_failures, last_checked_at)
+VALUES (?, ?, ?, 1, unixepoch('now'))
+ON CONFLICT (workspace_id, gh_source_id) DO UPDATE SET
+    last_error = excluded.last_error,
+    consecutive_failures = consecutive_failures + 1,
+    last_checked_at = excluded.last_checked_at
+`
+
+type recordGHSourceFailureParams struct {
+	WorkspaceID string
+	GhSourceID  string
+	LastError   apitypes.NullString
 }
 
-// first search by domain, if not found by subdomain
-func (q *Queries) getChangelogByDomainOrSubdomain(ctx context.Context, arg getChangelogByDomainOrSubdomainParams) (getChangelogByDomainOrSubdomainRow, error) {
-	row := q.db.QueryRowContext(ctx, getChangelogByDomainOrSubdomain, arg.Domain, arg.Subdomain)
-	var i getChangelogByDomainOrSubdomainRow
-	err := row.Scan(
-		&i.changelog.ID,
-		&i.changelog.WorkspaceID,
-		&i.changelog.Subdomain,
-		&i.changelog.Title,
-		&i.changelog.Subtitle,
-		&i.changelog.SourceID,
-		&i.changelog.LogoSrc,
-		&i.changelog.LogoLink,
-		&i.changelog.LogoAlt,
-		&i.changelog.LogoHeight,
-		&i.changelog.LogoWidth,
-		&i.changelog.CreatedAt,
-		&i.changelog.Domain,
-		&i.changelog.ColorScheme,
-		&i.changelog.HidePoweredBy,
-		&i.changelog.Protected,
-		&i.changelog.PasswordHash,
-		&i.changelog.Analytics,
-		&i.changelog.Searchable,
-		&i.ChangelogSource.ID,
-		&i.ChangelogSource.WorkspaceID,
-		&i.ChangelogSource.Owner,
-		&i.ChangelogSource.Repo,
-		&i.ChangelogSource.Path,
-		&i.ChangelogSource.InstallationID,
-	)
-	return i, err
+func (q *Queries) recordGHSourceFailure(ctx context.Context, arg recordGHSourceFailureParams) error {
+	_, err := q.db.ExecContext(ctx, recordGHSourceFailure, arg.WorkspaceID, arg.GhSourceID, arg.LastError)
+	return err
 }
 
-const getGHSource = `-- name: getGHSource :one
-SELECT id, workspace_id, owner, repo, path, installation_id FROM gh_sources
-WHERE workspace_id = ? AND id = ?
+const recordGHSourceSuccess = `-- name: recordGHSourceSuccess :exec
+INSERT INTO gh_source_health (workspace_id, gh_source_id, consecutive_failures, last_checked_at)
+VALUES (?, ?, 0, unixepoch('now'))
+ON CONFLICT (workspace_id, gh_source_id) DO UPDATE SET
+    last_error = NULL,
+    consecutive_failures = 0,
+    last_checked_at = excluded.last_checked_at
 `
 
-type getGHSourceParams struct {
+type recordGHSourceSuccessParams struct {
 	WorkspaceID string
-	ID          string
+	GhSourceID  string
 }
 
-func (q *Queries) getGHSource(ctx context.Context, arg getGHSourceParams) (ghSource, error) {
-	row := q.db.QueryRowContext(ctx, getGHSource, arg.WorkspaceID, arg.ID)
-	var i ghSource
-	err := row.Scan(
-		&i.ID,
-		&i.WorkspaceID,
-		&i.Owner,
-		&i.Repo,
-		&i.Path,
-		&i.InstallationID,
-	)
-	return i, err
+func (q *Queries) recordGHSourceSuccess(ctx context.Context, arg recordGHSourceSuccessParams) error {
+	_, err := q.db.ExecContext(ctx, recordGHSourceSuccess, arg.WorkspaceID, arg.GhSourceID)
+	return err
 }
 
-const getToken = `-- name: getToken :one
-SELECT "key", workspace_id FROM tokens
-WHERE key = ?
+const recordSearchQuery = `-- name: recordSearchQuery :exec
+INSERT INTO search_queries (workspace_id, changelog_id, query, result_count)
+VALUES (?, ?, ?, ?)
 `
 
-func (q *Queries) getToken(ctx context.Context, key string) (token, error) {
-	row := q.db.QueryRowContext(ctx, getToken, key)
-	var i token
-	err := row.Scan(&i.Key, &i.WorkspaceID)
-	return i, err
+type recordSearchQueryParams struct {
+	WorkspaceID string
+	ChangelogID string
+	Query       string
+	ResultCount int64
 }
 
-const getTokenByWorkspace = `-- name: getTokenByWorkspace :one
+func (q *Queries) recordSearchQuery(ctx context.Context, arg recordSearchQueryParams) error {
+	_, err := q.db.ExecContext(ctx, recordSearchQuery,
+		arg.WorkspaceID,
+		arg.ChangelogID,
+		arg.Query,
+		arg.ResultCount,
+	)
+	return err
+}
 
-SELECT "key", workspace_id FROM tokens
-WHERE workspace_id = ?
-LIMIT 1
+const resolveEntryComment = `-- name: resolveEntryComment :execrows
+UPDATE entry_comments
+SET resolved = ?, updated_at = unixepoch('now')
+WHERE workspace_id = ? AND changelog_id = ? AND id = ?
 `
 
-// technically a workspace can have multiple tokens, but domain only create one token when workspace is created
-func (q *Queries) getTokenByWorkspace(ctx context.Context, workspaceID string) (token, error) {
-	row := q.db.QueryRowContext(ctx, getTokenByWorkspace, workspaceID)
-	var i token
-	err := row.Scan(&i.Key, &i.WorkspaceID)
-	return i, err
+type resolveEntryCommentParams struct {
+	Resolved    int64
+	WorkspaceID string
+	ChangelogID string
+	ID          string
 }
 
-const getWorkspace = `-- name: getWorkspace :one
-SELECT w.id, w.name, t."key", t.workspace_id
-FROM workspaces w
-LEFT JOIN tokens t ON w.id = t.workspace_id
-WHERE id = ?
+func (q *Queries) resolveEntryComment(ctx context.Context, arg resolveEntryCommentParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, resolveEntryComment,
+		arg.Resolved,
+		arg.WorkspaceID,
+		arg.ChangelogID,
+		arg.ID,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const saveWorkspace = `-- name: saveWorkspace :one
+INSERT INTO workspaces (
+    id, name, created_at
+) VALUES (?1, ?2, unixepoch('now'))
+ON CONFLICT (id)
+DO UPDATE SET name = ?2
+RETURNING id, name, created_at, webhook_secret
 `
 
-type getWorkspaceRow struct {
-	workspace workspace
-	token     token
+type saveWorkspaceParams struct {
+	ID   string
+	Name string
 }
 
-func (q *Queries) getWorkspace(ctx context.Context, id string) (getWorkspaceRow, error) {
-	row := q.db.QueryRowContext(ctx, getWorkspace, id)
-	var i getWorkspaceRow
+func (q *Queries) saveWorkspace(ctx context.Context, arg saveWorkspaceParams) (workspace, error) {
+	row := q.db.QueryRowContext(ctx, saveWorkspace, arg.ID, arg.Name)
+	var i workspace
 	err := row.Scan(
-		&i.workspace.ID,
-		&i.workspace.Name,
-		&i.token.Key,
-		&i.token.WorkspaceID,
+		&i.ID,
+		&i.Name,
+		&i.CreatedAt,
+		&i.WebhookSecret,
 	)
 	return i, err
 }
 
-const listChangelogs = `-- name: listChangelogs :many
-SELECT c.id, c.workspace_id, c.subdomain, c.title, c.subtitle, c.source_id, c.logo_src, c.logo_link, c.logo_alt, c.logo_height, c.logo_width, c.created_at, c.domain, c.color_scheme, c.hide_powered_by, c.protected, c.password_hash, c.analytics, c.searchable, cs.id, cs.workspace_id, cs.owner, cs.repo, cs.path, cs.installation_id
-FROM changelogs c
-LEFT JOIN changelog_source cs ON c.workspace_id = cs.workspace_id AND c.source_id = cs.id
-WHERE c.workspace_id = ?
+const searchWorkspaces = `-- name: searchWorkspaces :many
+SELECT id, name, created_at, webhook_secret FROM workspaces
+WHERE name LIKE ?1 ESCAPE '\'
+ORDER BY name COLLATE NOCASE
+LIMIT ?2
 `
 
-type listChangelogsRow struct {
-	changelog       changelog
-	ChangelogSource changelogSource
+type searchWorkspacesParams struct {
+	NamePattern string
+	MaxResults  int64
 }
 
-func (q *Queries) listChangelogs(ctx context.Context, workspaceID string) ([]listChangelogsRow, error) {
-	rows, err := q.db.QueryContext(ctx, listChangelogs, workspaceID)
+func (q *Queries) searchWorkspaces(ctx context.Context, arg searchWorkspacesParams) ([]workspace, error) {
+	rows, err := q.db.QueryContext(ctx, searchWorkspaces, arg.NamePattern, arg.MaxResults)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []listChangelogsRow
+	var items []workspace
 	for rows.Next() {
-		var i listChangelogsRow
+		var i workspace
 		if err := rows.Scan(
-			&i.changelog.ID,
-			&i.changelog.WorkspaceID,
-			&i.changelog.Subdomain,
-			&i.changelog.Title,
-			&i.changelog.Subtitle,
-			&i.changelog.SourceID,
-			&i.changelog.LogoSrc,
-			&i.changelog.LogoLink,
-			&i.changelog.LogoAlt,
-			&i.changelog.LogoHeight,
-			&i.changelog.LogoWidth,
-			&i.changelog.CreatedAt,
-			&i.changelog.Domain,
-			&i.changelog.ColorScheme,
-			&i.changelog.HidePoweredBy,
-			&i.changelog.Protected,
-			&i.changelog.PasswordHash,
-			&i.changelog.Analytics,
-			&i.changelog.Searchable,
-			&i.ChangelogSource.ID,
-			&i.ChangelogSource.WorkspaceID,
-			&i.ChangelogSource.Owner,
-			&i.ChangelogSource.Repo,
-			&i.ChangelogSource.Path,
-			&i.ChangelogSource.InstallationID,
+			&i.ID,
+			&i.Name,
+			&i.CreatedAt,
+			&i.WebhookSecret,
 		); err != nil {
 			return nil, err
 		}
@@ -449,112 +4617,438 @@ func (q *Queries) listChangelogs(ctx context.Context, workspaceID string) ([]lis
 	return items, nil
 }
 
-const listGHSources = `-- name: listGHSources :many
-SELECT id, workspace_id, owner, repo, path, installation_id FROM gh_sources
-WHERE workspace_id = ?
+const setCachedTree = `-- name: setCachedTree :exec
+INSERT INTO gh_source_tree_cache (workspace_id, gh_source_id, tree_json, cached_at, etag)
+VALUES (?, ?, ?, unixepoch('now'), ?)
+ON CONFLICT (workspace_id, gh_source_id) DO UPDATE SET
+    tree_json = excluded.tree_json,
+    cached_at = excluded.cached_at,
+    etag = excluded.etag
+`
+
+type setCachedTreeParams struct {
+	WorkspaceID string
+	GhSourceID  string
+	TreeJson    []byte
+	Etag        string
+}
+
+func (q *Queries) setCachedTree(ctx context.Context, arg setCachedTreeParams) error {
+	_, err := q.db.ExecContext(ctx, setCachedTree,
+		arg.WorkspaceID,
+		arg.GhSourceID,
+		arg.TreeJson,
+		arg.Etag,
+	)
+	return err
+}
+
+const setChangelogCanonicalURL = `-- name: setChangelogCanonicalURL :execrows
+UPDATE changelogs
+SET canonical_url = ?
+WHERE workspace_id = ? AND id = ?
+`
+
+type setChangelogCanonicalURLParams struct {
+	CanonicalUrl apitypes.NullString
+	WorkspaceID  string
+	ID           string
+}
+
+func (q *Queries) setChangelogCanonicalURL(ctx context.Context, arg setChangelogCanonicalURLParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, setChangelogCanonicalURL, arg.CanonicalUrl, arg.WorkspaceID, arg.ID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const setChangelogContentHash = `-- name: setChangelogContentHash :exec
+INSERT INTO changelog_content_hashes (workspace_id, changelog_id, hash)
+VALUES (?, ?, ?)
+ON CONFLICT (workspace_id, changelog_id)
+DO UPDATE SET
+    hash = excluded.hash,
+    updated_at = unixepoch('now')
+`
+
+type setChangelogContentHashParams struct {
+	WorkspaceID string
+	ChangelogID string
+	Hash        string
+}
+
+func (q *Queries) setChangelogContentHash(ctx context.Context, arg setChangelogContentHashParams) error {
+	_, err := q.db.ExecContext(ctx, setChangelogContentHash, arg.WorkspaceID, arg.ChangelogID, arg.Hash)
+	return err
+}
+
+const setChangelogFaviconSrc = `-- name: setChangelogFaviconSrc :execrows
+UPDATE changelogs
+SET favicon_src = ?
+WHERE workspace_id = ? AND id = ?
+`
+
+type setChangelogFaviconSrcParams struct {
+	FaviconSrc  apitypes.NullString
+	WorkspaceID string
+	ID          string
+}
+
+func (q *Queries) setChangelogFaviconSrc(ctx context.Context, arg setChangelogFaviconSrcParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, setChangelogFaviconSrc, arg.FaviconSrc, arg.WorkspaceID, arg.ID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const setChangelogI18nString = `-- name: setChangelogI18nString :exec
+INSERT INTO changelog_i18n (workspace_id, changelog_id, key, value)
+VALUES (?, ?, ?, ?)
+ON CONFLICT (workspace_id, changelog_id, key) DO UPDATE SET
+    value = excluded.value
+`
+
+type setChangelogI18nStringParams struct {
+	WorkspaceID string
+	ChangelogID string
+	Key         string
+	Value       string
+}
+
+func (q *Queries) setChangelogI18nString(ctx context.Context, arg setChangelogI18nStringParams) error {
+	_, err := q.db.ExecContext(ctx, setChangelogI18nString,
+		arg.WorkspaceID,
+		arg.ChangelogID,
+		arg.Key,
+		arg.Value,
+	)
+	return err
+}
+
+const setChangelogOGImage = `-- name: setChangelogOGImage :exec
+INSERT INTO changelog_og_images (workspace_id, changelog_id, image, etag)
+VALUES (?, ?, ?, ?)
+ON CONFLICT (workspace_id, changelog_id)
+DO UPDATE SET
+    image = excluded.image,
+    etag = excluded.etag,
+    generated_at = unixepoch('now')
+`
+
+type setChangelogOGImageParams struct {
+	WorkspaceID string
+	ChangelogID string
+	Image       []byte
+	Etag        string
+}
+
+func (q *Queries) setChangelogOGImage(ctx context.Context, arg setChangelogOGImageParams) error {
+	_, err := q.db.ExecContext(ctx, setChangelogOGImage,
+		arg.WorkspaceID,
+		arg.ChangelogID,
+		arg.Image,
+		arg.Etag,
+	)
+	return err
+}
+
+const setChangelogRobotsTxt = `-- name: setChangelogRobotsTxt :execrows
+UPDATE changelogs
+SET robots_txt = ?
+WHERE workspace_id = ? AND id = ?
+`
+
+type setChangelogRobotsTxtParams struct {
+	RobotsTxt   apitypes.NullString
+	WorkspaceID string
+	ID          string
+}
+
+func (q *Queries) setChangelogRobotsTxt(ctx context.Context, arg setChangelogRobotsTxtParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, setChangelogRobotsTxt, arg.RobotsTxt, arg.WorkspaceID, arg.ID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const setChangelogSocialLinks = `-- name: setChangelogSocialLinks :execrows
+UPDATE changelogs
+SET social_links = ?
+WHERE workspace_id = ? AND id = ?
+`
+
+type setChangelogSocialLinksParams struct {
+	SocialLinks apitypes.NullString
+	WorkspaceID string
+	ID          string
+}
+
+func (q *Queries) setChangelogSocialLinks(ctx context.Context, arg setChangelogSocialLinksParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, setChangelogSocialLinks, arg.SocialLinks, arg.WorkspaceID, arg.ID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const setChangelogSortOrder = `-- name: setChangelogSortOrder :execrows
+UPDATE changelogs
+SET sort_order = ?
+WHERE workspace_id = ? AND id = ?
+`
+
+type setChangelogSortOrderParams struct {
+	SortOrder   int64
+	WorkspaceID string
+	ID          string
+}
+
+func (q *Queries) setChangelogSortOrder(ctx context.Context, arg setChangelogSortOrderParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, setChangelogSortOrder, arg.SortOrder, arg.WorkspaceID, arg.ID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const setChangelogSource = `-- name: setChangelogSource :exec
+UPDATE changelogs
+SET source_id = ?
+WHERE workspace_id = ? AND id = ?
+`
+
+type setChangelogSourceParams struct {
+	SourceID    apitypes.NullString
+	WorkspaceID string
+	ID          string
+}
+
+func (q *Queries) setChangelogSource(ctx context.Context, arg setChangelogSourceParams) error {
+	_, err := q.db.ExecContext(ctx, setChangelogSource, arg.SourceID, arg.WorkspaceID, arg.ID)
+	return err
+}
+
+const setChangelogTheme = `-- name: setChangelogTheme :exec
+INSERT INTO changelog_themes (workspace_id, changelog_id, font_family, accent_color, background_color, text_color, border_radius_px, custom_css)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+ON CONFLICT (workspace_id, changelog_id) DO UPDATE SET
+    font_family = excluded.font_family,
+    accent_color = excluded.accent_color,
+    background_color = excluded.background_color,
+    text_color = excluded.text_color,
+    border_radius_px = excluded.border_radius_px,
+    custom_css = excluded.custom_css
 `
 
-func (q *Queries) listGHSources(ctx context.Context, workspaceID string) ([]ghSource, error) {
-	rows, err := q.db.QueryContext(ctx, listGHSources, workspaceID)
+type setChangelogThemeParams struct {
+	WorkspaceID     string
+	ChangelogID     string
+	FontFamily      apitypes.NullString
+	AccentColor     apitypes.NullString
+	BackgroundColor apitypes.NullString
+	TextColor       apitypes.NullString
+	BorderRadiusPx  sql.NullInt64
+	CustomCss       apitypes.NullString
+}
+
+func (q *Queries) setChangelogTheme(ctx context.Context, arg setChangelogThemeParams) error {
+	_, err := q.db.ExecContext(ctx, setChangelogTheme,
+		arg.WorkspaceID,
+		arg.ChangelogID,
+		arg.FontFamily,
+		arg.AccentColor,
+		arg.BackgroundColor,
+		arg.TextColor,
+		arg.BorderRadiusPx,
+		arg.CustomCss,
+	)
+	return err
+}
+
+const setCustomDomainVerified = `-- name: setCustomDomainVerified :execrows
+UPDATE changelog_custom_domains
+SET verified = ?
+WHERE workspace_id = ? AND changelog_id = ? AND id = ?
+`
+
+type setCustomDomainVerifiedParams struct {
+	Verified    int64
+	WorkspaceID string
+	ChangelogID string
+	ID          string
+}
+
+func (q *Queries) setCustomDomainVerified(ctx context.Context, arg setCustomDomainVerifiedParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, setCustomDomainVerified,
+		arg.Verified,
+		arg.WorkspaceID,
+		arg.ChangelogID,
+		arg.ID,
+	)
 	if err != nil {
-		return nil, err
-	}
-	defer rows.Close()
-	var items []ghSource
-	for rows.Next() {
-		var i ghSource
-		if err := rows.Scan(
-			&i.ID,
-			&i.WorkspaceID,
-			&i.Owner,
-			&i.Repo,
-			&i.Path,
-			&i.InstallationID,
-		); err != nil {
-			return nil, err
-		}
-		items = append(items, i)
-	}
-	if err := rows.Close(); err != nil {
-		return nil, err
-	}
-	if err := rows.Err(); err != nil {
-		return nil, err
+		return 0, err
 	}
-	return items, nil
+	return result.RowsAffected()
 }
 
-const listWorkspacesChangelogCount = `-- name: listWorkspacesChangelogCount :many
-SELECT w.id, w.name, COUNT(c.id) AS changelog_count
-FROM workspaces w
-LEFT JOIN changelogs c ON w.id = c.workspace_id
-GROUP BY w.id, w.name
-ORDER BY changelog_count DESC
+const setGHSourceFetchInterval = `-- name: setGHSourceFetchInterval :execrows
+UPDATE gh_sources
+SET fetch_interval_seconds = ?
+WHERE workspace_id = ? AND id = ?
 `
 
-type listWorkspacesChangelogCountRow struct {
-	workspace      workspace
-	ChangelogCount int64
+type setGHSourceFetchIntervalParams struct {
+	FetchIntervalSeconds int64
+	WorkspaceID          string
+	ID                   string
 }
 
-func (q *Queries) listWorkspacesChangelogCount(ctx context.Context) ([]listWorkspacesChangelogCountRow, error) {
-	rows, err := q.db.QueryContext(ctx, listWorkspacesChangelogCount)
+func (q *Queries) setGHSourceFetchInterval(ctx context.Context, arg setGHSourceFetchIntervalParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, setGHSourceFetchInterval, arg.FetchIntervalSeconds, arg.WorkspaceID, arg.ID)
 	if err != nil {
-		return nil, err
-	}
-	defer rows.Close()
-	var items []listWorkspacesChangelogCountRow
-	for rows.Next() {
-		var i listWorkspacesChangelogCountRow
-		if err := rows.Scan(&i.workspace.ID, &i.workspace.Name, &i.ChangelogCount); err != nil {
-			return nil, err
-		}
-		items = append(items, i)
-	}
-	if err := rows.Close(); err != nil {
-		return nil, err
+		return 0, err
 	}
-	if err := rows.Err(); err != nil {
-		return nil, err
+	return result.RowsAffected()
+}
+
+const setGHSourceWebhookSecret = `-- name: setGHSourceWebhookSecret :execrows
+UPDATE gh_sources
+SET webhook_secret = ?
+WHERE workspace_id = ? AND id = ?
+`
+
+type setGHSourceWebhookSecretParams struct {
+	WebhookSecret apitypes.NullString
+	WorkspaceID   string
+	ID            string
+}
+
+func (q *Queries) setGHSourceWebhookSecret(ctx context.Context, arg setGHSourceWebhookSecretParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, setGHSourceWebhookSecret, arg.WebhookSecret, arg.WorkspaceID, arg.ID)
+	if err != nil {
+		return 0, err
 	}
-	return items, nil
+	return result.RowsAffected()
 }
 
-const saveWorkspace = `-- name: saveWorkspace :one
-INSERT INTO workspaces (
-    id, name
+const setInstallationIDForWorkspace = `-- name: setInstallationIDForWorkspace :exec
+INSERT INTO workspace_gh_installations (
+    workspace_id, installation_id
 ) VALUES (?1, ?2)
-ON CONFLICT (id)
-DO UPDATE SET name = ?2
-RETURNING id, name
+ON CONFLICT (workspace_id)
+DO UPDATE SET installation_id = ?2, installed_at = unixepoch('now')
 `
 
-type saveWorkspaceParams struct {
-	ID   string
-	Name string
+type setInstallationIDForWorkspaceParams struct {
+	WorkspaceID    string
+	InstallationID int64
 }
 
-func (q *Queries) saveWorkspace(ctx context.Context, arg saveWorkspaceParams) (workspace, error) {
-	row := q.db.QueryRowContext(ctx, saveWorkspace, arg.ID, arg.Name)
-	var i workspace
-	err := row.Scan(&i.ID, &i.Name)
-	return i, err
+func (q *Queries) setInstallationIDForWorkspace(ctx context.Context, arg setInstallationIDForWorkspaceParams) error {
+	_, err := q.db.ExecContext(ctx, setInstallationIDForWorkspace, arg.WorkspaceID, arg.InstallationID)
+	return err
 }
 
-const setChangelogSource = `-- name: setChangelogSource :exec
-UPDATE changelogs
-SET source_id = ?
-WHERE workspace_id = ? AND id = ?
+const setInstallationToken = `-- name: setInstallationToken :exec
+INSERT INTO gh_installation_tokens (installation_id, token, expires_at)
+VALUES (?, ?, ?)
+ON CONFLICT (installation_id) DO UPDATE SET
+    token = excluded.token,
+    expires_at = excluded.expires_at
 `
 
-type setChangelogSourceParams struct {
-	SourceID    apitypes.NullString
+type setInstallationTokenParams struct {
+	InstallationID int64
+	Token          string
+	ExpiresAt      int64
+}
+
+func (q *Queries) setInstallationToken(ctx context.Context, arg setInstallationTokenParams) error {
+	_, err := q.db.ExecContext(ctx, setInstallationToken, arg.InstallationID, arg.Token, arg.ExpiresAt)
+	return err
+}
+
+const setTokenScopes = `-- name: setTokenScopes :execrows
+UPDATE tokens
+SET scopes = ?
+WHERE workspace_id = ? AND key = ?
+`
+
+type setTokenScopesParams struct {
+	Scopes      apitypes.NullString
 	WorkspaceID string
-	ID          string
+	Key         string
 }
 
-func (q *Queries) setChangelogSource(ctx context.Context, arg setChangelogSourceParams) error {
-	_, err := q.db.ExecContext(ctx, setChangelogSource, arg.SourceID, arg.WorkspaceID, arg.ID)
+func (q *Queries) setTokenScopes(ctx context.Context, arg setTokenScopesParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, setTokenScopes, arg.Scopes, arg.WorkspaceID, arg.Key)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const setWorkspaceWebhookSecret = `-- name: setWorkspaceWebhookSecret :execrows
+UPDATE workspaces
+SET webhook_secret = ?
+WHERE id = ?
+`
+
+type setWorkspaceWebhookSecretParams struct {
+	WebhookSecret apitypes.NullString
+	ID            string
+}
+
+func (q *Queries) setWorkspaceWebhookSecret(ctx context.Context, arg setWorkspaceWebhookSecretParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, setWorkspaceWebhookSecret, arg.WebhookSecret, arg.ID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const startDomainVerification = `-- name: startDomainVerification :exec
+
+INSERT INTO domain_verifications (workspace_id, changelog_id, domain, cname_target)
+VALUES (?, ?, ?, ?)
+ON CONFLICT (workspace_id, changelog_id)
+DO UPDATE SET
+    domain = excluded.domain,
+    cname_target = excluded.cname_target,
+    verified_at = NULL,
+    last_checked_at = NULL,
+    check_error = NULL
+`
+
+type startDomainVerificationParams struct {
+	WorkspaceID string
+	ChangelogID string
+	Domain      string
+	CnameTarget string
+}
+
+// restarting a verification resets the result of previous checks
+func (q *Queries) startDomainVerification(ctx context.Context, arg startDomainVerificationParams) error {
+	_, err := q.db.ExecContext(ctx, startDomainVerification,
+		arg.WorkspaceID,
+		arg.ChangelogID,
+		arg.Domain,
+		arg.CnameTarget,
+	)
+	return err
+}
+
+const touchToken = `-- name: touchToken :exec
+UPDATE tokens
+SET last_used_at = unixepoch('now')
+WHERE key = ?
+`
+
+func (q *Queries) touchToken(ctx context.Context, key string) error {
+	_, err := q.db.ExecContext(ctx, touchToken, key)
 	return err
 }
 
@@ -575,9 +5069,12 @@ SET
    protected = coalesce(?21, protected),
    analytics = coalesce(?22, analytics),
    searchable = coalesce(?23, searchable),
-   password_hash = CASE WHEN cast(?24 as bool) THEN ?25 ELSE password_hash END
-WHERE workspace_id = ?26 AND id = ?27
-RETURNING id, workspace_id, subdomain, title, subtitle, source_id, logo_src, logo_link, logo_alt, logo_height, logo_width, created_at, domain, color_scheme, hide_powered_by, protected, password_hash, analytics, searchable
+   password_hash = CASE WHEN cast(?24 as bool) THEN ?25 ELSE password_hash END,
+   slug = CASE WHEN cast(?26 as bool) THEN ?27 ELSE slug END,
+   favicon_src = CASE WHEN cast(?28 as bool) THEN ?29 ELSE favicon_src END,
+   social_links = CASE WHEN cast(?30 as bool) THEN ?31 ELSE social_links END
+WHERE workspace_id = ?32 AND id = ?33
+RETURNING id, workspace_id, subdomain, title, subtitle, source_id, logo_src, logo_link, logo_alt, logo_height, logo_width, created_at, domain, color_scheme, hide_powered_by, protected, password_hash, analytics, searchable, favicon_src, sort_order, social_links, robots_txt, slug, canonical_url
 `
 
 type updateChangelogParams struct {
@@ -606,6 +5103,12 @@ type updateChangelogParams struct {
 	Searchable      sql.NullInt64
 	SetPasswordHash bool
 	PasswordHash    apitypes.NullString
+	SetSlug         bool
+	Slug            apitypes.NullString
+	SetFaviconSrc   bool
+	FaviconSrc      apitypes.NullString
+	SetSocialLinks  bool
+	SocialLinks     apitypes.NullString
 	WorkspaceID     string
 	ID              string
 }
@@ -637,6 +5140,12 @@ func (q *Queries) updateChangelog(ctx context.Context, arg updateChangelogParams
 		arg.Searchable,
 		arg.SetPasswordHash,
 		arg.PasswordHash,
+		arg.SetSlug,
+		arg.Slug,
+		arg.SetFaviconSrc,
+		arg.FaviconSrc,
+		arg.SetSocialLinks,
+		arg.SocialLinks,
 		arg.WorkspaceID,
 		arg.ID,
 	)
@@ -661,6 +5170,298 @@ func (q *Queries) updateChangelog(ctx context.Context, arg updateChangelogParams
 		&i.PasswordHash,
 		&i.Analytics,
 		&i.Searchable,
+		&i.FaviconSrc,
+		&i.SortOrder,
+		&i.SocialLinks,
+		&i.RobotsTxt,
+		&i.Slug,
+		&i.CanonicalUrl,
+	)
+	return i, err
+}
+
+const updateEntryComment = `-- name: updateEntryComment :one
+UPDATE entry_comments
+SET body = ?, updated_at = unixepoch('now')
+WHERE workspace_id = ? AND changelog_id = ? AND id = ?
+RETURNING id, workspace_id, changelog_id, entry_id, author_id, body, created_at, updated_at, resolved
+`
+
+type updateEntryCommentParams struct {
+	Body        string
+	WorkspaceID string
+	ChangelogID string
+	ID          string
+}
+
+func (q *Queries) updateEntryComment(ctx context.Context, arg updateEntryCommentParams) (entryComment, error) {
+	row := q.db.QueryRowContext(ctx, updateEntryComment,
+		arg.Body,
+		arg.WorkspaceID,
+		arg.ChangelogID,
+		arg.ID,
+	)
+	var i entryComment
+	err := row.Scan(
+		&i.ID,
+		&i.WorkspaceID,
+		&i.ChangelogID,
+		&i.EntryID,
+		&i.AuthorID,
+		&i.Body,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Resolved,
+	)
+	return i, err
+}
+
+const updateGHSourceLastFetched = `-- name: updateGHSourceLastFetched :exec
+UPDATE gh_sources
+SET last_fetched_at = unixepoch('now')
+WHERE workspace_id = ? AND id = ?
+`
+
+type updateGHSourceLastFetchedParams struct {
+	WorkspaceID string
+	ID          string
+}
+
+func (q *Queries) updateGHSourceLastFetched(ctx context.Context, arg updateGHSourceLastFetchedParams) error {
+	_, err := q.db.ExecContext(ctx, updateGHSourceLastFetched, arg.WorkspaceID, arg.ID)
+	return err
+}
+
+const updateWebhookEndpoint = `-- name: updateWebhookEndpoint :one
+UPDATE webhook_endpoints
+SET
+    url = ?1,
+    secret = COALESCE(?2, secret),
+    events = ?3,
+    active = ?4
+WHERE workspace_id = ?5 AND id = ?6
+RETURNING id, changelog_id, workspace_id, url, secret, events, active, created_at
+`
+
+type updateWebhookEndpointParams struct {
+	Url         string
+	Secret      apitypes.NullString
+	Events      string
+	Active      int64
+	WorkspaceID string
+	ID          string
+}
+
+func (q *Queries) updateWebhookEndpoint(ctx context.Context, arg updateWebhookEndpointParams) (webhookEndpoint, error) {
+	row := q.db.QueryRowContext(ctx, updateWebhookEndpoint,
+		arg.Url,
+		arg.Secret,
+		arg.Events,
+		arg.Active,
+		arg.WorkspaceID,
+		arg.ID,
+	)
+	var i webhookEndpoint
+	err := row.Scan(
+		&i.ID,
+		&i.ChangelogID,
+		&i.WorkspaceID,
+		&i.Url,
+		&i.Secret,
+		&i.Events,
+		&i.Active,
+		&i.CreatedAt,
 	)
 	return i, err
 }
+
+const updateWorkspaceMemberRole = `-- name: updateWorkspaceMemberRole :execrows
+UPDATE workspace_members
+SET role = ?
+WHERE workspace_id = ? AND user_id = ?
+`
+
+type updateWorkspaceMemberRoleParams struct {
+	Role        string
+	WorkspaceID string
+	UserID      string
+}
+
+func (q *Queries) updateWorkspaceMemberRole(ctx context.Context, arg updateWorkspaceMemberRoleParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, updateWorkspaceMemberRole, arg.Role, arg.WorkspaceID, arg.UserID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const upsertEmailDelivery = `-- name: upsertEmailDelivery :execrows
+INSERT INTO email_deliveries (id, workspace_id, changelog_id, to_email, subject, template, sent_at, status, error)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+ON CONFLICT (id) DO UPDATE SET
+    sent_at = excluded.sent_at,
+    status = excluded.status,
+    error = excluded.error
+WHERE email_deliveries.workspace_id = excluded.workspace_id
+`
+
+type upsertEmailDeliveryParams struct {
+	ID          string
+	WorkspaceID string
+	ChangelogID string
+	ToEmail     string
+	Subject     string
+	Template    string
+	SentAt      int64
+	Status      string
+	Error       apitypes.NullString
+}
+
+func (q *Queries) upsertEmailDelivery(ctx context.Context, arg upsertEmailDeliveryParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, upsertEmailDelivery,
+		arg.ID,
+		arg.WorkspaceID,
+		arg.ChangelogID,
+		arg.ToEmail,
+		arg.Subject,
+		arg.Template,
+		arg.SentAt,
+		arg.Status,
+		arg.Error,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const upsertPrimaryCustomDomain = `-- name: upsertPrimaryCustomDomain :execrows
+INSERT INTO changelog_custom_domains (id, workspace_id, changelog_id, domain)
+VALUES (?, ?, ?, ?)
+ON CONFLICT (domain) DO UPDATE SET
+    domain = excluded.domain
+WHERE changelog_custom_domains.workspace_id = excluded.workspace_id
+AND changelog_custom_domains.changelog_id = excluded.changelog_id
+`
+
+type upsertPrimaryCustomDomainParams struct {
+	ID          string
+	WorkspaceID string
+	ChangelogID string
+	Domain      string
+}
+
+func (q *Queries) upsertPrimaryCustomDomain(ctx context.Context, arg upsertPrimaryCustomDomainParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, upsertPrimaryCustomDomain,
+		arg.ID,
+		arg.WorkspaceID,
+		arg.ChangelogID,
+		arg.Domain,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const upsertWorkspaceBilling = `-- name: upsertWorkspaceBilling :exec
+INSERT INTO workspace_billing (workspace_id, customer_id, subscription_id, subscription_status, current_period_end)
+VALUES (?, ?, ?, ?, ?)
+ON CONFLICT (workspace_id) DO UPDATE SET
+    customer_id = excluded.customer_id,
+    subscription_id = excluded.subscription_id,
+    subscription_status = excluded.subscription_status,
+    current_period_end = excluded.current_period_end
+`
+
+type upsertWorkspaceBillingParams struct {
+	WorkspaceID        string
+	CustomerID         string
+	SubscriptionID     apitypes.NullString
+	SubscriptionStatus apitypes.NullString
+	CurrentPeriodEnd   sql.NullInt64
+}
+
+func (q *Queries) upsertWorkspaceBilling(ctx context.Context, arg upsertWorkspaceBillingParams) error {
+	_, err := q.db.ExecContext(ctx, upsertWorkspaceBilling,
+		arg.WorkspaceID,
+		arg.CustomerID,
+		arg.SubscriptionID,
+		arg.SubscriptionStatus,
+		arg.CurrentPeriodEnd,
+	)
+	return err
+}
+
+const upsertWorkspaceEmailSettings = `-- name: upsertWorkspaceEmailSettings :exec
+INSERT INTO workspace_email_settings (workspace_id, from_email, from_name, smtp_host, smtp_port, smtp_user, smtp_pass_enc, reply_to)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+ON CONFLICT (workspace_id) DO UPDATE SET
+    from_email = excluded.from_email,
+    from_name = excluded.from_name,
+    smtp_host = excluded.smtp_host,
+    smtp_port = excluded.smtp_port,
+    smtp_user = excluded.smtp_user,
+    smtp_pass_enc = COALESCE(excluded.smtp_pass_enc, workspace_email_settings.smtp_pass_enc),
+    reply_to = excluded.reply_to
+`
+
+type upsertWorkspaceEmailSettingsParams struct {
+	WorkspaceID string
+	FromEmail   string
+	FromName    apitypes.NullString
+	SmtpHost    string
+	SmtpPort    int64
+	SmtpUser    apitypes.NullString
+	SmtpPassEnc []byte
+	ReplyTo     apitypes.NullString
+}
+
+func (q *Queries) upsertWorkspaceEmailSettings(ctx context.Context, arg upsertWorkspaceEmailSettingsParams) error {
+	_, err := q.db.ExecContext(ctx, upsertWorkspaceEmailSettings,
+		arg.WorkspaceID,
+		arg.FromEmail,
+		arg.FromName,
+		arg.SmtpHost,
+		arg.SmtpPort,
+		arg.SmtpUser,
+		arg.SmtpPassEnc,
+		arg.ReplyTo,
+	)
+	return err
+}
+
+const upsertWorkspaceSSO = `-- name: upsertWorkspaceSSO :exec
+INSERT INTO workspace_sso (workspace_id, provider, issuer_url, client_id, client_secret_enc, certificate, enabled)
+VALUES (?, ?, ?, ?, ?, ?, ?)
+ON CONFLICT (workspace_id) DO UPDATE SET
+    provider = excluded.provider,
+    issuer_url = excluded.issuer_url,
+    client_id = excluded.client_id,
+    client_secret_enc = COALESCE(excluded.client_secret_enc, workspace_sso.client_secret_enc),
+    certificate = excluded.certificate,
+    enabled = excluded.enabled
+`
+
+type upsertWorkspaceSSOParams struct {
+	WorkspaceID     string
+	Provider        string
+	IssuerUrl       string
+	ClientID        apitypes.NullString
+	ClientSecretEnc []byte
+	Certificate     apitypes.NullString
+	Enabled         int64
+}
+
+func (q *Queries) upsertWorkspaceSSO(ctx context.Context, arg upsertWorkspaceSSOParams) error {
+	_, err := q.db.ExecContext(ctx, upsertWorkspaceSSO,
+		arg.WorkspaceID,
+		arg.Provider,
+		arg.IssuerUrl,
+		arg.ClientID,
+		arg.ClientSecretEnc,
+		arg.Certificate,
+		arg.Enabled,
+	)
+	return err
+}
@@ -0,0 +1,41 @@
+package store
+
+import (
+	"fmt"
+
+	"github.com/jonashiltl/openchangelog/internal/errs"
+)
+
+type QuotaUsage struct {
+	Changelogs int64
+	GHSources  int64
+	// Sum of the size of all assets of the workspace in bytes.
+	StorageBytes int64
+}
+
+// The limits of a plan, a limit of 0 means unlimited.
+type PlanLimits struct {
+	Changelogs   int64
+	GHSources    int64
+	StorageBytes int64
+}
+
+// Returns a bad request error naming the first limit the usage reached.
+// Should be called before creating a new resource.
+func (l PlanLimits) Check(usage QuotaUsage) error {
+	limits := []struct {
+		name         string
+		limit, usage int64
+	}{
+		{"changelogs", l.Changelogs, usage.Changelogs},
+		{"github sources", l.GHSources, usage.GHSources},
+		{"storage bytes", l.StorageBytes, usage.StorageBytes},
+	}
+
+	for _, q := range limits {
+		if q.limit > 0 && q.usage >= q.limit {
+			return errs.NewBadRequest(fmt.Errorf("plan limit of %d %s reached", q.limit, q.name))
+		}
+	}
+	return nil
+}
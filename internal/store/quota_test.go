@@ -0,0 +1,47 @@
+package store
+
+import "testing"
+
+func TestPlanLimitsCheck(t *testing.T) {
+	tables := []struct {
+		name      string
+		limits    PlanLimits
+		usage     QuotaUsage
+		expectErr bool
+	}{
+		{
+			name:   "unlimited",
+			limits: PlanLimits{},
+			usage:  QuotaUsage{Changelogs: 100, StorageBytes: 1 << 30},
+		},
+		{
+			name:   "below limit",
+			limits: PlanLimits{Changelogs: 3, GHSources: 1},
+			usage:  QuotaUsage{Changelogs: 2},
+		},
+		{
+			name:      "limit reached",
+			limits:    PlanLimits{Changelogs: 3},
+			usage:     QuotaUsage{Changelogs: 3},
+			expectErr: true,
+		},
+		{
+			name:      "storage exceeded",
+			limits:    PlanLimits{StorageBytes: 1024},
+			usage:     QuotaUsage{StorageBytes: 2048},
+			expectErr: true,
+		},
+	}
+
+	for _, table := range tables {
+		t.Run(table.name, func(t *testing.T) {
+			err := table.limits.Check(table.usage)
+			if table.expectErr && err == nil {
+				t.Error("expected to error but no error returned")
+			}
+			if !table.expectErr && err != nil {
+				t.Errorf("expected no error but got %s", err)
+			}
+		})
+	}
+}
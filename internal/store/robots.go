@@ -0,0 +1,51 @@
+package store
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/jonashiltl/openchangelog/internal/errs"
+)
+
+const (
+	max_robots_txt_size = 32 << 10
+	// served if the changelog has no robots.txt override
+	DefaultRobotsTxt = "User-agent: *\nAllow: /\n"
+)
+
+// Validates that content is a robots.txt file with "field: value" lines.
+// Allow and Disallow rules must follow a User-agent line.
+func validateRobotsTxt(content string) error {
+	if len(content) > max_robots_txt_size {
+		return errs.NewBadRequest(fmt.Errorf("robots.txt exceeds the maximum size of %d KB", max_robots_txt_size>>10))
+	}
+
+	hasUserAgent := false
+	scanner := bufio.NewScanner(strings.NewReader(content))
+	for n := 1; scanner.Scan(); n++ {
+		line, _, _ := strings.Cut(scanner.Text(), "#")
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+
+		field, _, ok := strings.Cut(line, ":")
+		if !ok {
+			return errs.NewBadRequest(fmt.Errorf("robots.txt line %d is missing a colon", n))
+		}
+		switch strings.ToLower(strings.TrimSpace(field)) {
+		case "user-agent":
+			hasUserAgent = true
+		case "allow", "disallow":
+			if !hasUserAgent {
+				return errs.NewBadRequest(fmt.Errorf("robots.txt line %d has a rule before any user-agent", n))
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return errs.NewBadRequest(errors.New("robots.txt is not valid"))
+	}
+	return nil
+}
@@ -0,0 +1,50 @@
+package store
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidateRobotsTxt(t *testing.T) {
+	tables := []struct {
+		name      string
+		content   string
+		expectErr bool
+	}{
+		{
+			name:    "default",
+			content: DefaultRobotsTxt,
+		},
+		{
+			name:    "disallow all with comments",
+			content: "# staging\nUser-agent: *\nDisallow: / # everything\n\nSitemap: https://example.com/sitemap.xml",
+		},
+		{
+			name:      "missing colon",
+			content:   "User-agent *\nDisallow: /",
+			expectErr: true,
+		},
+		{
+			name:      "rule before user-agent",
+			content:   "Disallow: /\nUser-agent: *",
+			expectErr: true,
+		},
+		{
+			name:      "too large",
+			content:   "User-agent: *\n" + strings.Repeat("Disallow: /a\n", 3000),
+			expectErr: true,
+		},
+	}
+
+	for _, table := range tables {
+		t.Run(table.name, func(t *testing.T) {
+			err := validateRobotsTxt(table.content)
+			if table.expectErr && err == nil {
+				t.Error("expected to error but no error returned")
+			}
+			if !table.expectErr && err != nil {
+				t.Errorf("expected no error but got %s", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,59 @@
+package store
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/jonashiltl/openchangelog/internal/errs"
+)
+
+const (
+	ScopeChangelogRead  = "changelog:read"
+	ScopeChangelogWrite = "changelog:write"
+	ScopeEntryRead      = "entry:read"
+	ScopeEntryWrite     = "entry:write"
+	ScopeAnalyticsRead  = "analytics:read"
+)
+
+// Granted to tokens which were never restricted to a set of scopes.
+var AllTokenScopes = []string{
+	ScopeChangelogRead,
+	ScopeChangelogWrite,
+	ScopeEntryRead,
+	ScopeEntryWrite,
+	ScopeAnalyticsRead,
+}
+
+// Validates the scopes and encodes them as json array, duplicates are removed.
+func encodeScopes(scopes []string) (string, error) {
+	if len(scopes) == 0 {
+		return "", errs.NewBadRequest(errors.New("token needs at least one scope"))
+	}
+
+	unique := make([]string, 0, len(scopes))
+	for _, s := range scopes {
+		if !slices.Contains(AllTokenScopes, s) {
+			return "", errs.NewBadRequest(fmt.Errorf("unknown scope %s", s))
+		}
+		if !slices.Contains(unique, s) {
+			unique = append(unique, s)
+		}
+	}
+
+	b, err := json.Marshal(unique)
+	if err != nil {
+		return "", errs.NewInternalError(err)
+	}
+	return string(b), nil
+}
+
+func decodeScopes(scopes string) ([]string, error) {
+	if scopes == "" {
+		return AllTokenScopes, nil
+	}
+	var res []string
+	err := json.Unmarshal([]byte(scopes), &res)
+	return res, err
+}
@@ -0,0 +1,69 @@
+package store
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestEncodeScopes(t *testing.T) {
+	tables := []struct {
+		name      string
+		scopes    []string
+		expected  []string
+		expectErr bool
+	}{
+		{
+			name:     "single",
+			scopes:   []string{ScopeChangelogRead},
+			expected: []string{ScopeChangelogRead},
+		},
+		{
+			name:     "duplicates",
+			scopes:   []string{ScopeEntryRead, ScopeEntryWrite, ScopeEntryRead},
+			expected: []string{ScopeEntryRead, ScopeEntryWrite},
+		},
+		{
+			name:      "empty",
+			scopes:    []string{},
+			expectErr: true,
+		},
+		{
+			name:      "unknown",
+			scopes:    []string{ScopeChangelogRead, "workspace:delete"},
+			expectErr: true,
+		},
+	}
+
+	for _, table := range tables {
+		t.Run(table.name, func(t *testing.T) {
+			encoded, err := encodeScopes(table.scopes)
+			if table.expectErr {
+				if err == nil {
+					t.Error("expected to error but no error returned")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("expected no error but got %s", err)
+			}
+
+			decoded, err := decodeScopes(encoded)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !slices.Equal(decoded, table.expected) {
+				t.Errorf("expected %v but got %v", table.expected, decoded)
+			}
+		})
+	}
+}
+
+func TestDecodeUnrestrictedScopes(t *testing.T) {
+	scopes, err := decodeScopes("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(scopes, AllTokenScopes) {
+		t.Errorf("expected %v but got %v", AllTokenScopes, scopes)
+	}
+}
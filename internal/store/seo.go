@@ -0,0 +1,35 @@
+package store
+
+// Rates how well a changelog is prepared to be found by search engines and shared on social media.
+type SEOScore struct {
+	// Between 0 and 100.
+	Score int
+	// Describes the checks which failed, empty if the score is 100.
+	Reasons []string
+}
+
+func (r getChangelogSEOSignalsRow) toSEOScore() SEOScore {
+	checks := []struct {
+		ok     bool
+		reason string
+	}{
+		// the title and subtitle are used as meta title and description
+		{r.Title.V() != "", "missing meta title, set a title"},
+		{r.Subtitle.V() != "", "missing meta description, set a subtitle"},
+		{r.HasOgImage == 1, "missing open graph image"},
+		// without a custom domain the changelog is only reachable through the shared host
+		{r.Domain.V() != "", "missing canonical url, set a custom domain"},
+		// entries are loaded from the source, so a changelog without one has none
+		{r.SourceID.V() != "", "no entries, connect a source"},
+	}
+
+	score := SEOScore{Reasons: []string{}}
+	for _, c := range checks {
+		if c.ok {
+			score.Score += 100 / len(checks)
+		} else {
+			score.Reasons = append(score.Reasons, c.reason)
+		}
+	}
+	return score
+}
@@ -0,0 +1,57 @@
+package store
+
+import (
+	"testing"
+
+	"github.com/jonashiltl/openchangelog/apitypes"
+)
+
+func TestToSEOScore(t *testing.T) {
+	tables := []struct {
+		name    string
+		row     getChangelogSEOSignalsRow
+		score   int
+		reasons int
+	}{
+		{
+			name:    "empty",
+			row:     getChangelogSEOSignalsRow{},
+			score:   0,
+			reasons: 5,
+		},
+		{
+			name: "complete",
+			row: getChangelogSEOSignalsRow{
+				Title:      apitypes.NewString("Acme Changelog"),
+				Subtitle:   apitypes.NewString("All the latest updates"),
+				Domain:     apitypes.NewString("changelog.acme.com"),
+				SourceID:   apitypes.NewString("gh_123"),
+				HasOgImage: 1,
+			},
+			score:   100,
+			reasons: 0,
+		},
+		{
+			name: "missing og image and domain",
+			row: getChangelogSEOSignalsRow{
+				Title:    apitypes.NewString("Acme Changelog"),
+				Subtitle: apitypes.NewString("All the latest updates"),
+				SourceID: apitypes.NewString("gh_123"),
+			},
+			score:   60,
+			reasons: 2,
+		},
+	}
+
+	for _, table := range tables {
+		t.Run(table.name, func(t *testing.T) {
+			s := table.row.toSEOScore()
+			if s.Score != table.score {
+				t.Errorf("expected score %d but got %d", table.score, s.Score)
+			}
+			if len(s.Reasons) != table.reasons {
+				t.Errorf("expected %d reasons but got %v", table.reasons, s.Reasons)
+			}
+		})
+	}
+}
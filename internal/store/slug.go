@@ -0,0 +1,47 @@
+package store
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/gosimple/slug"
+	"github.com/jonashiltl/openchangelog/internal/errs"
+)
+
+const (
+	max_slug_length = 64
+	// used if the title of the changelog has no characters to build a slug from
+	default_slug = "changelog"
+)
+
+// Kebab-cases the title to a slug of at most max_slug_length characters.
+// Returns an empty string if the title has no letters or digits.
+func slugify(title string) string {
+	return truncateSlug(slug.Make(title), max_slug_length)
+}
+
+// Cuts the slug to at most n characters, without leaving a trailing dash.
+func truncateSlug(slug string, n int) string {
+	if len(slug) <= n {
+		return slug
+	}
+	return strings.TrimRight(slug[:n], "-")
+}
+
+// Appends the suffix to the slug, shortening the slug so the result stays within max_slug_length.
+func suffixSlug(slug string, suffix int) string {
+	s := fmt.Sprintf("-%d", suffix)
+	return truncateSlug(slug, max_slug_length-len(s)) + s
+}
+
+// Validates that the slug is already kebab-cased, like slugs created from a title.
+func validateSlug(slug string) error {
+	if len(slug) > max_slug_length {
+		return errs.NewBadRequest(fmt.Errorf("slug can't be longer than %d characters", max_slug_length))
+	}
+	if slug == "" || slugify(slug) != slug {
+		return errs.NewBadRequest(errors.New("slug may only contain lowercase letters, digits and single dashes"))
+	}
+	return nil
+}
@@ -0,0 +1,77 @@
+package store
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSlugify(t *testing.T) {
+	tables := []struct {
+		title    string
+		expected string
+	}{
+		{
+			title:    "Acme Changelog",
+			expected: "acme-changelog",
+		},
+		{
+			title:    "  What's new in v2.0?! ",
+			expected: "whats-new-in-v2-0",
+		},
+		{
+			title:    "Über Änderungen",
+			expected: "uber-anderungen",
+		},
+		{
+			title:    "!!!",
+			expected: "",
+		},
+		{
+			title:    strings.Repeat("a", 63) + " b",
+			expected: strings.Repeat("a", 63),
+		},
+	}
+
+	for _, table := range tables {
+		t.Run(table.title, func(t *testing.T) {
+			slug := slugify(table.title)
+			if slug != table.expected {
+				t.Errorf("expected %s to equal %s", slug, table.expected)
+			}
+		})
+	}
+}
+
+func TestSuffixSlug(t *testing.T) {
+	slug := suffixSlug(strings.Repeat("a", max_slug_length), 12)
+	if len(slug) != max_slug_length || !strings.HasSuffix(slug, "a-12") {
+		t.Errorf("expected %s to be %d characters and end with a-12", slug, max_slug_length)
+	}
+}
+
+func TestValidateSlug(t *testing.T) {
+	tables := []struct {
+		slug  string
+		valid bool
+	}{
+		{slug: "acme-changelog", valid: true},
+		{slug: "v2", valid: true},
+		{slug: "", valid: false},
+		{slug: "Acme", valid: false},
+		{slug: "acme--changelog", valid: false},
+		{slug: "-acme", valid: false},
+		{slug: strings.Repeat("a", max_slug_length+1), valid: false},
+	}
+
+	for _, table := range tables {
+		t.Run(table.slug, func(t *testing.T) {
+			err := validateSlug(table.slug)
+			if table.valid && err != nil {
+				t.Errorf("expected %s to be valid, got %s", table.slug, err)
+			}
+			if !table.valid && err == nil {
+				t.Errorf("expected %s to be invalid", table.slug)
+			}
+		})
+	}
+}
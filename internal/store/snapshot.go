@@ -0,0 +1,80 @@
+package store
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/jonashiltl/openchangelog/apitypes"
+)
+
+const (
+	snapshot_prefix        = "snap"
+	default_snapshot_limit = 20
+	max_snapshot_limit     = 100
+)
+
+// Falls back to the default for a missing limit and caps it at the maximum.
+func clampSnapshotLimit(limit int) int {
+	if limit <= 0 {
+		return default_snapshot_limit
+	}
+	return min(limit, max_snapshot_limit)
+}
+
+// A point-in-time copy of a changelog's metadata.
+type ChangelogSnapshot struct {
+	ID          string
+	WorkspaceID WorkspaceID
+	ChangelogID ChangelogID
+	Changelog   Changelog
+	CreatedAt   time.Time
+}
+
+func (s changelogSnapshot) toExported() (ChangelogSnapshot, error) {
+	var cl Changelog
+	err := json.Unmarshal(s.Snapshot, &cl)
+	if err != nil {
+		return ChangelogSnapshot{}, err
+	}
+
+	return ChangelogSnapshot{
+		ID:          s.ID,
+		WorkspaceID: WorkspaceID(s.WorkspaceID),
+		ChangelogID: ChangelogID(s.ChangelogID),
+		Changelog:   cl,
+		CreatedAt:   time.Unix(s.CreatedAt, 0),
+	}, nil
+}
+
+func boolPtr(b bool) *bool {
+	return &b
+}
+
+// Returns the args to restore the branding and display settings of cl.
+// The subdomain, domain, slug, source and protection settings are not restored,
+// as they might conflict with other changelogs or lock out readers.
+func restoreArgs(cl Changelog) UpdateChangelogArgs {
+	return UpdateChangelogArgs{
+		Title:         orNull(cl.Title),
+		Subtitle:      orNull(cl.Subtitle),
+		LogoSrc:       orNull(cl.LogoSrc),
+		LogoLink:      orNull(cl.LogoLink),
+		LogoAlt:       orNull(cl.LogoAlt),
+		LogoHeight:    orNull(cl.LogoHeight),
+		LogoWidth:     orNull(cl.LogoWidth),
+		ColorScheme:   cl.ColorScheme,
+		HidePoweredBy: boolPtr(cl.HidePoweredBy),
+		Analytics:     boolPtr(cl.Analytics),
+		Searchable:    boolPtr(cl.Searchable),
+		FaviconSrc:    orNull(cl.FaviconSrc),
+		SocialLinks:   &cl.SocialLinks,
+	}
+}
+
+// UpdateChangelog skips zero values, so empty fields need to be explicitly nulled.
+func orNull(s apitypes.NullString) apitypes.NullString {
+	if s.IsValid() {
+		return s
+	}
+	return apitypes.NewNullString()
+}
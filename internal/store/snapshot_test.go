@@ -0,0 +1,123 @@
+package store
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/jonashiltl/openchangelog/apitypes"
+)
+
+func TestRestoreArgs(t *testing.T) {
+	tables := []struct {
+		name string
+		cl   Changelog
+	}{
+		{
+			name: "all fields",
+			cl: Changelog{
+				Title:      apitypes.NewString("Acme"),
+				Subtitle:   apitypes.NewString("What's new at Acme"),
+				LogoSrc:    apitypes.NewString("https://acme.com/logo.png"),
+				LogoLink:   apitypes.NewString("https://acme.com"),
+				LogoAlt:    apitypes.NewString("Acme logo"),
+				LogoHeight: apitypes.NewString("30px"),
+				LogoWidth:  apitypes.NewString("120px"),
+				FaviconSrc: apitypes.NewString("/assets/ast_123"),
+				SocialLinks: SocialLinks{
+					Twitter: "https://x.com/acme",
+					GitHub:  "https://github.com/acme",
+				},
+				ColorScheme:   Dark,
+				HidePoweredBy: true,
+				Analytics:     true,
+				Searchable:    true,
+			},
+		},
+		{
+			name: "empty fields",
+			cl: Changelog{
+				ColorScheme: System,
+			},
+		},
+	}
+
+	for _, table := range tables {
+		t.Run(table.name, func(t *testing.T) {
+			b, err := json.Marshal(table.cl)
+			if err != nil {
+				t.Fatal(err)
+			}
+			snapshot, err := changelogSnapshot{Snapshot: b}.toExported()
+			if err != nil {
+				t.Fatal(err)
+			}
+			args := restoreArgs(snapshot.Changelog)
+
+			strs := []struct {
+				field    string
+				arg      apitypes.NullString
+				expected apitypes.NullString
+			}{
+				{"Title", args.Title, table.cl.Title},
+				{"Subtitle", args.Subtitle, table.cl.Subtitle},
+				{"LogoSrc", args.LogoSrc, table.cl.LogoSrc},
+				{"LogoLink", args.LogoLink, table.cl.LogoLink},
+				{"LogoAlt", args.LogoAlt, table.cl.LogoAlt},
+				{"LogoHeight", args.LogoHeight, table.cl.LogoHeight},
+				{"LogoWidth", args.LogoWidth, table.cl.LogoWidth},
+				{"FaviconSrc", args.FaviconSrc, table.cl.FaviconSrc},
+			}
+			for _, s := range strs {
+				if s.arg.V() != s.expected.V() {
+					t.Errorf("expected %s %s to equal %s", s.field, s.arg.V(), s.expected.V())
+				}
+				// empty fields must be nulled, otherwise UpdateChangelog keeps the current value
+				if !s.arg.IsValid() && !s.arg.IsNull() {
+					t.Errorf("expected empty %s to be null", s.field)
+				}
+			}
+
+			if args.ColorScheme != table.cl.ColorScheme {
+				t.Errorf("expected %s to equal %s", args.ColorScheme, table.cl.ColorScheme)
+			}
+			if args.SocialLinks == nil || *args.SocialLinks != table.cl.SocialLinks {
+				t.Errorf("expected %v to equal %v", args.SocialLinks, table.cl.SocialLinks)
+			}
+
+			bools := []struct {
+				field    string
+				arg      *bool
+				expected bool
+			}{
+				{"HidePoweredBy", args.HidePoweredBy, table.cl.HidePoweredBy},
+				{"Analytics", args.Analytics, table.cl.Analytics},
+				{"Searchable", args.Searchable, table.cl.Searchable},
+			}
+			for _, b := range bools {
+				if b.arg == nil || *b.arg != b.expected {
+					t.Errorf("expected %s to be %t", b.field, b.expected)
+				}
+			}
+		})
+	}
+}
+
+func TestClampSnapshotLimit(t *testing.T) {
+	tables := []struct {
+		limit    int
+		expected int
+	}{
+		{limit: -1, expected: default_snapshot_limit},
+		{limit: 0, expected: default_snapshot_limit},
+		{limit: 5, expected: 5},
+		{limit: max_snapshot_limit, expected: max_snapshot_limit},
+		{limit: 10000, expected: max_snapshot_limit},
+	}
+
+	for _, table := range tables {
+		got := clampSnapshotLimit(table.limit)
+		if got != table.expected {
+			t.Errorf("expected %d to equal %d", got, table.expected)
+		}
+	}
+}
@@ -0,0 +1,62 @@
+package store
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+
+	"github.com/jonashiltl/openchangelog/apitypes"
+	"github.com/jonashiltl/openchangelog/internal/errs"
+)
+
+// Links shown as icons in the footer of the changelog, empty links are hidden.
+type SocialLinks struct {
+	Twitter  string `json:"twitter,omitempty"`
+	LinkedIn string `json:"linkedin,omitempty"`
+	GitHub   string `json:"github,omitempty"`
+	Website  string `json:"website,omitempty"`
+}
+
+func (l SocialLinks) validate() error {
+	links := []struct {
+		name, link string
+	}{
+		{"twitter", l.Twitter},
+		{"linkedin", l.LinkedIn},
+		{"github", l.GitHub},
+		{"website", l.Website},
+	}
+
+	for _, sl := range links {
+		if sl.link == "" {
+			continue
+		}
+		u, err := url.Parse(sl.link)
+		if err != nil || u.Scheme != "https" || u.Host == "" {
+			return errs.NewBadRequest(fmt.Errorf("%s link must be a https url", sl.name))
+		}
+	}
+	return nil
+}
+
+func (l SocialLinks) toNullString() (apitypes.NullString, error) {
+	if l == (SocialLinks{}) {
+		return apitypes.NewNullString(), nil
+	}
+	b, err := json.Marshal(l)
+	if err != nil {
+		return apitypes.NullString{}, err
+	}
+	return apitypes.NewString(string(b)), nil
+}
+
+// Returns empty links if ns is null or not valid json.
+func parseSocialLinks(ns apitypes.NullString) SocialLinks {
+	var l SocialLinks
+	if ns.IsValid() {
+		if err := json.Unmarshal([]byte(ns.V()), &l); err != nil {
+			return SocialLinks{}
+		}
+	}
+	return l
+}
@@ -0,0 +1,45 @@
+package store
+
+import "testing"
+
+func TestSocialLinksValidate(t *testing.T) {
+	tables := []struct {
+		name      string
+		links     SocialLinks
+		expectErr bool
+	}{
+		{
+			name:  "empty",
+			links: SocialLinks{},
+		},
+		{
+			name: "https links",
+			links: SocialLinks{
+				Twitter: "https://x.com/openchangelog",
+				GitHub:  "https://github.com/JonasHiltl/openchangelog",
+			},
+		},
+		{
+			name:      "http link",
+			links:     SocialLinks{Website: "http://openchangelog.com"},
+			expectErr: true,
+		},
+		{
+			name:      "missing scheme",
+			links:     SocialLinks{LinkedIn: "linkedin.com/company/openchangelog"},
+			expectErr: true,
+		},
+	}
+
+	for _, table := range tables {
+		t.Run(table.name, func(t *testing.T) {
+			err := table.links.validate()
+			if table.expectErr && err == nil {
+				t.Error("expected to error but no error returned")
+			}
+			if !table.expectErr && err != nil {
+				t.Errorf("expected no error but got %s", err)
+			}
+		})
+	}
+}
@@ -2,13 +2,22 @@ package store
 
 import (
 	"context"
+	"crypto/sha256"
 	"database/sql"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
 	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/jonashiltl/openchangelog/apitypes"
 	"github.com/jonashiltl/openchangelog/internal/errs"
+	"github.com/jonashiltl/openchangelog/internal/xlog"
 	_ "github.com/mattn/go-sqlite3"
 
 	"github.com/guregu/null/v5"
@@ -20,6 +29,7 @@ func (cl changelog) toExported(source changelogSource) Changelog {
 		ID:            ChangelogID(cl.ID),
 		Subdomain:     Subdomain(cl.Subdomain),
 		Domain:        Domain(cl.Domain),
+		Slug:          cl.Slug.V(),
 		Title:         cl.Title,
 		Subtitle:      cl.Subtitle,
 		LogoSrc:       cl.LogoSrc,
@@ -27,6 +37,8 @@ func (cl changelog) toExported(source changelogSource) Changelog {
 		LogoAlt:       cl.LogoAlt,
 		LogoHeight:    cl.LogoHeight,
 		LogoWidth:     cl.LogoWidth,
+		FaviconSrc:    cl.FaviconSrc,
+		SocialLinks:   parseSocialLinks(cl.SocialLinks),
 		ColorScheme:   cl.ColorScheme,
 		HidePoweredBy: cl.HidePoweredBy == 1,
 		Protected:     cl.Protected == 1,
@@ -58,10 +70,36 @@ func (gh ghSource) toExported() GHSource {
 		Repo:           gh.Repo,
 		Path:           gh.Path,
 		InstallationID: gh.InstallationID,
+		LastFetchedAt:  time.Unix(gh.LastFetchedAt, 0),
+		FetchInterval:  time.Duration(gh.FetchIntervalSeconds) * time.Second,
+		PrivateKeyID:   gh.PrivateKeyID.V(),
 	}
 }
 
-func NewSQLiteStore(conn string) (Store, error) {
+func (h ghSourceHealth) toExported() GHSourceHealth {
+	return GHSourceHealth{
+		WorkspaceID:         WorkspaceID(h.WorkspaceID),
+		GHSourceID:          GHSourceID(h.GhSourceID),
+		LastError:           h.LastError.V(),
+		ConsecutiveFailures: int(h.ConsecutiveFailures),
+		LastCheckedAt:       time.Unix(h.LastCheckedAt, 0),
+	}
+}
+
+type SQLiteOptions struct {
+	// How long the list of all subdomains is cached, 0 disables caching.
+	SubdomainCacheTTL time.Duration
+	// 32 byte key used to encrypt secrets like GitHub App private keys.
+	EncryptionKey []byte
+	// Host the custom domains of changelogs must point to with a CNAME record.
+	CNAMETarget string
+	// Url the subdomains of changelogs are served under, e.g. https://openchangelog.com
+	BaseURL string
+	// How long new workspace tokens are valid, 0 creates tokens which never expire.
+	TokenTTL time.Duration
+}
+
+func NewSQLiteStore(conn string, opts SQLiteOptions) (Store, error) {
 	db, err := sql.Open("sqlite3", conn)
 	if err != nil {
 		return nil, err
@@ -70,45 +108,111 @@ func NewSQLiteStore(conn string) (Store, error) {
 	q := New(db)
 
 	return &sqlite{
-		q:  q,
-		db: db,
+		q:    q,
+		db:   db,
+		opts: opts,
 	}, nil
 }
 
 type sqlite struct {
-	q  *Queries
-	db *sql.DB
+	q    *Queries
+	db   *sql.DB
+	opts SQLiteOptions
+
+	subdomainsMu        sync.Mutex
+	subdomains          []Subdomain
+	subdomainsExpiresAt time.Time
 }
 
+// how often creating a changelog is retried if another changelog took its slug in the meantime
+const max_slug_retries = 5
+
 func (s *sqlite) CreateChangelog(ctx context.Context, cl Changelog) (Changelog, error) {
-	c, err := s.q.createChangelog(ctx, createChangelogParams{
-		ID:            cl.ID.String(),
-		WorkspaceID:   cl.WorkspaceID.String(),
-		Subdomain:     cl.Subdomain.String(),
-		Domain:        cl.Domain.NullString(),
-		Title:         cl.Title,
-		Subtitle:      cl.Subtitle,
-		LogoSrc:       cl.LogoSrc,
-		LogoLink:      cl.LogoLink,
-		LogoAlt:       cl.LogoAlt,
-		LogoHeight:    cl.LogoHeight,
-		LogoWidth:     cl.LogoWidth,
-		ColorScheme:   cl.ColorScheme,
-		HidePoweredBy: boolToInt(cl.HidePoweredBy),
-		Protected:     boolToInt(cl.Protected),
-		Analytics:     boolToInt(cl.Analytics),
-		Searchable:    boolToInt(cl.Searchable),
-		PasswordHash:  apitypes.NewString(cl.PasswordHash),
-	})
+	base := slugify(cl.Title.V())
+	if base == "" {
+		base = default_slug
+	}
+	slug, next, err := s.newChangelogSlug(ctx, base)
+	if err != nil {
+		return Changelog{}, err
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return Changelog{}, err
+	}
+	defer tx.Rollback()
+	q := s.q.WithTx(tx)
+
+	var c changelog
+	for retries := 0; ; retries++ {
+		c, err = q.createChangelog(ctx, createChangelogParams{
+			ID:            cl.ID.String(),
+			WorkspaceID:   cl.WorkspaceID.String(),
+			Subdomain:     cl.Subdomain.String(),
+			Domain:        cl.Domain.NullString(),
+			Title:         cl.Title,
+			Subtitle:      cl.Subtitle,
+			LogoSrc:       cl.LogoSrc,
+			LogoLink:      cl.LogoLink,
+			LogoAlt:       cl.LogoAlt,
+			LogoHeight:    cl.LogoHeight,
+			LogoWidth:     cl.LogoWidth,
+			ColorScheme:   cl.ColorScheme,
+			HidePoweredBy: boolToInt(cl.HidePoweredBy),
+			Protected:     boolToInt(cl.Protected),
+			Analytics:     boolToInt(cl.Analytics),
+			Searchable:    boolToInt(cl.Searchable),
+			PasswordHash:  apitypes.NewString(cl.PasswordHash),
+			Slug:          apitypes.NewString(slug),
+		})
+		// the slug was free when it was checked, but a concurrent create can take it before the insert
+		if err != nil && retries < max_slug_retries &&
+			strings.Contains(err.Error(), "UNIQUE constraint failed: changelogs.slug") {
+			slug = suffixSlug(base, next)
+			next++
+			continue
+		}
+		break
+	}
 	if err != nil {
 		return Changelog{}, formatUnqueConstraint(err)
 	}
 
+	err = syncPrimaryCustomDomain(ctx, q, cl.WorkspaceID, cl.ID, apitypes.NullString{}, cl.Domain.NullString())
+	if err != nil {
+		return Changelog{}, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return Changelog{}, err
+	}
+	s.invalidateSubdomains()
+
 	// TODO get source
 	return c.toExported(changelogSource{}), nil
 }
 
+// Returns the first slug of base which isn't used by any other changelog yet,
+// by suffixing it with -2, -3, ... on collision.
+// Also returns the suffix to try next if the slug is taken before it's inserted.
+func (s *sqlite) newChangelogSlug(ctx context.Context, base string) (string, int, error) {
+	slug := base
+	for i := 2; ; i++ {
+		exists, err := s.q.changelogSlugExists(ctx, apitypes.NewString(slug))
+		if err != nil {
+			return "", 0, err
+		}
+		if exists == 0 {
+			return slug, i, nil
+		}
+		slug = suffixSlug(base, i)
+	}
+}
+
 var errNoChangelog = errs.NewError(errs.ErrNotFound, errors.New("changelog not found"))
+var errNoWorkspace = errs.NewError(errs.ErrNotFound, errors.New("workspace not found"))
 
 func (s *sqlite) GetChangelog(ctx context.Context, wID WorkspaceID, cID ChangelogID) (Changelog, error) {
 	cl, err := s.q.getChangelog(ctx, getChangelogParams{
@@ -132,6 +236,9 @@ func (s *sqlite) GetChangelogByDomainOrSubdomain(ctx context.Context, domain Dom
 	})
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
+			if domain.String() != "" {
+				return s.GetChangelogByCustomDomain(ctx, domain)
+			}
 			return Changelog{}, errNoChangelog
 		}
 		return Changelog{}, err
@@ -140,237 +247,2947 @@ func (s *sqlite) GetChangelogByDomainOrSubdomain(ctx context.Context, domain Dom
 	return cl.changelog.toExported(cl.ChangelogSource), nil
 }
 
-func (s *sqlite) ListChangelogs(ctx context.Context, wID WorkspaceID) ([]Changelog, error) {
-	cls, err := s.q.listChangelogs(ctx, wID.String())
+func (s *sqlite) GetChangelogBrandKit(ctx context.Context, domain Domain, subdomain Subdomain) (BrandKit, error) {
+	row, err := s.q.getChangelogBrandKit(ctx, getChangelogBrandKitParams{
+		Domain:    domain.NullString(),
+		Subdomain: subdomain.String(),
+	})
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
-			return make([]Changelog, 0), nil
+			return BrandKit{}, errNoChangelog
 		}
-		return nil, err
+		return BrandKit{}, err
 	}
 
-	res := make([]Changelog, len(cls))
-	for i, cl := range cls {
-		res[i] = cl.changelog.toExported(cl.ChangelogSource)
-	}
-	return res, nil
+	return BrandKit{
+		LogoSrc:     row.LogoSrc,
+		LogoLink:    row.LogoLink,
+		LogoAlt:     row.LogoAlt,
+		LogoHeight:  row.LogoHeight,
+		LogoWidth:   row.LogoWidth,
+		ColorScheme: row.ColorScheme,
+		FaviconSrc:  row.FaviconSrc,
+		SocialLinks: parseSocialLinks(row.SocialLinks),
+	}, nil
 }
 
-// dereferences b to it's int representation
-func saveDerefToInt(b *bool) int64 {
-	if b != nil && *b {
-		return 1
+func (s *sqlite) GetChangelogBySlug(ctx context.Context, wID WorkspaceID, slug string) (Changelog, error) {
+	cl, err := s.q.getChangelogBySlug(ctx, getChangelogBySlugParams{
+		WorkspaceID: wID.String(),
+		Slug:        apitypes.NewString(slug),
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return Changelog{}, errNoChangelog
+		}
+		return Changelog{}, err
 	}
-	return 0
-}
 
-// Returns 1 if b is true, otherwise 2
-func boolToInt(b bool) int64 {
-	var i int64
-	if b {
-		i = 1
-	}
-	return i
+	return cl.changelog.toExported(cl.ChangelogSource), nil
 }
 
-func (s *sqlite) UpdateChangelog(ctx context.Context, wID WorkspaceID, cID ChangelogID, args UpdateChangelogArgs) (Changelog, error) {
-	// does not update string fields if they are zero value
-	_, err := s.q.updateChangelog(ctx, updateChangelogParams{
-		ID:          cID.String(),
-		WorkspaceID: wID.String(),
-		Subdomain:   args.Subdomain,
-		HidePoweredBy: sql.NullInt64{ // update if HidePoweredBy != nil
-			Int64: saveDerefToInt(args.HidePoweredBy),
-			Valid: args.HidePoweredBy != nil,
-		},
-		ColorScheme:    args.ColorScheme,
-		SetColorScheme: int(args.ColorScheme) != 0,
-		Title:          args.Title,
-		SetTitle:       !args.Title.IsZero(),
-		Subtitle:       args.Subtitle,
-		SetSubtitle:    !args.Subtitle.IsZero(),
-		Domain:         args.Domain.NullString(),
-		SetDomain:      !args.Domain.NullString().IsZero(),
-		LogoSrc:        args.LogoSrc,
-		SetLogoSrc:     !args.LogoSrc.IsZero(),
-		LogoLink:       args.LogoLink,
-		SetLogoLink:    !args.LogoLink.IsZero(),
-		LogoAlt:        args.LogoAlt,
-		SetLogoAlt:     !args.LogoAlt.IsZero(),
-		LogoHeight:     args.LogoHeight,
-		SetLogoHeight:  !args.LogoHeight.IsZero(),
-		LogoWidth:      args.LogoWidth,
-		SetLogoWidth:   !args.LogoWidth.IsZero(),
-		Protected: sql.NullInt64{ // update if args.Protected is defined
-			Int64: saveDerefToInt(args.Protected),
-			Valid: args.Protected != nil,
-		},
-		Analytics: sql.NullInt64{ // update if args.Analytics is defined
-			Int64: saveDerefToInt(args.Analytics),
-			Valid: args.Analytics != nil,
-		},
-		Searchable: sql.NullInt64{ // update if args.Searchable is defined
-			Int64: saveDerefToInt(args.Searchable),
-			Valid: args.Searchable != nil,
-		},
-		PasswordHash:    args.PasswordHash,
-		SetPasswordHash: !args.PasswordHash.IsZero(),
+func (s *sqlite) GetChangelogBySubdomainOrSlug(ctx context.Context, subdomain Subdomain, slug string) (Changelog, error) {
+	cl, err := s.q.getChangelogBySubdomainOrSlug(ctx, getChangelogBySubdomainOrSlugParams{
+		Subdomain: subdomain.String(),
+		Slug:      apitypes.NewString(slug),
 	})
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return Changelog{}, errNoChangelog
 		}
-		return Changelog{}, formatUnqueConstraint(err)
+		return Changelog{}, err
 	}
-	return s.GetChangelog(ctx, wID, cID)
-}
 
-// If err is a unique constraint error, return humanized error message.
-// Otherwise return err
-func formatUnqueConstraint(err error) error {
-	if strings.Contains(err.Error(), "UNIQUE constraint failed: changelogs.subdomain") {
-		return errs.NewBadRequest(errors.New("subdomain already taken, please try again with a different one"))
-	}
-	if strings.Contains(err.Error(), "UNIQUE constraint failed: changelogs.domain") {
-		return errs.NewBadRequest(errors.New("domain already taken, please try again with a different one"))
-	}
-	return err
+	return cl.changelog.toExported(cl.ChangelogSource), nil
 }
 
-func (s *sqlite) DeleteChangelog(ctx context.Context, wID WorkspaceID, cID ChangelogID) error {
-	return s.q.deleteChangelog(ctx, deleteChangelogParams{
-		WorkspaceID: wID.String(),
-		ID:          cID.String(),
-	})
-}
+const custom_domain_prefix = "cd"
 
-func (s *sqlite) SetChangelogGHSource(ctx context.Context, wID WorkspaceID, cID ChangelogID, ghID GHSourceID) error {
-	return s.q.setChangelogSource(ctx, setChangelogSourceParams{
-		SourceID:    apitypes.NewString(ghID.String()),
-		WorkspaceID: wID.String(),
-		ID:          cID.String(),
-	})
+func (d changelogCustomDomain) toExported() CustomDomain {
+	return CustomDomain{
+		ID:          d.ID,
+		WorkspaceID: WorkspaceID(d.WorkspaceID),
+		ChangelogID: ChangelogID(d.ChangelogID),
+		Domain:      Domain(apitypes.NewString(d.Domain)),
+		Verified:    d.Verified == 1,
+		CreatedAt:   time.Unix(d.CreatedAt, 0),
+	}
 }
 
-func (s *sqlite) DeleteChangelogSource(ctx context.Context, wID WorkspaceID, cID ChangelogID) error {
-	return s.q.deleteChangelogSource(ctx, deleteChangelogSourceParams{
-		WorkspaceID: wID.String(),
-		ID:          cID.String(),
-	})
-}
+func (s *sqlite) AddCustomDomain(ctx context.Context, wID WorkspaceID, cID ChangelogID, domain string) (CustomDomain, error) {
+	parsed, err := ParseDomain(domain)
+	if err != nil {
+		return CustomDomain{}, err
+	}
 
-func (s *sqlite) SaveWorkspace(ctx context.Context, ws Workspace) (Workspace, error) {
-	tx, err := s.db.Begin()
+	tx, err := s.db.BeginTx(ctx, nil)
 	if err != nil {
-		return Workspace{}, err
+		return CustomDomain{}, err
 	}
 	defer tx.Rollback()
 	q := s.q.WithTx(tx)
 
-	c, err := q.saveWorkspace(ctx, saveWorkspaceParams{
-		ID:   ws.ID.String(),
-		Name: ws.Name,
+	// the primary domain of another changelog might not be in changelog_custom_domains if it was never verified
+	taken, err := q.changelogDomainTaken(ctx, changelogDomainTakenParams{
+		Domain:      parsed.NullString(),
+		WorkspaceID: wID.String(),
+		ChangelogID: cID.String(),
 	})
 	if err != nil {
-		return Workspace{}, err
+		return CustomDomain{}, err
+	}
+	if taken == 1 {
+		return CustomDomain{}, errDomainTaken
 	}
 
-	if ws.Token != "" {
-		err := q.createToken(ctx, createTokenParams{
-			Key:         ws.Token.String(),
-			WorkspaceID: ws.ID.String(),
-		})
-		if err != nil {
-			return Workspace{}, err
+	row, err := q.createCustomDomain(ctx, createCustomDomainParams{
+		ID:          newID(custom_domain_prefix),
+		WorkspaceID: wID.String(),
+		ChangelogID: cID.String(),
+		Domain:      parsed.String(),
+	})
+	if err != nil {
+		if strings.Contains(err.Error(), "FOREIGN KEY constraint failed") {
+			return CustomDomain{}, errNoChangelog
 		}
+		return CustomDomain{}, formatUnqueConstraint(err)
 	}
 
 	err = tx.Commit()
 	if err != nil {
-		return Workspace{}, err
+		return CustomDomain{}, err
 	}
+	return row.toExported(), nil
+}
 
-	return Workspace{
-		ID:    WorkspaceID(c.ID),
-		Name:  c.Name,
-		Token: ws.Token,
-	}, nil
+func (s *sqlite) RemoveCustomDomain(ctx context.Context, wID WorkspaceID, cID ChangelogID, domainID string) error {
+	n, err := s.q.deleteCustomDomain(ctx, deleteCustomDomainParams{
+		WorkspaceID: wID.String(),
+		ChangelogID: cID.String(),
+		ID:          domainID,
+	})
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return errs.NewError(errs.ErrNotFound, errors.New("custom domain not found"))
+	}
+	return nil
 }
 
-func (s *sqlite) GetWorkspace(ctx context.Context, wID WorkspaceID) (Workspace, error) {
-	row, err := s.q.getWorkspace(ctx, wID.String())
+func (s *sqlite) SetCustomDomainVerified(ctx context.Context, wID WorkspaceID, cID ChangelogID, domainID string, verified bool) error {
+	n, err := s.q.setCustomDomainVerified(ctx, setCustomDomainVerifiedParams{
+		Verified:    boolToInt(verified),
+		WorkspaceID: wID.String(),
+		ChangelogID: cID.String(),
+		ID:          domainID,
+	})
 	if err != nil {
-		return Workspace{}, err
+		return err
 	}
-	return Workspace{
-		ID:    WorkspaceID(row.workspace.ID),
-		Name:  row.workspace.Name,
-		Token: Token(row.token.Key),
-	}, nil
+	if n == 0 {
+		return errs.NewError(errs.ErrNotFound, errors.New("custom domain not found"))
+	}
+	return nil
 }
 
-func (s *sqlite) GetWorkspaceIDByToken(ctx context.Context, token string) (WorkspaceID, error) {
-	row, err := s.q.getToken(ctx, token)
+func (s *sqlite) ListCustomDomains(ctx context.Context, wID WorkspaceID, cID ChangelogID) ([]CustomDomain, error) {
+	rows, err := s.q.listCustomDomains(ctx, listCustomDomainsParams{
+		WorkspaceID: wID.String(),
+		ChangelogID: cID.String(),
+	})
 	if err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
-			return "", errs.NewError(errs.ErrUnauthorized, errors.New("invalid bearer token"))
-		}
-		return "", err
+		return nil, err
 	}
-	return WorkspaceID(row.WorkspaceID), nil
+
+	res := make([]CustomDomain, len(rows))
+	for i, r := range rows {
+		res[i] = r.toExported()
+	}
+	return res, nil
 }
 
-func (s *sqlite) DeleteWorkspace(ctx context.Context, wID WorkspaceID) error {
-	return s.q.deleteWorkspace(ctx, wID.String())
+func (s *sqlite) GetChangelogByCustomDomain(ctx context.Context, domain Domain) (Changelog, error) {
+	cl, err := s.q.getChangelogByCustomDomain(ctx, domain.String())
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return Changelog{}, errNoChangelog
+		}
+		return Changelog{}, err
+	}
+	return cl.changelog.toExported(cl.ChangelogSource), nil
 }
 
-func (s *sqlite) CreateGHSource(ctx context.Context, gh GHSource) (GHSource, error) {
-	row, err := s.q.createGHSource(ctx, createGHSourceParams{
-		WorkspaceID:    gh.WorkspaceID.String(),
-		ID:             gh.ID.String(),
-		Owner:          gh.Owner,
-		Repo:           gh.Repo,
-		Path:           gh.Path,
-		InstallationID: gh.InstallationID,
-	})
+func (s *sqlite) GetChangelogOEmbedData(ctx context.Context, url string) (OEmbedResponse, error) {
+	domain, subdomain, err := parseChangelogURL(url)
 	if err != nil {
-		return GHSource{}, err
+		return OEmbedResponse{}, err
 	}
-	return row.toExported(), nil
+	cl, err := s.GetChangelogByDomainOrSubdomain(ctx, domain, subdomain)
+	if err != nil {
+		return OEmbedResponse{}, err
+	}
+	ws, err := s.GetWorkspaceByChangelog(ctx, cl.WorkspaceID, cl.ID)
+	if err != nil {
+		return OEmbedResponse{}, err
+	}
+	return newOEmbedResponse(cl, ws.Name), nil
 }
 
-func (s *sqlite) DeleteGHSource(ctx context.Context, wID WorkspaceID, ghID GHSourceID) error {
-	return s.q.deleteGHSource(ctx, deleteGHSourceParams{
+func (s *sqlite) GetChangelogPublicURL(ctx context.Context, wID WorkspaceID, cID ChangelogID) (string, error) {
+	row, err := s.q.getChangelogHost(ctx, getChangelogHostParams{
 		WorkspaceID: wID.String(),
-		ID:          ghID.String(),
+		ID:          cID.String(),
 	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", errNoChangelog
+		}
+		return "", err
+	}
+	return publicChangelogURL(s.opts.BaseURL, Domain(row.Domain), Subdomain(row.Subdomain))
 }
 
-func (s *sqlite) GetGHSource(ctx context.Context, wID WorkspaceID, ghID GHSourceID) (GHSource, error) {
-	row, err := s.q.getGHSource(ctx, getGHSourceParams{
-		WorkspaceID: wID.String(),
-		ID:          ghID.String(),
-	})
+func (s *sqlite) GetCDNPurgeList(ctx context.Context, wID WorkspaceID, cID ChangelogID, entryID string) ([]string, error) {
+	changelogURL, err := s.GetChangelogPublicURL(ctx, wID, cID)
 	if err != nil {
-		return GHSource{}, err
+		return nil, err
 	}
-	return row.toExported(), nil
+	return cdnPurgeList(changelogURL, cID, entryID), nil
 }
 
-func (s *sqlite) ListGHSources(ctx context.Context, wID WorkspaceID) ([]GHSource, error) {
-	rows, err := s.q.listGHSources(ctx, wID.String())
+func (s *sqlite) ListChangelogs(ctx context.Context, wID WorkspaceID) ([]Changelog, error) {
+	cls, err := s.q.listChangelogs(ctx, wID.String())
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
-			return make([]GHSource, 0), nil
+			return make([]Changelog, 0), nil
 		}
 		return nil, err
 	}
 
-	sources := make([]GHSource, len(rows))
-	for i, row := range rows {
-		sources[i] = row.toExported()
+	res := make([]Changelog, len(cls))
+	for i, cl := range cls {
+		res[i] = cl.changelog.toExported(cl.ChangelogSource)
 	}
-	return sources, nil
+	return res, nil
+}
+
+func (s *sqlite) ListChangelogsByInstallationID(ctx context.Context, installationID int64) ([]Changelog, error) {
+	rows, err := s.q.listChangelogsByInstallationID(ctx, installationID)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]Changelog, len(rows))
+	for i, row := range rows {
+		gh, err := s.decodeGHSource(row.ghSource, row.ConsecutiveFailures)
+		if err != nil {
+			return nil, err
+		}
+		res[i] = row.changelog.toExported(changelogSource{})
+		res[i].GHSource = null.NewValue(gh, true)
+	}
+	return res, nil
+}
+
+func (s *sqlite) BulkGetChangelogs(ctx context.Context, wID WorkspaceID, cIDs []ChangelogID) ([]*Changelog, error) {
+	ids := make([]string, len(cIDs))
+	for i, id := range cIDs {
+		ids[i] = id.String()
+	}
+
+	rows, err := s.q.listChangelogsByIDs(ctx, listChangelogsByIDsParams{
+		WorkspaceID: wID.String(),
+		Ids:         ids,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	byID := make(map[ChangelogID]*Changelog, len(rows))
+	for _, r := range rows {
+		cl := r.changelog.toExported(r.ChangelogSource)
+		byID[cl.ID] = &cl
+	}
+
+	res := make([]*Changelog, len(cIDs))
+	for i, id := range cIDs {
+		res[i] = byID[id]
+	}
+	return res, nil
+}
+
+var errNoDomainVerification = errs.NewError(errs.ErrNotFound, errors.New("domain verification not started"))
+
+func (s *sqlite) StartDomainVerification(ctx context.Context, wID WorkspaceID, cID ChangelogID) error {
+	if s.opts.CNAMETarget == "" {
+		return errs.NewBadRequest(errors.New("custom domains are not configured"))
+	}
+	cl, err := s.GetChangelog(ctx, wID, cID)
+	if err != nil {
+		return err
+	}
+	if !cl.Domain.NullString().IsValid() {
+		return errs.NewBadRequest(errors.New("changelog has no custom domain"))
+	}
+
+	return s.q.startDomainVerification(ctx, startDomainVerificationParams{
+		WorkspaceID: wID.String(),
+		ChangelogID: cID.String(),
+		Domain:      cl.Domain.String(),
+		CnameTarget: s.opts.CNAMETarget,
+	})
+}
+
+func (s *sqlite) RecordDomainVerification(ctx context.Context, wID WorkspaceID, cID ChangelogID, verified bool, checkErr error) error {
+	var checkError apitypes.NullString
+	if checkErr != nil {
+		checkError = apitypes.NewString(checkErr.Error())
+	}
+
+	n, err := s.q.recordDomainVerification(ctx, recordDomainVerificationParams{
+		Verified:    verified,
+		CheckError:  checkError,
+		WorkspaceID: wID.String(),
+		ChangelogID: cID.String(),
+	})
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return errNoDomainVerification
+	}
+	return nil
+}
+
+func (s *sqlite) GetDomainVerificationStatus(ctx context.Context, wID WorkspaceID, cID ChangelogID) (DomainVerification, error) {
+	row, err := s.q.getDomainVerification(ctx, getDomainVerificationParams{
+		WorkspaceID: wID.String(),
+		ChangelogID: cID.String(),
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return DomainVerification{}, errNoDomainVerification
+		}
+		return DomainVerification{}, err
+	}
+
+	v := row.domainVerification
+	// a verification is only valid for the domain it was started for
+	res := DomainVerification{
+		ChangelogID: ChangelogID(v.ChangelogID),
+		Domain:      Domain(row.ChangelogDomain),
+		CNAMETarget: v.CnameTarget,
+	}
+	if v.Domain != row.ChangelogDomain.V() {
+		return res, nil
+	}
+
+	res.Verified = v.VerifiedAt.Valid
+	res.CheckError = v.CheckError.V()
+	if v.VerifiedAt.Valid {
+		res.VerifiedAt = time.Unix(v.VerifiedAt.Int64, 0)
+	}
+	if v.LastCheckedAt.Valid {
+		res.LastCheckedAt = time.Unix(v.LastCheckedAt.Int64, 0)
+	}
+	return res, nil
+}
+
+func (s *sqlite) SetChangelogRobotsTxt(ctx context.Context, wID WorkspaceID, cID ChangelogID, content string) error {
+	var robotsTxt apitypes.NullString
+	if strings.TrimSpace(content) != "" {
+		err := validateRobotsTxt(content)
+		if err != nil {
+			return err
+		}
+		robotsTxt = apitypes.NewString(content)
+	}
+
+	n, err := s.q.setChangelogRobotsTxt(ctx, setChangelogRobotsTxtParams{
+		RobotsTxt:   robotsTxt,
+		WorkspaceID: wID.String(),
+		ID:          cID.String(),
+	})
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return errNoChangelog
+	}
+	return nil
+}
+
+func (s *sqlite) GetChangelogRobotsTxt(ctx context.Context, domain Domain, subdomain Subdomain) (string, error) {
+	robotsTxt, err := s.q.getChangelogRobotsTxt(ctx, getChangelogRobotsTxtParams{
+		Domain:    domain.NullString(),
+		Subdomain: subdomain.String(),
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", errNoChangelog
+		}
+		return "", err
+	}
+	if !robotsTxt.IsValid() {
+		return DefaultRobotsTxt, nil
+	}
+	return robotsTxt.V(), nil
+}
+
+func (s *sqlite) SetCanonicalURL(ctx context.Context, wID WorkspaceID, cID ChangelogID, canonicalURL string) error {
+	var canonical apitypes.NullString
+	if canonicalURL != "" {
+		err := validateCanonicalURL(canonicalURL)
+		if err != nil {
+			return err
+		}
+		canonical = apitypes.NewString(canonicalURL)
+	}
+
+	n, err := s.q.setChangelogCanonicalURL(ctx, setChangelogCanonicalURLParams{
+		CanonicalUrl: canonical,
+		WorkspaceID:  wID.String(),
+		ID:           cID.String(),
+	})
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return errNoChangelog
+	}
+	return nil
+}
+
+func (s *sqlite) GetCanonicalURL(ctx context.Context, domain Domain, subdomain Subdomain) (string, error) {
+	row, err := s.q.getChangelogCanonicalURL(ctx, getChangelogCanonicalURLParams{
+		Domain:    domain.NullString(),
+		Subdomain: subdomain.String(),
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", errNoChangelog
+		}
+		return "", err
+	}
+	if row.CanonicalUrl.IsValid() {
+		return row.CanonicalUrl.V(), nil
+	}
+	return publicChangelogURL(s.opts.BaseURL, Domain(row.Domain), Subdomain(row.Subdomain))
+}
+
+func (s *sqlite) SetI18nString(ctx context.Context, wID WorkspaceID, cID ChangelogID, key, value string) error {
+	err := validateI18nString(key, value)
+	if err != nil {
+		return err
+	}
+
+	err = s.q.setChangelogI18nString(ctx, setChangelogI18nStringParams{
+		WorkspaceID: wID.String(),
+		ChangelogID: cID.String(),
+		Key:         key,
+		Value:       value,
+	})
+	if err != nil && strings.Contains(err.Error(), "FOREIGN KEY constraint failed") {
+		return errNoChangelog
+	}
+	return err
+}
+
+func (s *sqlite) GetI18nStrings(ctx context.Context, wID WorkspaceID, cID ChangelogID) (map[string]string, error) {
+	rows, err := s.q.listChangelogI18nStrings(ctx, listChangelogI18nStringsParams{
+		WorkspaceID: wID.String(),
+		ChangelogID: cID.String(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	strs := make(map[string]string, len(rows))
+	for _, r := range rows {
+		strs[r.Key] = r.Value
+	}
+	return strs, nil
+}
+
+func (s *sqlite) DeleteI18nString(ctx context.Context, wID WorkspaceID, cID ChangelogID, key string) error {
+	return s.q.deleteChangelogI18nString(ctx, deleteChangelogI18nStringParams{
+		WorkspaceID: wID.String(),
+		ChangelogID: cID.String(),
+		Key:         key,
+	})
+}
+
+func (s *sqlite) GetChangelogTheme(ctx context.Context, wID WorkspaceID, cID ChangelogID) (Theme, error) {
+	row, err := s.q.getChangelogTheme(ctx, getChangelogThemeParams{
+		WorkspaceID: wID.String(),
+		ChangelogID: cID.String(),
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return Theme{}, nil
+		}
+		return Theme{}, err
+	}
+
+	theme := Theme{
+		FontFamily:      row.FontFamily.V(),
+		AccentColor:     row.AccentColor.V(),
+		BackgroundColor: row.BackgroundColor.V(),
+		TextColor:       row.TextColor.V(),
+		CustomCSS:       row.CustomCss.V(),
+	}
+	if row.BorderRadiusPx.Valid {
+		radius := int(row.BorderRadiusPx.Int64)
+		theme.BorderRadiusPx = &radius
+	}
+	return theme, nil
+}
+
+func (s *sqlite) SetChangelogTheme(ctx context.Context, wID WorkspaceID, cID ChangelogID, theme Theme) error {
+	err := theme.validate()
+	if err != nil {
+		return err
+	}
+
+	var radius sql.NullInt64
+	if theme.BorderRadiusPx != nil {
+		radius = sql.NullInt64{Int64: int64(*theme.BorderRadiusPx), Valid: true}
+	}
+
+	err = s.q.setChangelogTheme(ctx, setChangelogThemeParams{
+		WorkspaceID:     wID.String(),
+		ChangelogID:     cID.String(),
+		FontFamily:      apitypes.NewString(theme.FontFamily),
+		AccentColor:     apitypes.NewString(theme.AccentColor),
+		BackgroundColor: apitypes.NewString(theme.BackgroundColor),
+		TextColor:       apitypes.NewString(theme.TextColor),
+		BorderRadiusPx:  radius,
+		CustomCss:       apitypes.NewString(theme.CustomCSS),
+	})
+	if err != nil && strings.Contains(err.Error(), "FOREIGN KEY constraint failed") {
+		return errNoChangelog
+	}
+	return err
+}
+
+func (s *sqlite) SetSocialLinks(ctx context.Context, wID WorkspaceID, cID ChangelogID, links SocialLinks) error {
+	err := links.validate()
+	if err != nil {
+		return err
+	}
+	ns, err := links.toNullString()
+	if err != nil {
+		return err
+	}
+
+	n, err := s.q.setChangelogSocialLinks(ctx, setChangelogSocialLinksParams{
+		SocialLinks: ns,
+		WorkspaceID: wID.String(),
+		ID:          cID.String(),
+	})
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return errNoChangelog
+	}
+	return nil
+}
+
+func (s *sqlite) SetChangelogSortOrder(ctx context.Context, wID WorkspaceID, cID ChangelogID, order int) error {
+	n, err := s.q.setChangelogSortOrder(ctx, setChangelogSortOrderParams{
+		SortOrder:   int64(order),
+		WorkspaceID: wID.String(),
+		ID:          cID.String(),
+	})
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return errNoChangelog
+	}
+	return nil
+}
+
+func (s *sqlite) BulkSetChangelogSortOrder(ctx context.Context, wID WorkspaceID, orders map[ChangelogID]int) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	q := s.q.WithTx(tx)
+
+	for cID, order := range orders {
+		n, err := q.setChangelogSortOrder(ctx, setChangelogSortOrderParams{
+			SortOrder:   int64(order),
+			WorkspaceID: wID.String(),
+			ID:          cID.String(),
+		})
+		if err != nil {
+			return err
+		}
+		if n == 0 {
+			return errNoChangelog
+		}
+	}
+
+	return tx.Commit()
+}
+
+func (s *sqlite) ListChangelogsWithoutSource(ctx context.Context, wID WorkspaceID) ([]Changelog, error) {
+	cls, err := s.q.listChangelogsWithoutSource(ctx, wID.String())
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return make([]Changelog, 0), nil
+		}
+		return nil, err
+	}
+
+	res := make([]Changelog, len(cls))
+	for i, cl := range cls {
+		res[i] = cl.toExported(changelogSource{})
+	}
+	return res, nil
+}
+
+func (s *sqlite) ListPublicChangelogs(ctx context.Context, page, pageSize int) ([]Changelog, int64, error) {
+	if page < 1 || pageSize < 1 {
+		return nil, 0, errs.NewError(errs.ErrBadRequest, errors.New("page and page size must be greater than 0"))
+	}
+
+	cls, err := s.q.listPublicChangelogs(ctx, listPublicChangelogsParams{
+		Limit:  int64(pageSize),
+		Offset: int64((page - 1) * pageSize),
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+
+	total, err := s.q.countPublicChangelogs(ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	res := make([]Changelog, len(cls))
+	for i, cl := range cls {
+		res[i] = cl.changelog.toExported(cl.ChangelogSource)
+	}
+	return res, total, nil
+}
+
+func (s *sqlite) CountPublicChangelogs(ctx context.Context) (int64, error) {
+	return s.q.countPublicChangelogs(ctx)
+}
+
+func (s *sqlite) ListChangelogsWithAnalytics(ctx context.Context, page, pageSize int) ([]Changelog, int64, error) {
+	if page < 1 || pageSize < 1 {
+		return nil, 0, errs.NewError(errs.ErrBadRequest, errors.New("page and page size must be greater than 0"))
+	}
+
+	cls, err := s.q.listChangelogsWithAnalytics(ctx, listChangelogsWithAnalyticsParams{
+		Limit:  int64(pageSize),
+		Offset: int64((page - 1) * pageSize),
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+
+	total, err := s.q.countChangelogsWithAnalytics(ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	res := make([]Changelog, len(cls))
+	for i, cl := range cls {
+		res[i] = cl.changelog.toExported(cl.ChangelogSource)
+	}
+	return res, total, nil
+}
+
+func (s *sqlite) CreateChangelogToken(ctx context.Context, wID WorkspaceID, cID ChangelogID) (ChangelogToken, error) {
+	token, err := NewChangelogToken()
+	if err != nil {
+		return "", err
+	}
+	err = s.q.createChangelogToken(ctx, createChangelogTokenParams{
+		TokenHash:   token.hash(),
+		ChangelogID: cID.String(),
+		WorkspaceID: wID.String(),
+	})
+	if err != nil {
+		if strings.Contains(err.Error(), "FOREIGN KEY constraint failed") {
+			return "", errNoChangelog
+		}
+		return "", err
+	}
+	return token, nil
+}
+
+func (s *sqlite) GetChangelogByToken(ctx context.Context, token string) (Changelog, error) {
+	cl, err := s.q.getChangelogByToken(ctx, ChangelogToken(token).hash())
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return Changelog{}, errs.NewError(errs.ErrUnauthorized, errors.New("invalid changelog token"))
+		}
+		return Changelog{}, err
+	}
+	return cl.changelog.toExported(cl.ChangelogSource), nil
+}
+
+func (s *sqlite) RevokeChangelogToken(ctx context.Context, wID WorkspaceID, token ChangelogToken) error {
+	n, err := s.q.deleteChangelogToken(ctx, deleteChangelogTokenParams{
+		WorkspaceID: wID.String(),
+		TokenHash:   token.hash(),
+	})
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return errs.NewError(errs.ErrNotFound, errors.New("changelog token not found"))
+	}
+	return nil
+}
+
+func (s *sqlite) CreateRSSToken(ctx context.Context, wID WorkspaceID, cID ChangelogID) (string, error) {
+	token, err := newSecretToken()
+	if err != nil {
+		return "", err
+	}
+
+	err = s.q.createRSSToken(ctx, createRSSTokenParams{
+		TokenHash:   hashSecretToken(token),
+		ChangelogID: cID.String(),
+		WorkspaceID: wID.String(),
+	})
+	if err != nil {
+		if strings.Contains(err.Error(), "FOREIGN KEY constraint failed") {
+			return "", errNoChangelog
+		}
+		return "", err
+	}
+	return token, nil
+}
+
+var errInvalidRSSToken = errs.NewError(errs.ErrUnauthorized, errors.New("invalid rss token"))
+
+func (s *sqlite) GetChangelogByRSSToken(ctx context.Context, token string) (Changelog, error) {
+	cl, err := s.q.getChangelogByRSSToken(ctx, hashSecretToken(token))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return Changelog{}, errInvalidRSSToken
+		}
+		return Changelog{}, err
+	}
+	return cl.changelog.toExported(cl.ChangelogSource), nil
+}
+
+func (s *sqlite) RevokeRSSToken(ctx context.Context, wID WorkspaceID, cID ChangelogID, token string) error {
+	n, err := s.q.deleteRSSToken(ctx, deleteRSSTokenParams{
+		WorkspaceID: wID.String(),
+		ChangelogID: cID.String(),
+		TokenHash:   hashSecretToken(token),
+	})
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return errs.NewError(errs.ErrNotFound, errors.New("rss token not found"))
+	}
+	return nil
+}
+
+// how long a preview link can be shared before it has to be recreated
+const preview_token_ttl = 7 * 24 * time.Hour
+
+func (s *sqlite) CreatePreviewToken(ctx context.Context, wID WorkspaceID, cID ChangelogID) (string, error) {
+	token, err := newSecretToken()
+	if err != nil {
+		return "", err
+	}
+
+	err = s.q.createPreviewToken(ctx, createPreviewTokenParams{
+		TokenHash:   hashSecretToken(token),
+		ChangelogID: cID.String(),
+		WorkspaceID: wID.String(),
+		ExpiresAt:   time.Now().Add(preview_token_ttl).Unix(),
+	})
+	if err != nil {
+		if strings.Contains(err.Error(), "FOREIGN KEY constraint failed") {
+			return "", errNoChangelog
+		}
+		return "", err
+	}
+	return token, nil
+}
+
+var errInvalidPreviewToken = errs.NewError(errs.ErrNotFound, errors.New("preview not found"))
+
+func (s *sqlite) GetChangelogByPreviewToken(ctx context.Context, token string) (Changelog, error) {
+	cl, err := s.q.getChangelogByPreviewToken(ctx, hashSecretToken(token))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return Changelog{}, errInvalidPreviewToken
+		}
+		return Changelog{}, err
+	}
+	if cl.ExpiresAt < time.Now().Unix() {
+		return Changelog{}, errInvalidPreviewToken
+	}
+	return cl.changelog.toExported(cl.ChangelogSource), nil
+}
+
+func (s *sqlite) RevokePreviewToken(ctx context.Context, wID WorkspaceID, cID ChangelogID, token string) error {
+	n, err := s.q.deletePreviewToken(ctx, deletePreviewTokenParams{
+		WorkspaceID: wID.String(),
+		ChangelogID: cID.String(),
+		TokenHash:   hashSecretToken(token),
+	})
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return errs.NewError(errs.ErrNotFound, errors.New("preview token not found"))
+	}
+	return nil
+}
+
+func (s *sqlite) SnapshotChangelog(ctx context.Context, wID WorkspaceID, cID ChangelogID) error {
+	cl, err := s.GetChangelog(ctx, wID, cID)
+	if err != nil {
+		return err
+	}
+	// no need to keep credentials around
+	cl.PasswordHash = ""
+
+	snapshot, err := json.Marshal(cl)
+	if err != nil {
+		return err
+	}
+
+	return s.q.createChangelogSnapshot(ctx, createChangelogSnapshotParams{
+		ID:          newID(snapshot_prefix),
+		WorkspaceID: wID.String(),
+		ChangelogID: cID.String(),
+		Snapshot:    snapshot,
+	})
+}
+
+func (s *sqlite) ListSnapshots(ctx context.Context, wID WorkspaceID, cID ChangelogID, limit int) ([]ChangelogSnapshot, error) {
+	rows, err := s.q.listChangelogSnapshots(ctx, listChangelogSnapshotsParams{
+		WorkspaceID: wID.String(),
+		ChangelogID: cID.String(),
+		Limit:       int64(clampSnapshotLimit(limit)),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]ChangelogSnapshot, len(rows))
+	for i, r := range rows {
+		res[i], err = r.toExported()
+		if err != nil {
+			return nil, err
+		}
+	}
+	return res, nil
+}
+
+func (s *sqlite) RestoreSnapshot(ctx context.Context, wID WorkspaceID, cID ChangelogID, snapshotID string) (Changelog, error) {
+	row, err := s.q.getChangelogSnapshot(ctx, getChangelogSnapshotParams{
+		WorkspaceID: wID.String(),
+		ChangelogID: cID.String(),
+		ID:          snapshotID,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return Changelog{}, errs.NewError(errs.ErrNotFound, errors.New("snapshot not found"))
+		}
+		return Changelog{}, err
+	}
+
+	snapshot, err := row.toExported()
+	if err != nil {
+		return Changelog{}, err
+	}
+	return s.UpdateChangelog(ctx, wID, cID, restoreArgs(snapshot.Changelog))
+}
+
+func (s *sqlite) DiffChangelogs(ctx context.Context, wID WorkspaceID, aID, bID ChangelogID) (ChangelogDiff, error) {
+	a, err := s.GetChangelog(ctx, wID, aID)
+	if err != nil {
+		return nil, err
+	}
+	b, err := s.GetChangelog(ctx, wID, bID)
+	if err != nil {
+		return nil, err
+	}
+	return diffChangelogs(a, b), nil
+}
+
+func (s *sqlite) RecordSearchQuery(ctx context.Context, wID WorkspaceID, cID ChangelogID, query string, resultCount int) error {
+	// queries are case insensitive, normalize them to group them together
+	query = strings.ToLower(strings.TrimSpace(query))
+	if query == "" {
+		return nil
+	}
+	err := s.q.recordSearchQuery(ctx, recordSearchQueryParams{
+		WorkspaceID: wID.String(),
+		ChangelogID: cID.String(),
+		Query:       query,
+		ResultCount: int64(resultCount),
+	})
+	if err != nil && strings.Contains(err.Error(), "FOREIGN KEY constraint failed") {
+		return errNoChangelog
+	}
+	return err
+}
+
+func (s *sqlite) GetTopSearchQueries(ctx context.Context, wID WorkspaceID, cID ChangelogID, limit int, from, to time.Time) ([]SearchQueryStat, error) {
+	rows, err := s.q.getTopSearchQueries(ctx, getTopSearchQueriesParams{
+		WorkspaceID: wID.String(),
+		ChangelogID: cID.String(),
+		FromTime:    from.Unix(),
+		ToTime:      to.Unix(),
+		MaxResults:  int64(limit),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	stats := make([]SearchQueryStat, len(rows))
+	for i, r := range rows {
+		stats[i] = SearchQueryStat{
+			Query: r.Query,
+			Count: r.Count,
+		}
+	}
+	return stats, nil
+}
+
+const access_log_prefix = "al"
+
+func (l changelogAccessLog) toExported() AccessLogEntry {
+	return AccessLogEntry{
+		ID:          l.ID,
+		WorkspaceID: WorkspaceID(l.WorkspaceID),
+		ChangelogID: ChangelogID(l.ChangelogID),
+		VisitorHash: l.VisitorHash,
+		UserAgent:   l.UserAgent.V(),
+		Referer:     l.Referer.V(),
+		AccessedAt:  time.Unix(l.AccessedAt, 0),
+	}
+}
+
+func (s *sqlite) AppendAccessLog(ctx context.Context, log AccessLogEntry) error {
+	if log.AccessedAt.IsZero() {
+		log.AccessedAt = time.Now()
+	}
+
+	err := s.q.createAccessLog(ctx, createAccessLogParams{
+		ID:          newID(access_log_prefix),
+		WorkspaceID: log.WorkspaceID.String(),
+		ChangelogID: log.ChangelogID.String(),
+		VisitorHash: log.VisitorHash,
+		UserAgent:   apitypes.NewString(log.UserAgent),
+		Referer:     apitypes.NewString(log.Referer),
+		AccessedAt:  log.AccessedAt.Unix(),
+	})
+	if err != nil && strings.Contains(err.Error(), "FOREIGN KEY constraint failed") {
+		return errNoChangelog
+	}
+	return err
+}
+
+func (s *sqlite) ListAccessLog(ctx context.Context, wID WorkspaceID, cID ChangelogID, from, to time.Time, page, pageSize int) ([]AccessLogEntry, error) {
+	if page < 1 || pageSize < 1 {
+		return nil, errs.NewError(errs.ErrBadRequest, errors.New("page and page size must be greater than 0"))
+	}
+
+	rows, err := s.q.listAccessLog(ctx, listAccessLogParams{
+		WorkspaceID: wID.String(),
+		ChangelogID: cID.String(),
+		FromTime:    from.Unix(),
+		ToTime:      to.Unix(),
+		Limit:       int64(pageSize),
+		Offset:      int64((page - 1) * pageSize),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	logs := make([]AccessLogEntry, len(rows))
+	for i, r := range rows {
+		logs[i] = r.toExported()
+	}
+	return logs, nil
+}
+
+func (s *sqlite) PurgeAccessLog(ctx context.Context, before time.Time) (int64, error) {
+	return s.q.purgeAccessLog(ctx, before.Unix())
+}
+
+func (s *sqlite) GetWorkspaceDashboardSummary(ctx context.Context, wID WorkspaceID) (DashboardSummary, error) {
+	row, err := s.q.getWorkspaceDashboardSummary(ctx, getWorkspaceDashboardSummaryParams{
+		WorkspaceID:       wID.String(),
+		ViewsSince:        time.Now().AddDate(0, 0, -30).Unix(),
+		DegradedThreshold: gh_source_degraded_threshold,
+	})
+	if err != nil {
+		return DashboardSummary{}, err
+	}
+
+	return DashboardSummary{
+		ChangelogCount:       row.ChangelogCount,
+		TotalViewsLast30Days: row.TotalViews,
+		UnprocessedWebhooks:  row.UnprocessedWebhooks,
+		DegradedSources:      row.DegradedSources,
+	}, nil
+}
+
+func (s *sqlite) GetHeatmapByDayOfWeek(ctx context.Context, wID WorkspaceID, cID ChangelogID, from, to time.Time) ([7]int64, error) {
+	var heatmap [7]int64
+	rows, err := s.q.getHeatmapByDayOfWeek(ctx, getHeatmapByDayOfWeekParams{
+		WorkspaceID: wID.String(),
+		ChangelogID: cID.String(),
+		FromTime:    from.Unix(),
+		ToTime:      to.Unix(),
+	})
+	if err != nil {
+		return heatmap, err
+	}
+
+	for _, r := range rows {
+		heatmap[r.Day] = r.Views
+	}
+	return heatmap, nil
+}
+
+func (s *sqlite) GetHeatmapByHour(ctx context.Context, wID WorkspaceID, cID ChangelogID, from, to time.Time) ([24]int64, error) {
+	var heatmap [24]int64
+	rows, err := s.q.getHeatmapByHour(ctx, getHeatmapByHourParams{
+		WorkspaceID: wID.String(),
+		ChangelogID: cID.String(),
+		FromTime:    from.Unix(),
+		ToTime:      to.Unix(),
+	})
+	if err != nil {
+		return heatmap, err
+	}
+
+	for _, r := range rows {
+		heatmap[r.Hour] = r.Views
+	}
+	return heatmap, nil
+}
+
+func (s *sqlite) GetTrafficSources(ctx context.Context, wID WorkspaceID, cID ChangelogID, from, to time.Time, limit int) ([]TrafficSource, error) {
+	rows, err := s.q.getTrafficSources(ctx, getTrafficSourcesParams{
+		WorkspaceID: wID.String(),
+		ChangelogID: cID.String(),
+		FromTime:    from.Unix(),
+		ToTime:      to.Unix(),
+		MaxResults:  int64(limit),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sources := make([]TrafficSource, len(rows))
+	for i, r := range rows {
+		sources[i] = TrafficSource{
+			Domain: r.Domain,
+			Visits: r.Visits,
+		}
+	}
+	return sources, nil
+}
+
+func (s *sqlite) GetWorkspaceAnalytics(ctx context.Context, wID WorkspaceID, from, to time.Time) (WorkspaceAnalytics, error) {
+	row, err := s.q.getWorkspaceAnalytics(ctx, getWorkspaceAnalyticsParams{
+		WorkspaceID: wID.String(),
+		FromTime:    from.Unix(),
+		ToTime:      to.Unix(),
+	})
+	if err != nil {
+		return WorkspaceAnalytics{}, err
+	}
+	return WorkspaceAnalytics{
+		TotalViews:     row.TotalViews,
+		UniqueVisitors: row.UniqueVisitors,
+	}, nil
+}
+
+func (s *sqlite) CreateAnnouncement(ctx context.Context, a Announcement) (Announcement, error) {
+	err := a.validate()
+	if err != nil {
+		return Announcement{}, err
+	}
+
+	row, err := s.q.createAnnouncement(ctx, createAnnouncementParams{
+		ID:          newID(announcement_prefix),
+		WorkspaceID: a.WorkspaceID.String(),
+		ChangelogID: a.ChangelogID.String(),
+		Message:     a.Message,
+		CtaUrl:      a.CTAURL,
+		StartsAt:    a.StartsAt.Unix(),
+		EndsAt:      a.EndsAt.Unix(),
+	})
+	if err != nil {
+		if strings.Contains(err.Error(), "FOREIGN KEY constraint failed") {
+			return Announcement{}, errNoChangelog
+		}
+		return Announcement{}, err
+	}
+	return row.toExported(), nil
+}
+
+func (s *sqlite) DeleteAnnouncement(ctx context.Context, wID WorkspaceID, cID ChangelogID, announcementID string) error {
+	n, err := s.q.deleteAnnouncement(ctx, deleteAnnouncementParams{
+		WorkspaceID: wID.String(),
+		ChangelogID: cID.String(),
+		ID:          announcementID,
+	})
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return errs.NewError(errs.ErrNotFound, errors.New("announcement not found"))
+	}
+	return nil
+}
+
+func (s *sqlite) ListActiveAnnouncements(ctx context.Context, cID ChangelogID, at time.Time) ([]Announcement, error) {
+	rows, err := s.q.listActiveAnnouncements(ctx, listActiveAnnouncementsParams{
+		ChangelogID: cID.String(),
+		At:          at.Unix(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]Announcement, len(rows))
+	for i, r := range rows {
+		res[i] = r.toExported()
+	}
+	return res, nil
+}
+
+func (s *sqlite) ListAllAnnouncements(ctx context.Context, wID WorkspaceID, cID ChangelogID) ([]Announcement, error) {
+	rows, err := s.q.listAllAnnouncements(ctx, listAllAnnouncementsParams{
+		WorkspaceID: wID.String(),
+		ChangelogID: cID.String(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]Announcement, len(rows))
+	for i, r := range rows {
+		res[i] = r.toExported()
+	}
+	return res, nil
+}
+
+func (s *sqlite) CreateWebhookEndpoint(ctx context.Context, w WebhookEndpoint) (WebhookEndpoint, error) {
+	err := w.validate()
+	if err != nil {
+		return WebhookEndpoint{}, err
+	}
+
+	if w.Secret == "" {
+		w.Secret, err = newSecretToken()
+		if err != nil {
+			return WebhookEndpoint{}, errs.NewInternalError(err)
+		}
+	}
+	secret, err := s.encryptString(w.Secret)
+	if err != nil {
+		return WebhookEndpoint{}, err
+	}
+
+	events, err := json.Marshal(w.Events)
+	if err != nil {
+		return WebhookEndpoint{}, errs.NewInternalError(err)
+	}
+
+	row, err := s.q.createWebhookEndpoint(ctx, createWebhookEndpointParams{
+		ID:          newID(webhook_endpoint_prefix),
+		ChangelogID: w.ChangelogID.String(),
+		WorkspaceID: w.WorkspaceID.String(),
+		Url:         w.URL,
+		Secret:      secret,
+		Events:      string(events),
+		Active:      boolToInt(w.Active),
+	})
+	if err != nil {
+		if strings.Contains(err.Error(), "FOREIGN KEY constraint failed") {
+			return WebhookEndpoint{}, errNoChangelog
+		}
+		return WebhookEndpoint{}, err
+	}
+	return s.webhookEndpointToExported(row)
+}
+
+func (s *sqlite) UpdateWebhookEndpoint(ctx context.Context, w WebhookEndpoint) (WebhookEndpoint, error) {
+	err := w.validate()
+	if err != nil {
+		return WebhookEndpoint{}, err
+	}
+
+	// an empty secret keeps the current one
+	var secret apitypes.NullString
+	if w.Secret != "" {
+		encrypted, err := s.encryptString(w.Secret)
+		if err != nil {
+			return WebhookEndpoint{}, err
+		}
+		secret = apitypes.NewString(encrypted)
+	}
+
+	events, err := json.Marshal(w.Events)
+	if err != nil {
+		return WebhookEndpoint{}, errs.NewInternalError(err)
+	}
+
+	row, err := s.q.updateWebhookEndpoint(ctx, updateWebhookEndpointParams{
+		Url:         w.URL,
+		Secret:      secret,
+		Events:      string(events),
+		Active:      boolToInt(w.Active),
+		WorkspaceID: w.WorkspaceID.String(),
+		ID:          w.ID,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return WebhookEndpoint{}, errNoWebhookEndpoint
+		}
+		return WebhookEndpoint{}, err
+	}
+	return s.webhookEndpointToExported(row)
+}
+
+func (s *sqlite) DeleteWebhookEndpoint(ctx context.Context, wID WorkspaceID, endpointID string) error {
+	n, err := s.q.deleteWebhookEndpoint(ctx, deleteWebhookEndpointParams{
+		WorkspaceID: wID.String(),
+		ID:          endpointID,
+	})
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return errNoWebhookEndpoint
+	}
+	return nil
+}
+
+func (s *sqlite) ListWebhookEndpoints(ctx context.Context, wID WorkspaceID, cID ChangelogID) ([]WebhookEndpoint, error) {
+	rows, err := s.q.listWebhookEndpoints(ctx, listWebhookEndpointsParams{
+		WorkspaceID: wID.String(),
+		ChangelogID: cID.String(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]WebhookEndpoint, len(rows))
+	for i, r := range rows {
+		res[i], err = s.webhookEndpointToExported(r)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return res, nil
+}
+
+func (s *sqlite) GetWebhookEndpoint(ctx context.Context, wID WorkspaceID, endpointID string) (WebhookEndpoint, error) {
+	row, err := s.q.getWebhookEndpoint(ctx, getWebhookEndpointParams{
+		WorkspaceID: wID.String(),
+		ID:          endpointID,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return WebhookEndpoint{}, errNoWebhookEndpoint
+		}
+		return WebhookEndpoint{}, err
+	}
+	return s.webhookEndpointToExported(row)
+}
+
+// Converts the endpoint and decrypts its secret.
+func (s *sqlite) webhookEndpointToExported(w webhookEndpoint) (WebhookEndpoint, error) {
+	secret, err := s.decryptString(w.Secret)
+	if err != nil {
+		return WebhookEndpoint{}, err
+	}
+	res := w.toExported()
+	res.Secret = secret
+	return res, nil
+}
+
+// Encrypts s with the encryption key and encodes it as base64, so it can be stored in a TEXT column.
+func (s *sqlite) encryptString(plaintext string) (string, error) {
+	ciphertext, err := encrypt(s.opts.EncryptionKey, []byte(plaintext))
+	if err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(ciphertext), nil
+}
+
+// Reverses encryptString.
+func (s *sqlite) decryptString(encoded string) (string, error) {
+	ciphertext, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return "", errs.NewInternalError(err)
+	}
+	plaintext, err := decrypt(s.opts.EncryptionKey, ciphertext)
+	if err != nil {
+		return "", err
+	}
+	return string(plaintext), nil
+}
+
+func (s *sqlite) RecordWebhookDelivery(ctx context.Context, d WebhookDelivery) error {
+	if d.ID == "" {
+		d.ID = newID(webhook_delivery_prefix)
+	}
+	if d.DeliveredAt.IsZero() {
+		d.DeliveredAt = time.Now()
+	}
+
+	statusCode := sql.NullInt64{Int64: int64(d.StatusCode), Valid: d.StatusCode != 0}
+	payloadHash := sha256.Sum256(d.Payload)
+	err := s.q.createWebhookDelivery(ctx, createWebhookDeliveryParams{
+		ID:           d.ID,
+		EndpointID:   d.EndpointID,
+		EventType:    string(d.EventType),
+		Payload:      d.Payload,
+		PayloadHash:  hex.EncodeToString(payloadHash[:]),
+		StatusCode:   statusCode,
+		ResponseBody: apitypes.NewString(d.ResponseBody),
+		DeliveredAt:  d.DeliveredAt.Unix(),
+		DurationMs:   d.Duration.Milliseconds(),
+		Success:      boolToInt(d.Success),
+	})
+	if err != nil && strings.Contains(err.Error(), "FOREIGN KEY constraint failed") {
+		return errNoWebhookEndpoint
+	}
+	return err
+}
+
+func (s *sqlite) ListWebhookDeliveries(ctx context.Context, wID WorkspaceID, endpointID string, page, pageSize int) ([]WebhookDelivery, error) {
+	if page < 1 || pageSize < 1 {
+		return nil, errs.NewError(errs.ErrBadRequest, errors.New("page and page size must be greater than 0"))
+	}
+
+	rows, err := s.q.listWebhookDeliveries(ctx, listWebhookDeliveriesParams{
+		WorkspaceID: wID.String(),
+		EndpointID:  endpointID,
+		Limit:       int64(pageSize),
+		Offset:      int64((page - 1) * pageSize),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]WebhookDelivery, len(rows))
+	for i, r := range rows {
+		res[i] = r.toExported()
+	}
+	return res, nil
+}
+
+func (s *sqlite) RetryFailedDeliveries(ctx context.Context, endpointID string, limit int) ([]WebhookDelivery, error) {
+	rows, err := s.q.listFailedWebhookDeliveries(ctx, listFailedWebhookDeliveriesParams{
+		EndpointID: endpointID,
+		Limit:      int64(limit),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]WebhookDelivery, len(rows))
+	for i, r := range rows {
+		res[i] = r.toExported()
+	}
+	return res, nil
+}
+
+func (s *sqlite) ListAllSubdomains(ctx context.Context) ([]Subdomain, error) {
+	s.subdomainsMu.Lock()
+	defer s.subdomainsMu.Unlock()
+	if s.subdomains != nil && time.Now().Before(s.subdomainsExpiresAt) {
+		return slices.Clone(s.subdomains), nil
+	}
+
+	rows, err := s.q.listAllSubdomains(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	subdomains := make([]Subdomain, len(rows))
+	for i, r := range rows {
+		subdomains[i] = Subdomain(r)
+	}
+
+	if s.opts.SubdomainCacheTTL > 0 {
+		s.subdomains = slices.Clone(subdomains)
+		s.subdomainsExpiresAt = time.Now().Add(s.opts.SubdomainCacheTTL)
+	}
+	return subdomains, nil
+}
+
+// Evicts the cached subdomains, must be called whenever a subdomain is added, changed or removed.
+func (s *sqlite) invalidateSubdomains() {
+	s.subdomainsMu.Lock()
+	defer s.subdomainsMu.Unlock()
+	s.subdomains = nil
+}
+
+func (s *sqlite) ListSubdomainsByWorkspace(ctx context.Context, wID WorkspaceID) ([]Subdomain, error) {
+	rows, err := s.q.listSubdomainsByWorkspace(ctx, wID.String())
+	if err != nil {
+		return nil, err
+	}
+
+	subdomains := make([]Subdomain, len(rows))
+	for i, r := range rows {
+		subdomains[i] = Subdomain(r)
+	}
+	return subdomains, nil
+}
+
+// dereferences b to it's int representation
+func saveDerefToInt(b *bool) int64 {
+	if b != nil && *b {
+		return 1
+	}
+	return 0
+}
+
+// Returns 1 if b is true, otherwise 2
+func boolToInt(b bool) int64 {
+	var i int64
+	if b {
+		i = 1
+	}
+	return i
+}
+
+func (s *sqlite) UpdateChangelog(ctx context.Context, wID WorkspaceID, cID ChangelogID, args UpdateChangelogArgs) (Changelog, error) {
+	if !args.Slug.IsZero() {
+		err := validateSlug(args.Slug.V())
+		if err != nil {
+			return Changelog{}, err
+		}
+	}
+
+	var socialLinks apitypes.NullString
+	if args.SocialLinks != nil {
+		err := args.SocialLinks.validate()
+		if err != nil {
+			return Changelog{}, err
+		}
+		socialLinks, err = args.SocialLinks.toNullString()
+		if err != nil {
+			return Changelog{}, err
+		}
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return Changelog{}, err
+	}
+	defer tx.Rollback()
+	q := s.q.WithTx(tx)
+
+	setDomain := !args.Domain.NullString().IsZero()
+	var oldDomain apitypes.NullString
+	if setDomain {
+		host, err := q.getChangelogHost(ctx, getChangelogHostParams{
+			WorkspaceID: wID.String(),
+			ID:          cID.String(),
+		})
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return Changelog{}, errNoChangelog
+			}
+			return Changelog{}, err
+		}
+		oldDomain = host.Domain
+	}
+
+	// does not update string fields if they are zero value
+	_, err = q.updateChangelog(ctx, updateChangelogParams{
+		ID:          cID.String(),
+		WorkspaceID: wID.String(),
+		Subdomain:   args.Subdomain,
+		HidePoweredBy: sql.NullInt64{ // update if HidePoweredBy != nil
+			Int64: saveDerefToInt(args.HidePoweredBy),
+			Valid: args.HidePoweredBy != nil,
+		},
+		ColorScheme:    args.ColorScheme,
+		SetColorScheme: int(args.ColorScheme) != 0,
+		Title:          args.Title,
+		SetTitle:       !args.Title.IsZero(),
+		Subtitle:       args.Subtitle,
+		SetSubtitle:    !args.Subtitle.IsZero(),
+		Domain:         args.Domain.NullString(),
+		SetDomain:      setDomain,
+		LogoSrc:        args.LogoSrc,
+		SetLogoSrc:     !args.LogoSrc.IsZero(),
+		LogoLink:       args.LogoLink,
+		SetLogoLink:    !args.LogoLink.IsZero(),
+		LogoAlt:        args.LogoAlt,
+		SetLogoAlt:     !args.LogoAlt.IsZero(),
+		LogoHeight:     args.LogoHeight,
+		SetLogoHeight:  !args.LogoHeight.IsZero(),
+		LogoWidth:      args.LogoWidth,
+		SetLogoWidth:   !args.LogoWidth.IsZero(),
+		Protected: sql.NullInt64{ // update if args.Protected is defined
+			Int64: saveDerefToInt(args.Protected),
+			Valid: args.Protected != nil,
+		},
+		Analytics: sql.NullInt64{ // update if args.Analytics is defined
+			Int64: saveDerefToInt(args.Analytics),
+			Valid: args.Analytics != nil,
+		},
+		Searchable: sql.NullInt64{ // update if args.Searchable is defined
+			Int64: saveDerefToInt(args.Searchable),
+			Valid: args.Searchable != nil,
+		},
+		PasswordHash:    args.PasswordHash,
+		SetPasswordHash: !args.PasswordHash.IsZero(),
+		Slug:            args.Slug,
+		SetSlug:         !args.Slug.IsZero(),
+		FaviconSrc:      args.FaviconSrc,
+		SetFaviconSrc:   !args.FaviconSrc.IsZero(),
+		SocialLinks:     socialLinks,
+		SetSocialLinks:  args.SocialLinks != nil,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return Changelog{}, errNoChangelog
+		}
+		return Changelog{}, formatUnqueConstraint(err)
+	}
+
+	if setDomain && oldDomain.V() != args.Domain.String() {
+		err = syncPrimaryCustomDomain(ctx, q, wID, cID, oldDomain, args.Domain.NullString())
+		if err != nil {
+			return Changelog{}, err
+		}
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return Changelog{}, err
+	}
+	if args.Subdomain.IsValid() {
+		s.invalidateSubdomains()
+	}
+	return s.GetChangelog(ctx, wID, cID)
+}
+
+// Replaces the copy of the primary domain in changelog_custom_domains, so the old domain stops serving the changelog.
+// The new domain starts out unverified.
+func syncPrimaryCustomDomain(ctx context.Context, q *Queries, wID WorkspaceID, cID ChangelogID, oldDomain, newDomain apitypes.NullString) error {
+	if oldDomain.IsValid() {
+		err := q.deleteCustomDomainByDomain(ctx, deleteCustomDomainByDomainParams{
+			WorkspaceID: wID.String(),
+			ChangelogID: cID.String(),
+			Domain:      oldDomain.V(),
+		})
+		if err != nil {
+			return err
+		}
+	}
+	if newDomain.IsValid() {
+		n, err := q.upsertPrimaryCustomDomain(ctx, upsertPrimaryCustomDomainParams{
+			ID:          newID(custom_domain_prefix),
+			WorkspaceID: wID.String(),
+			ChangelogID: cID.String(),
+			Domain:      newDomain.V(),
+		})
+		if err != nil {
+			return err
+		}
+		// another changelog already serves the domain
+		if n == 0 {
+			return errDomainTaken
+		}
+	}
+	return nil
+}
+
+var errDomainTaken = errs.NewBadRequest(errors.New("domain already taken, please try again with a different one"))
+
+// If err is a unique constraint error, return humanized error message.
+// Otherwise return err
+func formatUnqueConstraint(err error) error {
+	if strings.Contains(err.Error(), "UNIQUE constraint failed: changelogs.subdomain") {
+		return errs.NewBadRequest(errors.New("subdomain already taken, please try again with a different one"))
+	}
+	if strings.Contains(err.Error(), "UNIQUE constraint failed: changelogs.domain") ||
+		strings.Contains(err.Error(), "UNIQUE constraint failed: changelog_custom_domains.domain") {
+		return errDomainTaken
+	}
+	if strings.Contains(err.Error(), "UNIQUE constraint failed: changelogs.slug") {
+		return errs.NewBadRequest(errors.New("slug already taken, please try again with a different one"))
+	}
+	if strings.Contains(err.Error(), "UNIQUE constraint failed: workspaces.name") {
+		return errs.NewBadRequest(errors.New("workspace name already taken, please try again with a different one"))
+	}
+	return err
+}
+
+func (s *sqlite) DeleteChangelog(ctx context.Context, wID WorkspaceID, cID ChangelogID) error {
+	err := s.q.deleteChangelog(ctx, deleteChangelogParams{
+		WorkspaceID: wID.String(),
+		ID:          cID.String(),
+	})
+	if err != nil {
+		return err
+	}
+	s.invalidateSubdomains()
+	return nil
+}
+
+func (s *sqlite) SetChangelogGHSource(ctx context.Context, wID WorkspaceID, cID ChangelogID, ghID GHSourceID) error {
+	return s.q.setChangelogSource(ctx, setChangelogSourceParams{
+		SourceID:    apitypes.NewString(ghID.String()),
+		WorkspaceID: wID.String(),
+		ID:          cID.String(),
+	})
+}
+
+func (s *sqlite) DeleteChangelogSource(ctx context.Context, wID WorkspaceID, cID ChangelogID) error {
+	return s.q.deleteChangelogSource(ctx, deleteChangelogSourceParams{
+		WorkspaceID: wID.String(),
+		ID:          cID.String(),
+	})
+}
+
+// how long downloading and storing a favicon may take
+const favicon_download_timeout = 30 * time.Second
+
+func (s *sqlite) SetChangelogFaviconFromURL(ctx context.Context, wID WorkspaceID, cID ChangelogID, faviconURL string) error {
+	err := validateImageURL(faviconURL)
+	if err != nil {
+		return err
+	}
+	_, err = s.q.getChangelogHost(ctx, getChangelogHostParams{
+		WorkspaceID: wID.String(),
+		ID:          cID.String(),
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return errNoChangelog
+		}
+		return err
+	}
+
+	// the download outlives the request, so it can't use its context
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), favicon_download_timeout)
+		defer cancel()
+		err := s.storeFavicon(ctx, wID, cID, faviconURL)
+		if err != nil {
+			slog.Error("failed to store favicon", slog.String("cid", cID.String()), xlog.ErrAttr(err))
+		}
+	}()
+	return nil
+}
+
+// Downloads the favicon and replaces the favicon asset of the changelog with it.
+func (s *sqlite) storeFavicon(ctx context.Context, wID WorkspaceID, cID ChangelogID, faviconURL string) error {
+	data, contentType, err := downloadImage(ctx, faviconURL, max_favicon_size)
+	if err != nil {
+		return err
+	}
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	q := s.q.WithTx(tx)
+
+	// only keep the latest favicon
+	err = q.deleteChangelogAssetsByType(ctx, deleteChangelogAssetsByTypeParams{
+		WorkspaceID: wID.String(),
+		ChangelogID: cID.String(),
+		AssetType:   string(AssetFavicon),
+	})
+	if err != nil {
+		return err
+	}
+
+	assetID := newID(asset_prefix)
+	err = q.createChangelogAsset(ctx, createChangelogAssetParams{
+		ID:          assetID,
+		WorkspaceID: wID.String(),
+		ChangelogID: cID.String(),
+		AssetType:   string(AssetFavicon),
+		ContentType: contentType,
+		Data:        data,
+	})
+	if err != nil {
+		if strings.Contains(err.Error(), "FOREIGN KEY constraint failed") {
+			return errNoChangelog
+		}
+		return err
+	}
+
+	n, err := q.setChangelogFaviconSrc(ctx, setChangelogFaviconSrcParams{
+		FaviconSrc:  apitypes.NewString(AssetPath(assetID)),
+		WorkspaceID: wID.String(),
+		ID:          cID.String(),
+	})
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return errNoChangelog
+	}
+	return tx.Commit()
+}
+
+var errNoAsset = errs.NewError(errs.ErrNotFound, errors.New("asset not found"))
+
+func (s *sqlite) GetChangelogAsset(ctx context.Context, assetID string) (ChangelogAsset, error) {
+	a, err := s.q.getChangelogAsset(ctx, assetID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ChangelogAsset{}, errNoAsset
+		}
+		return ChangelogAsset{}, err
+	}
+	return ChangelogAsset{
+		ID:          a.ID,
+		WorkspaceID: WorkspaceID(a.WorkspaceID),
+		ChangelogID: ChangelogID(a.ChangelogID),
+		Type:        AssetType(a.AssetType),
+		ContentType: a.ContentType,
+		Data:        a.Data,
+		CreatedAt:   time.Unix(a.CreatedAt, 0),
+	}, nil
+}
+
+func (s *sqlite) SetChangelogOGImage(ctx context.Context, wID WorkspaceID, cID ChangelogID, image []byte) error {
+	etag, err := parseOGImage(image)
+	if err != nil {
+		return err
+	}
+	err = s.q.setChangelogOGImage(ctx, setChangelogOGImageParams{
+		WorkspaceID: wID.String(),
+		ChangelogID: cID.String(),
+		Image:       image,
+		Etag:        etag,
+	})
+	if err != nil && strings.Contains(err.Error(), "FOREIGN KEY constraint failed") {
+		return errNoChangelog
+	}
+	return err
+}
+
+func (s *sqlite) GetChangelogOGImage(ctx context.Context, wID WorkspaceID, cID ChangelogID) ([]byte, string, time.Time, error) {
+	img, err := s.q.getChangelogOGImage(ctx, getChangelogOGImageParams{
+		WorkspaceID: wID.String(),
+		ChangelogID: cID.String(),
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, "", time.Time{}, errs.NewError(errs.ErrNotFound, errors.New("og image not found"))
+		}
+		return nil, "", time.Time{}, err
+	}
+	return img.Image, img.Etag, time.Unix(img.GeneratedAt, 0), nil
+}
+
+func (s *sqlite) GetContentHash(ctx context.Context, wID WorkspaceID, cID ChangelogID) (string, error) {
+	hash, err := s.q.getChangelogContentHash(ctx, getChangelogContentHashParams{
+		WorkspaceID: wID.String(),
+		ChangelogID: cID.String(),
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", nil
+	}
+	return hash, err
+}
+
+func (s *sqlite) SetContentHash(ctx context.Context, wID WorkspaceID, cID ChangelogID, hash string) error {
+	err := validateContentHash(hash)
+	if err != nil {
+		return err
+	}
+	err = s.q.setChangelogContentHash(ctx, setChangelogContentHashParams{
+		WorkspaceID: wID.String(),
+		ChangelogID: cID.String(),
+		Hash:        hash,
+	})
+	if err != nil && strings.Contains(err.Error(), "FOREIGN KEY constraint failed") {
+		return errNoChangelog
+	}
+	return err
+}
+
+func (s *sqlite) GetChangelogSEOScore(ctx context.Context, wID WorkspaceID, cID ChangelogID) (SEOScore, error) {
+	row, err := s.q.getChangelogSEOSignals(ctx, getChangelogSEOSignalsParams{
+		WorkspaceID: wID.String(),
+		ID:          cID.String(),
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return SEOScore{}, errNoChangelog
+		}
+		return SEOScore{}, err
+	}
+	return row.toSEOScore(), nil
+}
+
+func (s *sqlite) SaveWorkspace(ctx context.Context, ws Workspace) (Workspace, error) {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return Workspace{}, err
+	}
+	defer tx.Rollback()
+	q := s.q.WithTx(tx)
+
+	c, err := q.saveWorkspace(ctx, saveWorkspaceParams{
+		ID:   ws.ID.String(),
+		Name: ws.Name,
+	})
+	if err != nil {
+		return Workspace{}, formatUnqueConstraint(err)
+	}
+
+	if ws.Token != "" {
+		err := q.createToken(ctx, createTokenParams{
+			ID:          apitypes.NewString(newID(api_key_prefix)),
+			Key:         ws.Token.String(),
+			WorkspaceID: ws.ID.String(),
+			ExpiresAt:   s.tokenExpiresAt(),
+		})
+		if err != nil {
+			return Workspace{}, err
+		}
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return Workspace{}, err
+	}
+
+	return Workspace{
+		ID:        WorkspaceID(c.ID),
+		Name:      c.Name,
+		Token:     ws.Token,
+		CreatedAt: time.Unix(c.CreatedAt, 0),
+	}, nil
+}
+
+func (s *sqlite) GetOrCreateWorkspace(ctx context.Context, name string) (Workspace, bool, error) {
+	if name == "" {
+		return Workspace{}, false, errs.NewBadRequest(errors.New("workspace name is required"))
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return Workspace{}, false, err
+	}
+	defer tx.Rollback()
+	q := s.q.WithTx(tx)
+
+	n, err := q.createWorkspaceIfNotExists(ctx, createWorkspaceIfNotExistsParams{
+		ID:   NewWID().String(),
+		Name: name,
+	})
+	if err != nil {
+		return Workspace{}, false, err
+	}
+	created := n > 0
+
+	w, err := q.getWorkspaceByName(ctx, name)
+	if err != nil {
+		return Workspace{}, false, err
+	}
+
+	var token Token
+	if created {
+		token = NewToken()
+		err = q.createToken(ctx, createTokenParams{
+			ID:          apitypes.NewString(newID(api_key_prefix)),
+			Key:         token.String(),
+			WorkspaceID: w.ID,
+			ExpiresAt:   s.tokenExpiresAt(),
+		})
+		if err != nil {
+			return Workspace{}, false, err
+		}
+	} else {
+		key, err := q.getTokenByWorkspace(ctx, w.ID)
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			return Workspace{}, false, err
+		}
+		token = Token(key)
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return Workspace{}, false, err
+	}
+
+	return Workspace{
+		ID:        WorkspaceID(w.ID),
+		Name:      w.Name,
+		Token:     token,
+		CreatedAt: time.Unix(w.CreatedAt, 0),
+	}, created, nil
+}
+
+func (s *sqlite) GetWorkspace(ctx context.Context, wID WorkspaceID) (Workspace, error) {
+	row, err := s.q.getWorkspace(ctx, wID.String())
+	if err != nil {
+		return Workspace{}, err
+	}
+	return Workspace{
+		ID:        WorkspaceID(row.workspace.ID),
+		Name:      row.workspace.Name,
+		Token:     Token(row.token.Key),
+		CreatedAt: time.Unix(row.workspace.CreatedAt, 0),
+	}, nil
+}
+
+func (s *sqlite) GetWorkspaceByChangelog(ctx context.Context, wID WorkspaceID, cID ChangelogID) (Workspace, error) {
+	row, err := s.q.getWorkspaceByChangelog(ctx, getWorkspaceByChangelogParams{
+		WorkspaceID: wID.String(),
+		ID:          cID.String(),
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return Workspace{}, errNoChangelog
+		}
+		return Workspace{}, err
+	}
+	return Workspace{
+		ID:        WorkspaceID(row.workspace.ID),
+		Name:      row.workspace.Name,
+		CreatedAt: time.Unix(row.workspace.CreatedAt, 0),
+	}, nil
+}
+
+func (s *sqlite) GetWorkspaceBillingInfo(ctx context.Context, wID WorkspaceID) (BillingInfo, error) {
+	row, err := s.q.getWorkspaceBilling(ctx, wID.String())
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return BillingInfo{}, errs.NewError(errs.ErrNotFound, errors.New("billing info not found"))
+		}
+		return BillingInfo{}, err
+	}
+
+	info := BillingInfo{
+		CustomerID:         row.CustomerID,
+		SubscriptionID:     row.SubscriptionID.V(),
+		SubscriptionStatus: row.SubscriptionStatus.V(),
+	}
+	if row.CurrentPeriodEnd.Valid {
+		info.CurrentPeriodEnd = time.Unix(row.CurrentPeriodEnd.Int64, 0)
+	}
+	return info, nil
+}
+
+func (s *sqlite) UpdateWorkspaceBillingInfo(ctx context.Context, wID WorkspaceID, info BillingInfo) error {
+	if info.CustomerID == "" {
+		return errs.NewBadRequest(errors.New("customer id is required"))
+	}
+
+	var periodEnd sql.NullInt64
+	if !info.CurrentPeriodEnd.IsZero() {
+		periodEnd = sql.NullInt64{Int64: info.CurrentPeriodEnd.Unix(), Valid: true}
+	}
+
+	err := s.q.upsertWorkspaceBilling(ctx, upsertWorkspaceBillingParams{
+		WorkspaceID:        wID.String(),
+		CustomerID:         info.CustomerID,
+		SubscriptionID:     apitypes.NewString(info.SubscriptionID),
+		SubscriptionStatus: apitypes.NewString(info.SubscriptionStatus),
+		CurrentPeriodEnd:   periodEnd,
+	})
+	if err != nil && strings.Contains(err.Error(), "FOREIGN KEY constraint failed") {
+		return errNoWorkspace
+	}
+	return err
+}
+
+func (s *sqlite) RecordEmailDelivery(ctx context.Context, d EmailDelivery) error {
+	err := d.validate()
+	if err != nil {
+		return err
+	}
+	if d.ID == "" {
+		d.ID = newID(email_delivery_prefix)
+	}
+	if d.SentAt.IsZero() {
+		d.SentAt = time.Now()
+	}
+
+	n, err := s.q.upsertEmailDelivery(ctx, upsertEmailDeliveryParams{
+		ID:          d.ID,
+		WorkspaceID: d.WorkspaceID.String(),
+		ChangelogID: d.ChangelogID.String(),
+		ToEmail:     d.ToEmail,
+		Subject:     d.Subject,
+		Template:    d.Template,
+		SentAt:      d.SentAt.Unix(),
+		Status:      string(d.Status),
+		Error:       apitypes.NewString(d.Error),
+	})
+	if err != nil {
+		if strings.Contains(err.Error(), "FOREIGN KEY constraint failed") {
+			return errNoChangelog
+		}
+		return err
+	}
+	// the id belongs to a delivery of another workspace
+	if n == 0 {
+		return errs.NewBadRequest(errors.New("email delivery id already taken"))
+	}
+	return nil
+}
+
+func (s *sqlite) GetEmailDelivery(ctx context.Context, wID WorkspaceID, id string) (EmailDelivery, error) {
+	row, err := s.q.getEmailDelivery(ctx, getEmailDeliveryParams{
+		WorkspaceID: wID.String(),
+		ID:          id,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return EmailDelivery{}, errNoEmailDelivery
+		}
+		return EmailDelivery{}, err
+	}
+	return row.toExported(), nil
+}
+
+func (s *sqlite) ListEmailDeliveries(ctx context.Context, wID WorkspaceID, cID ChangelogID, page, pageSize int) ([]EmailDelivery, error) {
+	if page < 1 || pageSize < 1 {
+		return nil, errs.NewError(errs.ErrBadRequest, errors.New("page and page size must be greater than 0"))
+	}
+
+	rows, err := s.q.listEmailDeliveries(ctx, listEmailDeliveriesParams{
+		WorkspaceID: wID.String(),
+		ChangelogID: cID.String(),
+		Limit:       int64(pageSize),
+		Offset:      int64((page - 1) * pageSize),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]EmailDelivery, len(rows))
+	for i, r := range rows {
+		res[i] = r.toExported()
+	}
+	return res, nil
+}
+
+func (s *sqlite) GetWorkspaceWebhookSecret(ctx context.Context, wID WorkspaceID) (string, error) {
+	encrypted, err := s.q.getWorkspaceWebhookSecret(ctx, wID.String())
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", errNoWorkspace
+		}
+		return "", err
+	}
+	if !encrypted.IsValid() {
+		return "", errs.NewError(errs.ErrNotFound, errors.New("webhook secret not set"))
+	}
+	return s.decryptString(encrypted.V())
+}
+
+func (s *sqlite) RotateWorkspaceWebhookSecret(ctx context.Context, wID WorkspaceID) (string, error) {
+	secret, err := newSecretToken()
+	if err != nil {
+		return "", err
+	}
+	encrypted, err := s.encryptString(secret)
+	if err != nil {
+		return "", err
+	}
+
+	n, err := s.q.setWorkspaceWebhookSecret(ctx, setWorkspaceWebhookSecretParams{
+		WebhookSecret: apitypes.NewString(encrypted),
+		ID:            wID.String(),
+	})
+	if err != nil {
+		return "", err
+	}
+	if n == 0 {
+		return "", errNoWorkspace
+	}
+	return secret, nil
+}
+
+func (s *sqlite) GetEmailSettings(ctx context.Context, wID WorkspaceID) (EmailSettings, error) {
+	row, err := s.q.getWorkspaceEmailSettings(ctx, wID.String())
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return EmailSettings{}, errNoEmailSettings
+		}
+		return EmailSettings{}, err
+	}
+
+	settings := EmailSettings{
+		FromEmail: row.FromEmail,
+		FromName:  row.FromName.V(),
+		SMTPHost:  row.SmtpHost,
+		SMTPPort:  int(row.SmtpPort),
+		SMTPUser:  row.SmtpUser.V(),
+		ReplyTo:   row.ReplyTo.V(),
+	}
+	if len(row.SmtpPassEnc) > 0 {
+		pass, err := decrypt(s.opts.EncryptionKey, row.SmtpPassEnc)
+		if err != nil {
+			return EmailSettings{}, err
+		}
+		settings.SMTPPassword = string(pass)
+	}
+	return settings, nil
+}
+
+func (s *sqlite) SetEmailSettings(ctx context.Context, wID WorkspaceID, settings EmailSettings) error {
+	settings, err := settings.parse()
+	if err != nil {
+		return err
+	}
+
+	var pass []byte
+	if settings.SMTPPassword != "" {
+		pass, err = encrypt(s.opts.EncryptionKey, []byte(settings.SMTPPassword))
+		if err != nil {
+			return err
+		}
+	}
+
+	err = s.q.upsertWorkspaceEmailSettings(ctx, upsertWorkspaceEmailSettingsParams{
+		WorkspaceID: wID.String(),
+		FromEmail:   settings.FromEmail,
+		FromName:    apitypes.NewString(settings.FromName),
+		SmtpHost:    settings.SMTPHost,
+		SmtpPort:    int64(settings.SMTPPort),
+		SmtpUser:    apitypes.NewString(settings.SMTPUser),
+		SmtpPassEnc: pass,
+		ReplyTo:     apitypes.NewString(settings.ReplyTo),
+	})
+	if err != nil && strings.Contains(err.Error(), "FOREIGN KEY constraint failed") {
+		return errNoWorkspace
+	}
+	return err
+}
+
+func (s *sqlite) GetSSOConfig(ctx context.Context, wID WorkspaceID) (SSOConfig, error) {
+	row, err := s.q.getWorkspaceSSO(ctx, wID.String())
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return SSOConfig{}, errNoSSOConfig
+		}
+		return SSOConfig{}, err
+	}
+
+	cfg := SSOConfig{
+		Provider:    SSOProvider(row.Provider),
+		IssuerURL:   row.IssuerUrl,
+		ClientID:    row.ClientID.V(),
+		Certificate: row.Certificate.V(),
+		Enabled:     row.Enabled == 1,
+		CreatedAt:   time.Unix(row.CreatedAt, 0),
+	}
+	if len(row.ClientSecretEnc) > 0 {
+		secret, err := decrypt(s.opts.EncryptionKey, row.ClientSecretEnc)
+		if err != nil {
+			return SSOConfig{}, err
+		}
+		cfg.ClientSecret = string(secret)
+	}
+	return cfg, nil
+}
+
+func (s *sqlite) SetSSOConfig(ctx context.Context, wID WorkspaceID, cfg SSOConfig) error {
+	err := cfg.validate()
+	if err != nil {
+		return err
+	}
+
+	var secret []byte
+	if cfg.ClientSecret != "" {
+		secret, err = encrypt(s.opts.EncryptionKey, []byte(cfg.ClientSecret))
+		if err != nil {
+			return err
+		}
+	}
+
+	err = s.q.upsertWorkspaceSSO(ctx, upsertWorkspaceSSOParams{
+		WorkspaceID:     wID.String(),
+		Provider:        string(cfg.Provider),
+		IssuerUrl:       cfg.IssuerURL,
+		ClientID:        apitypes.NewString(cfg.ClientID),
+		ClientSecretEnc: secret,
+		Certificate:     apitypes.NewString(cfg.Certificate),
+		Enabled:         boolToInt(cfg.Enabled),
+	})
+	if err != nil && strings.Contains(err.Error(), "FOREIGN KEY constraint failed") {
+		return errNoWorkspace
+	}
+	return err
+}
+
+func (s *sqlite) DeleteSSOConfig(ctx context.Context, wID WorkspaceID) error {
+	return s.q.deleteWorkspaceSSO(ctx, wID.String())
+}
+
+func (s *sqlite) GetWorkspaceQuotaUsage(ctx context.Context, wID WorkspaceID) (QuotaUsage, error) {
+	row, err := s.q.getWorkspaceQuotaUsage(ctx, wID.String())
+	if err != nil {
+		return QuotaUsage{}, err
+	}
+	return QuotaUsage{
+		Changelogs:   row.Changelogs,
+		GHSources:    row.GhSources,
+		StorageBytes: row.StorageBytes,
+	}, nil
+}
+
+// Returns when a token created now expires, null if tokens don't expire.
+func (s *sqlite) tokenExpiresAt() sql.NullInt64 {
+	if s.opts.TokenTTL <= 0 {
+		return sql.NullInt64{}
+	}
+	return sql.NullInt64{Int64: time.Now().Add(s.opts.TokenTTL).Unix(), Valid: true}
+}
+
+func (s *sqlite) GetWorkspaceIDByToken(ctx context.Context, token string) (WorkspaceID, error) {
+	row, err := s.q.getToken(ctx, token)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", errs.NewError(errs.ErrUnauthorized, errors.New("invalid bearer token"))
+		}
+		return "", err
+	}
+	if row.ExpiresAt.Valid && row.ExpiresAt.Int64 < time.Now().Unix() {
+		return "", errs.NewError(errs.ErrUnauthorized, errors.New("bearer token expired"))
+	}
+	// last_used_at is informational, it doesn't have to be written on every request
+	if !row.LastUsedAt.Valid || time.Since(time.Unix(row.LastUsedAt.Int64, 0)) > token_touch_interval {
+		err = s.q.touchToken(ctx, token)
+		if err != nil {
+			slog.Error("failed to update last used at of token", slog.String("wid", row.WorkspaceID), xlog.ErrAttr(err))
+		}
+	}
+	return WorkspaceID(row.WorkspaceID), nil
+}
+
+func (s *sqlite) ListAPIKeys(ctx context.Context, wID WorkspaceID) ([]APIKey, error) {
+	rows, err := s.q.listTokens(ctx, wID.String())
+	if err != nil {
+		return nil, err
+	}
+
+	keys := make([]APIKey, len(rows))
+	for i, r := range rows {
+		keys[i] = APIKey{
+			ID:        r.ID.V(),
+			Key:       Token(r.Key).Masked(),
+			Label:     r.Label.V(),
+			CreatedAt: time.Unix(r.CreatedAt, 0),
+		}
+		if r.ExpiresAt.Valid {
+			keys[i].ExpiresAt = time.Unix(r.ExpiresAt.Int64, 0)
+		}
+		if r.LastUsedAt.Valid {
+			keys[i].LastUsedAt = time.Unix(r.LastUsedAt.Int64, 0)
+		}
+	}
+	return keys, nil
+}
+
+func (s *sqlite) RevokeAPIKey(ctx context.Context, wID WorkspaceID, id string) error {
+	n, err := s.q.deleteToken(ctx, deleteTokenParams{
+		WorkspaceID: wID.String(),
+		ID:          apitypes.NewString(id),
+	})
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return errs.NewError(errs.ErrNotFound, errors.New("api key not found"))
+	}
+	return nil
+}
+
+func (s *sqlite) SetTokenScopes(ctx context.Context, wID WorkspaceID, tokenKey string, scopes []string) error {
+	encoded, err := encodeScopes(scopes)
+	if err != nil {
+		return err
+	}
+
+	n, err := s.q.setTokenScopes(ctx, setTokenScopesParams{
+		Scopes:      apitypes.NewString(encoded),
+		WorkspaceID: wID.String(),
+		Key:         tokenKey,
+	})
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return errs.NewError(errs.ErrNotFound, errors.New("token not found"))
+	}
+	return nil
+}
+
+func (s *sqlite) GetTokenScopes(ctx context.Context, token string) ([]string, error) {
+	row, err := s.q.getToken(ctx, token)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errs.NewError(errs.ErrUnauthorized, errors.New("invalid bearer token"))
+		}
+		return nil, err
+	}
+
+	scopes, err := decodeScopes(row.Scopes.V())
+	if err != nil {
+		return nil, errs.NewInternalError(err)
+	}
+	return scopes, nil
+}
+
+func (s *sqlite) RecordAPIRequest(ctx context.Context, wID WorkspaceID, tokenKey, endpoint string) error {
+	err := s.q.createAPIRequest(ctx, createAPIRequestParams{
+		WorkspaceID: wID.String(),
+		TokenKey:    apitypes.NewString(tokenKey),
+		Endpoint:    endpoint,
+		RequestedAt: time.Now().Unix(),
+	})
+	if err != nil && strings.Contains(err.Error(), "FOREIGN KEY constraint failed") {
+		return errs.NewError(errs.ErrNotFound, errors.New("workspace or token not found"))
+	}
+	return err
+}
+
+func (s *sqlite) GetAPIUsage(ctx context.Context, wID WorkspaceID, from, to time.Time) (APIUsage, error) {
+	rows, err := s.q.getAPIUsage(ctx, getAPIUsageParams{
+		WorkspaceID: wID.String(),
+		FromTime:    from.Unix(),
+		ToTime:      to.Unix(),
+	})
+	if err != nil {
+		return APIUsage{}, err
+	}
+
+	usage := APIUsage{ByEndpoint: make(map[string]int64, len(rows))}
+	for _, r := range rows {
+		usage.TotalRequests += r.Count
+		usage.ByEndpoint[r.Endpoint] = r.Count
+	}
+	return usage, nil
+}
+
+func (s *sqlite) PurgeAPIRequests(ctx context.Context, before time.Time) error {
+	return s.q.purgeAPIRequests(ctx, before.Unix())
+}
+
+func (s *sqlite) GetWorkspaceToken(ctx context.Context, wID WorkspaceID) (Token, error) {
+	key, err := s.q.getTokenByWorkspace(ctx, wID.String())
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", errs.NewError(errs.ErrNotFound, errors.New("token not found"))
+		}
+		return "", err
+	}
+	return Token(key), nil
+}
+
+func (s *sqlite) DeleteWorkspace(ctx context.Context, wID WorkspaceID) error {
+	err := s.q.deleteWorkspace(ctx, wID.String())
+	if err != nil {
+		return err
+	}
+	// the changelogs of the workspace are deleted with it
+	s.invalidateSubdomains()
+	return nil
+}
+
+func (s *sqlite) ListWorkspacesWithExpiredTokens(ctx context.Context, before time.Time) ([]WorkspaceID, error) {
+	rows, err := s.q.listWorkspacesWithExpiredTokens(ctx, sql.NullInt64{Int64: before.Unix(), Valid: true})
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]WorkspaceID, len(rows))
+	for i, r := range rows {
+		ids[i] = WorkspaceID(r)
+	}
+	return ids, nil
+}
+
+func (s *sqlite) PurgeExpiredTokens(ctx context.Context, before time.Time) (int64, error) {
+	return s.q.purgeExpiredTokens(ctx, sql.NullInt64{Int64: before.Unix(), Valid: true})
+}
+
+func (s *sqlite) CreateInvite(ctx context.Context, wID WorkspaceID, email, role string, ttl time.Duration) (string, error) {
+	email, err := parseInvite(email, role)
+	if err != nil {
+		return "", err
+	}
+	token, err := newSecretToken()
+	if err != nil {
+		return "", err
+	}
+
+	err = s.q.createWorkspaceInvite(ctx, createWorkspaceInviteParams{
+		TokenHash:   hashSecretToken(token),
+		WorkspaceID: wID.String(),
+		Email:       email,
+		Role:        role,
+		ExpiresAt:   time.Now().Add(ttl).Unix(),
+	})
+	if err != nil {
+		if strings.Contains(err.Error(), "FOREIGN KEY constraint failed") {
+			return "", errNoWorkspace
+		}
+		return "", err
+	}
+	return token, nil
+}
+
+func (s *sqlite) AcceptInvite(ctx context.Context, token string) (WorkspaceID, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return "", err
+	}
+	defer tx.Rollback()
+	q := s.q.WithTx(tx)
+
+	invite, err := q.getWorkspaceInvite(ctx, hashSecretToken(token))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", errInvalidInvite
+		}
+		return "", err
+	}
+	if invite.AcceptedAt.Valid {
+		return "", errs.NewError(errs.ErrUnauthorized, errors.New("invite already accepted"))
+	}
+	if invite.ExpiresAt < time.Now().Unix() {
+		return "", errs.NewError(errs.ErrUnauthorized, errors.New("invite expired"))
+	}
+
+	err = q.acceptWorkspaceInvite(ctx, invite.TokenHash)
+	if err != nil {
+		return "", err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return "", err
+	}
+	return WorkspaceID(invite.WorkspaceID), nil
+}
+
+func (s *sqlite) RevokeInvite(ctx context.Context, wID WorkspaceID, token string) error {
+	n, err := s.q.deleteWorkspaceInvite(ctx, deleteWorkspaceInviteParams{
+		WorkspaceID: wID.String(),
+		TokenHash:   hashSecretToken(token),
+	})
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return errs.NewError(errs.ErrNotFound, errors.New("invite not found"))
+	}
+	return nil
+}
+
+func (s *sqlite) AddWorkspaceMember(ctx context.Context, wID WorkspaceID, userID string, role MemberRole) (WorkspaceMember, error) {
+	role, err := ParseMemberRole(role.String())
+	if err != nil {
+		return WorkspaceMember{}, err
+	}
+
+	m, err := s.q.addWorkspaceMember(ctx, addWorkspaceMemberParams{
+		WorkspaceID: wID.String(),
+		UserID:      userID,
+		Role:        role.String(),
+	})
+	if err != nil {
+		if strings.Contains(err.Error(), "FOREIGN KEY constraint failed") {
+			return WorkspaceMember{}, errNoWorkspace
+		}
+		if strings.Contains(err.Error(), "UNIQUE constraint failed") {
+			return WorkspaceMember{}, errs.NewBadRequest(errors.New("user is already a member of the workspace"))
+		}
+		return WorkspaceMember{}, err
+	}
+	return m.toExported(), nil
+}
+
+func (s *sqlite) RemoveWorkspaceMember(ctx context.Context, wID WorkspaceID, userID string) error {
+	n, err := s.q.deleteWorkspaceMember(ctx, deleteWorkspaceMemberParams{
+		WorkspaceID: wID.String(),
+		UserID:      userID,
+	})
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return errNoMember
+	}
+	return nil
+}
+
+func (s *sqlite) GetWorkspaceMember(ctx context.Context, wID WorkspaceID, userID string) (WorkspaceMember, error) {
+	m, err := s.q.getWorkspaceMember(ctx, getWorkspaceMemberParams{
+		WorkspaceID: wID.String(),
+		UserID:      userID,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return WorkspaceMember{}, errNoMember
+		}
+		return WorkspaceMember{}, err
+	}
+	return m.toExported(), nil
+}
+
+func (s *sqlite) ListWorkspaceMembers(ctx context.Context, wID WorkspaceID) ([]WorkspaceMember, error) {
+	rows, err := s.q.listWorkspaceMembers(ctx, wID.String())
+	if err != nil {
+		return nil, err
+	}
+
+	members := make([]WorkspaceMember, len(rows))
+	for i, m := range rows {
+		members[i] = m.toExported()
+	}
+	return members, nil
+}
+
+func (s *sqlite) UpdateMemberRole(ctx context.Context, wID WorkspaceID, userID string, role MemberRole) error {
+	role, err := ParseMemberRole(role.String())
+	if err != nil {
+		return err
+	}
+
+	n, err := s.q.updateWorkspaceMemberRole(ctx, updateWorkspaceMemberRoleParams{
+		Role:        role.String(),
+		WorkspaceID: wID.String(),
+		UserID:      userID,
+	})
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return errNoMember
+	}
+	return nil
+}
+
+func (s *sqlite) CreateGHSource(ctx context.Context, gh GHSource) (GHSource, error) {
+	var privateKey []byte
+	if len(gh.PrivateKey) > 0 {
+		var err error
+		privateKey, err = encrypt(s.opts.EncryptionKey, gh.PrivateKey)
+		if err != nil {
+			return GHSource{}, err
+		}
+	}
+
+	row, err := s.q.createGHSource(ctx, createGHSourceParams{
+		WorkspaceID:    gh.WorkspaceID.String(),
+		ID:             gh.ID.String(),
+		Owner:          gh.Owner,
+		Repo:           gh.Repo,
+		Path:           gh.Path,
+		InstallationID: gh.InstallationID,
+		PrivateKeyID:   apitypes.NewString(gh.PrivateKeyID),
+		PrivateKeyBlob: privateKey,
+	})
+	if err != nil {
+		return GHSource{}, err
+	}
+	created := row.toExported()
+	created.PrivateKey = gh.PrivateKey
+	return created, nil
+}
+
+func (s *sqlite) DeleteGHSource(ctx context.Context, wID WorkspaceID, ghID GHSourceID) error {
+	return s.q.deleteGHSource(ctx, deleteGHSourceParams{
+		WorkspaceID: wID.String(),
+		ID:          ghID.String(),
+	})
+}
+
+func (s *sqlite) GetGHSource(ctx context.Context, wID WorkspaceID, ghID GHSourceID) (GHSource, error) {
+	row, err := s.q.getGHSource(ctx, getGHSourceParams{
+		WorkspaceID: wID.String(),
+		ID:          ghID.String(),
+	})
+	if err != nil {
+		return GHSource{}, err
+	}
+	return s.decodeGHSource(row.ghSource, row.ConsecutiveFailures)
+}
+
+func (s *sqlite) GetGHSourceByChangelog(ctx context.Context, wID WorkspaceID, cID ChangelogID) (GHSource, error) {
+	row, err := s.q.getGHSourceByChangelog(ctx, getGHSourceByChangelogParams{
+		WorkspaceID: wID.String(),
+		ID:          cID.String(),
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return GHSource{}, errNoGHSource
+		}
+		return GHSource{}, err
+	}
+	return s.decodeGHSource(row.ghSource, row.ConsecutiveFailures)
+}
+
+// Converts the source to its exported form and decrypts its private key.
+func (s *sqlite) decodeGHSource(row ghSource, consecutiveFailures int64) (GHSource, error) {
+	gh := row.toExported()
+	gh.Degraded = consecutiveFailures >= gh_source_degraded_threshold
+	if len(row.PrivateKeyBlob) > 0 {
+		var err error
+		gh.PrivateKey, err = decrypt(s.opts.EncryptionKey, row.PrivateKeyBlob)
+		if err != nil {
+			return GHSource{}, err
+		}
+	}
+	return gh, nil
+}
+
+func (s *sqlite) ListGHSources(ctx context.Context, wID WorkspaceID) ([]GHSource, error) {
+	rows, err := s.q.listGHSources(ctx, wID.String())
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return make([]GHSource, 0), nil
+		}
+		return nil, err
+	}
+
+	sources := make([]GHSource, len(rows))
+	for i, row := range rows {
+		sources[i] = row.toExported()
+	}
+	return sources, nil
+}
+
+func (s *sqlite) ListGHSourcesNeedingRefresh(ctx context.Context, limit int) ([]GHSource, error) {
+	rows, err := s.q.listGHSourcesNeedingRefresh(ctx, int64(limit))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return make([]GHSource, 0), nil
+		}
+		return nil, err
+	}
+
+	sources := make([]GHSource, len(rows))
+	for i, row := range rows {
+		sources[i] = row.toExported()
+	}
+	return sources, nil
+}
+
+func (s *sqlite) ListOrphanedGHSources(ctx context.Context) ([]GHSource, error) {
+	rows, err := s.q.listOrphanedGHSources(ctx)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return make([]GHSource, 0), nil
+		}
+		return nil, err
+	}
+
+	sources := make([]GHSource, len(rows))
+	for i, row := range rows {
+		sources[i] = row.toExported()
+	}
+	return sources, nil
+}
+
+func (s *sqlite) UpdateGHSourceLastFetched(ctx context.Context, wID WorkspaceID, ghID GHSourceID) error {
+	return s.q.updateGHSourceLastFetched(ctx, updateGHSourceLastFetchedParams{
+		WorkspaceID: wID.String(),
+		ID:          ghID.String(),
+	})
+}
+
+// number of consecutive sync failures after which a source is reported as degraded
+const gh_source_degraded_threshold = 5
+
+func (s *sqlite) RecordGHSourceFailure(ctx context.Context, wID WorkspaceID, ghID GHSourceID, err error) error {
+	err = s.q.recordGHSourceFailure(ctx, recordGHSourceFailureParams{
+		WorkspaceID: wID.String(),
+		GhSourceID:  ghID.String(),
+		LastError:   apitypes.NewString(err.Error()),
+	})
+	if err != nil && strings.Contains(err.Error(), "FOREIGN KEY constraint failed") {
+		return errNoGHSource
+	}
+	return err
+}
+
+func (s *sqlite) RecordGHSourceSuccess(ctx context.Context, wID WorkspaceID, ghID GHSourceID) error {
+	err := s.q.recordGHSourceSuccess(ctx, recordGHSourceSuccessParams{
+		WorkspaceID: wID.String(),
+		GhSourceID:  ghID.String(),
+	})
+	if err != nil && strings.Contains(err.Error(), "FOREIGN KEY constraint failed") {
+		return errNoGHSource
+	}
+	return err
+}
+
+func (s *sqlite) GetGHSourceHealth(ctx context.Context, wID WorkspaceID, ghID GHSourceID) (GHSourceHealth, error) {
+	row, err := s.q.getGHSourceHealth(ctx, getGHSourceHealthParams{
+		WorkspaceID: wID.String(),
+		GhSourceID:  ghID.String(),
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			// a source which was never synced is healthy
+			if _, err := s.GetGHSource(ctx, wID, ghID); err != nil {
+				if errors.Is(err, sql.ErrNoRows) {
+					return GHSourceHealth{}, errNoGHSource
+				}
+				return GHSourceHealth{}, err
+			}
+			return GHSourceHealth{WorkspaceID: wID, GHSourceID: ghID}, nil
+		}
+		return GHSourceHealth{}, err
+	}
+	return row.ghSourceHealth.toExported(), nil
+}
+
+var errNoGHSource = errs.NewError(errs.ErrNotFound, errors.New("source not found"))
+
+func (s *sqlite) SetGHSourceWebhookSecret(ctx context.Context, wID WorkspaceID, ghID GHSourceID, secret string) error {
+	var encrypted apitypes.NullString
+	if secret != "" {
+		e, err := s.encryptString(secret)
+		if err != nil {
+			return err
+		}
+		encrypted = apitypes.NewString(e)
+	}
+
+	n, err := s.q.setGHSourceWebhookSecret(ctx, setGHSourceWebhookSecretParams{
+		WebhookSecret: encrypted,
+		WorkspaceID:   wID.String(),
+		ID:            ghID.String(),
+	})
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return errNoGHSource
+	}
+	return nil
+}
+
+const (
+	min_poll_interval_seconds = 60
+	max_poll_interval_seconds = 86400
+)
+
+func (s *sqlite) SetGHSourcePollInterval(ctx context.Context, wID WorkspaceID, ghID GHSourceID, seconds int) error {
+	if seconds < min_poll_interval_seconds || seconds > max_poll_interval_seconds {
+		return errs.NewBadRequest(fmt.Errorf("poll interval must be between %d and %d seconds", min_poll_interval_seconds, max_poll_interval_seconds))
+	}
+
+	n, err := s.q.setGHSourceFetchInterval(ctx, setGHSourceFetchIntervalParams{
+		FetchIntervalSeconds: int64(seconds),
+		WorkspaceID:          wID.String(),
+		ID:                   ghID.String(),
+	})
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return errNoGHSource
+	}
+	return nil
+}
+
+func (s *sqlite) GetGHSourceWebhookSecret(ctx context.Context, wID WorkspaceID, ghID GHSourceID) (string, error) {
+	encrypted, err := s.q.getGHSourceWebhookSecret(ctx, getGHSourceWebhookSecretParams{
+		WorkspaceID: wID.String(),
+		ID:          ghID.String(),
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", errNoGHSource
+		}
+		return "", err
+	}
+	if !encrypted.IsValid() {
+		return "", errs.NewError(errs.ErrNotFound, errors.New("webhook secret not set"))
+	}
+	return s.decryptString(encrypted.V())
+}
+
+const sync_log_prefix = "sl"
+
+func (l ghSyncLog) toExported() GHSyncLog {
+	return GHSyncLog{
+		ID:           l.ID,
+		WorkspaceID:  WorkspaceID(l.WorkspaceID),
+		GHSourceID:   GHSourceID(l.GhSourceID),
+		StartedAt:    time.Unix(l.StartedAt, 0),
+		FinishedAt:   time.Unix(l.FinishedAt, 0),
+		FilesFound:   int(l.FilesFound),
+		FilesChanged: int(l.FilesChanged),
+		Error:        l.Error.V(),
+	}
+}
+
+func (s *sqlite) AppendSyncLog(ctx context.Context, log GHSyncLog) error {
+	err := s.q.createSyncLog(ctx, createSyncLogParams{
+		ID:           newID(sync_log_prefix),
+		WorkspaceID:  log.WorkspaceID.String(),
+		GhSourceID:   log.GHSourceID.String(),
+		StartedAt:    log.StartedAt.Unix(),
+		FinishedAt:   log.FinishedAt.Unix(),
+		FilesFound:   int64(log.FilesFound),
+		FilesChanged: int64(log.FilesChanged),
+		Error:        apitypes.NewString(log.Error),
+	})
+	if err != nil && strings.Contains(err.Error(), "FOREIGN KEY constraint failed") {
+		return errNoGHSource
+	}
+	return err
+}
+
+func (s *sqlite) ListSyncLogs(ctx context.Context, wID WorkspaceID, ghID GHSourceID, limit int) ([]GHSyncLog, error) {
+	rows, err := s.q.listSyncLogs(ctx, listSyncLogsParams{
+		WorkspaceID: wID.String(),
+		GhSourceID:  ghID.String(),
+		Limit:       int64(limit),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	logs := make([]GHSyncLog, len(rows))
+	for i, r := range rows {
+		logs[i] = r.ghSyncLog.toExported()
+	}
+	return logs, nil
+}
+
+func (s *sqlite) PurgeSyncLogs(ctx context.Context, before time.Time) (int64, error) {
+	return s.q.purgeSyncLogs(ctx, before.Unix())
+}
+
+func (s *sqlite) GetCachedTree(ctx context.Context, wID WorkspaceID, ghID GHSourceID) ([]string, string, error) {
+	row, err := s.q.getCachedTree(ctx, getCachedTreeParams{
+		WorkspaceID: wID.String(),
+		GhSourceID:  ghID.String(),
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, "", errs.NewError(errs.ErrNotFound, errors.New("tree not cached"))
+		}
+		return nil, "", err
+	}
+
+	var files []string
+	err = json.Unmarshal(row.TreeJson, &files)
+	if err != nil {
+		return nil, "", err
+	}
+	return files, row.Etag, nil
+}
+
+func (s *sqlite) SetCachedTree(ctx context.Context, wID WorkspaceID, ghID GHSourceID, files []string, etag string) error {
+	tree, err := json.Marshal(files)
+	if err != nil {
+		return err
+	}
+	err = s.q.setCachedTree(ctx, setCachedTreeParams{
+		WorkspaceID: wID.String(),
+		GhSourceID:  ghID.String(),
+		TreeJson:    tree,
+		Etag:        etag,
+	})
+	if err != nil && strings.Contains(err.Error(), "FOREIGN KEY constraint failed") {
+		return errNoGHSource
+	}
+	return err
+}
+
+func (s *sqlite) GetFileCache(ctx context.Context, wID WorkspaceID, ghID GHSourceID) (map[string]string, error) {
+	rows, err := s.q.listCachedFiles(ctx, listCachedFilesParams{
+		WorkspaceID: wID.String(),
+		GhSourceID:  ghID.String(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	files := make(map[string]string, len(rows))
+	for _, r := range rows {
+		files[r.Path] = r.Sha
+	}
+	return files, nil
+}
+
+func (s *sqlite) SetFileCache(ctx context.Context, wID WorkspaceID, ghID GHSourceID, files map[string]string) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	q := s.q.WithTx(tx)
+
+	err = q.deleteCachedFiles(ctx, deleteCachedFilesParams{
+		WorkspaceID: wID.String(),
+		GhSourceID:  ghID.String(),
+	})
+	if err != nil {
+		return err
+	}
+
+	for path, sha := range files {
+		err = q.createCachedFile(ctx, createCachedFileParams{
+			WorkspaceID: wID.String(),
+			GhSourceID:  ghID.String(),
+			Path:        path,
+			Sha:         sha,
+		})
+		if err != nil {
+			if strings.Contains(err.Error(), "FOREIGN KEY constraint failed") {
+				return errNoGHSource
+			}
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+func (s *sqlite) ProcessGHPushPayload(ctx context.Context, wID WorkspaceID, ghID GHSourceID, addedFiles, modifiedFiles, removedFiles []string) ([]string, error) {
+	// the cached sha of a modified file is outdated, it's cached again once the file was fetched
+	stale := append(append([]string{}, modifiedFiles...), removedFiles...)
+	if len(stale) > 0 {
+		err := s.q.deleteCachedFilesByPath(ctx, deleteCachedFilesByPathParams{
+			WorkspaceID: wID.String(),
+			GhSourceID:  ghID.String(),
+			Paths:       stale,
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return pushedPaths(addedFiles, modifiedFiles, removedFiles), nil
 }
 
 func (s *sqlite) ListWorkspacesChangelogCount(ctx context.Context) ([]WorkspaceChangelogCount, error) {
@@ -385,11 +3202,486 @@ func (s *sqlite) ListWorkspacesChangelogCount(ctx context.Context) ([]WorkspaceC
 	for i, row := range rows {
 		res[i] = WorkspaceChangelogCount{
 			Workspace: Workspace{
-				ID:   WorkspaceID(row.workspace.ID),
-				Name: row.workspace.Name,
+				ID:        WorkspaceID(row.workspace.ID),
+				Name:      row.workspace.Name,
+				CreatedAt: time.Unix(row.workspace.CreatedAt, 0),
 			},
 			ChangelogCount: row.ChangelogCount,
 		}
 	}
 	return res, nil
 }
+
+// strftime formats of the supported growth stats granularities
+var growth_period_formats = map[string]string{
+	"day":   "%Y-%m-%d",
+	"week":  "%Y-W%W",
+	"month": "%Y-%m",
+}
+
+func (s *sqlite) GetWorkspaceGrowthStats(ctx context.Context, granularity string, from, to time.Time) ([]GrowthDataPoint, error) {
+	format, ok := growth_period_formats[granularity]
+	if !ok {
+		return nil, errs.NewError(errs.ErrBadRequest, errors.New("granularity must be one of day, week or month"))
+	}
+
+	rows, err := s.q.getWorkspaceGrowthStats(ctx, getWorkspaceGrowthStatsParams{
+		PeriodFormat: format,
+		FromTime:     from.Unix(),
+		ToTime:       to.Unix(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	points := make([]GrowthDataPoint, len(rows))
+	for i, r := range rows {
+		points[i] = GrowthDataPoint{
+			Period:        r.Period,
+			NewWorkspaces: r.NewWorkspaces,
+			NewChangelogs: r.NewChangelogs,
+		}
+	}
+	return points, nil
+}
+
+func (s *sqlite) GetWorkspaceCreationTimeline(ctx context.Context, granularity, from, to string) ([]TimelinePoint, error) {
+	// quarters are aggregated from the monthly growth stats
+	statsGranularity := granularity
+	switch granularity {
+	case "day", "month":
+	case "quarter":
+		statsGranularity = "month"
+	default:
+		return nil, errs.NewBadRequest(errors.New("granularity must be one of day, month or quarter"))
+	}
+
+	fromDate, err := time.Parse(time.DateOnly, from)
+	if err != nil {
+		return nil, errs.NewBadRequest(errors.New("from must be a date in the format YYYY-MM-DD"))
+	}
+	toDate, err := time.Parse(time.DateOnly, to)
+	if err != nil {
+		return nil, errs.NewBadRequest(errors.New("to must be a date in the format YYYY-MM-DD"))
+	}
+
+	// include the whole to day
+	stats, err := s.GetWorkspaceGrowthStats(ctx, statsGranularity, fromDate, toDate.AddDate(0, 0, 1).Add(-time.Second))
+	if err != nil {
+		return nil, err
+	}
+	return creationTimeline(stats, granularity == "quarter"), nil
+}
+
+// escapes the LIKE wildcards, so the prefix is matched literally
+var likeWildcardReplacer = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+
+func (s *sqlite) SearchWorkspaces(ctx context.Context, namePrefix string, limit int) ([]Workspace, error) {
+	rows, err := s.q.searchWorkspaces(ctx, searchWorkspacesParams{
+		NamePattern: likeWildcardReplacer.Replace(namePrefix) + "%",
+		MaxResults:  int64(limit),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]Workspace, len(rows))
+	for i, r := range rows {
+		res[i] = Workspace{
+			ID:        WorkspaceID(r.ID),
+			Name:      r.Name,
+			CreatedAt: time.Unix(r.CreatedAt, 0),
+		}
+	}
+	return res, nil
+}
+
+var errNoInstallation = errs.NewError(errs.ErrNotFound, errors.New("github installation not found"))
+
+func (s *sqlite) GetInstallationIDByWorkspace(ctx context.Context, wID WorkspaceID) (int64, error) {
+	id, err := s.q.getInstallationIDByWorkspace(ctx, wID.String())
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, errNoInstallation
+		}
+		return 0, err
+	}
+	return id, nil
+}
+
+func (s *sqlite) SetInstallationIDForWorkspace(ctx context.Context, wID WorkspaceID, installationID int64) error {
+	err := s.q.setInstallationIDForWorkspace(ctx, setInstallationIDForWorkspaceParams{
+		WorkspaceID:    wID.String(),
+		InstallationID: installationID,
+	})
+	if err != nil && strings.Contains(err.Error(), "UNIQUE constraint failed: workspace_gh_installations.installation_id") {
+		return errs.NewBadRequest(errors.New("github installation is already linked to a different workspace"))
+	}
+	return err
+}
+
+// installation tokens which expire within this duration aren't returned, so they don't expire while in use
+const installation_token_expiry_margin = time.Minute
+
+func (s *sqlite) GetInstallationToken(ctx context.Context, installationID int64) (string, error) {
+	encrypted, err := s.q.getInstallationToken(ctx, getInstallationTokenParams{
+		InstallationID: installationID,
+		ExpiresAt:      time.Now().Add(installation_token_expiry_margin).Unix(),
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", errs.NewError(errs.ErrNotFound, errors.New("installation token not found"))
+		}
+		return "", err
+	}
+	return s.decryptString(encrypted)
+}
+
+func (s *sqlite) SetInstallationToken(ctx context.Context, installationID int64, token string, expiresAt time.Time) error {
+	encrypted, err := s.encryptString(token)
+	if err != nil {
+		return err
+	}
+	return s.q.setInstallationToken(ctx, setInstallationTokenParams{
+		InstallationID: installationID,
+		Token:          encrypted,
+		ExpiresAt:      expiresAt.Unix(),
+	})
+}
+
+func (l label) toExported() Label {
+	return Label{
+		WorkspaceID: WorkspaceID(l.WorkspaceID),
+		Name:        l.Name,
+		CreatedAt:   time.Unix(l.CreatedAt, 0),
+	}
+}
+
+func (s *sqlite) CreateLabel(ctx context.Context, wID WorkspaceID, name string) (Label, error) {
+	name, err := ParseLabel(name)
+	if err != nil {
+		return Label{}, err
+	}
+
+	l, err := s.q.createLabel(ctx, createLabelParams{
+		WorkspaceID: wID.String(),
+		Name:        name,
+	})
+	if err != nil {
+		if strings.Contains(err.Error(), "UNIQUE constraint failed: labels.workspace_id, labels.name") {
+			return Label{}, errs.NewBadRequest(errors.New("label already exists"))
+		}
+		return Label{}, err
+	}
+	return l.toExported(), nil
+}
+
+func (s *sqlite) DeleteLabel(ctx context.Context, wID WorkspaceID, name string) error {
+	name, err := ParseLabel(name)
+	if err != nil {
+		return err
+	}
+	return s.q.deleteLabel(ctx, deleteLabelParams{
+		WorkspaceID: wID.String(),
+		Name:        name,
+	})
+}
+
+func (s *sqlite) AttachLabelToEntry(ctx context.Context, wID WorkspaceID, cID ChangelogID, entryID string, label string) error {
+	name, err := ParseLabel(label)
+	if err != nil {
+		return err
+	}
+
+	err = s.q.attachLabelToEntry(ctx, attachLabelToEntryParams{
+		WorkspaceID: wID.String(),
+		ChangelogID: cID.String(),
+		EntryID:     entryID,
+		LabelName:   name,
+	})
+	if err != nil && strings.Contains(err.Error(), "FOREIGN KEY constraint failed") {
+		return errs.NewError(errs.ErrNotFound, errors.New("label or changelog not found"))
+	}
+	return err
+}
+
+func (s *sqlite) DetachLabelFromEntry(ctx context.Context, wID WorkspaceID, cID ChangelogID, entryID string, label string) error {
+	name, err := ParseLabel(label)
+	if err != nil {
+		return err
+	}
+	return s.q.detachLabelFromEntry(ctx, detachLabelFromEntryParams{
+		WorkspaceID: wID.String(),
+		ChangelogID: cID.String(),
+		EntryID:     entryID,
+		LabelName:   name,
+	})
+}
+
+func (s *sqlite) ListLabelsByEntry(ctx context.Context, wID WorkspaceID, cID ChangelogID, entryID string) ([]Label, error) {
+	rows, err := s.q.listLabelsByEntry(ctx, listLabelsByEntryParams{
+		WorkspaceID: wID.String(),
+		ChangelogID: cID.String(),
+		EntryID:     entryID,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return make([]Label, 0), nil
+		}
+		return nil, err
+	}
+
+	labels := make([]Label, len(rows))
+	for i, row := range rows {
+		labels[i] = row.toExported()
+	}
+	return labels, nil
+}
+
+func (s *sqlite) BulkUpdateEntryLabels(ctx context.Context, wID WorkspaceID, fromLabel, toLabel string) (int64, error) {
+	from, err := ParseLabel(fromLabel)
+	if err != nil {
+		return 0, err
+	}
+	to, err := ParseLabel(toLabel)
+	if err != nil {
+		return 0, err
+	}
+	if from == to {
+		return 0, errs.NewBadRequest(errors.New("labels must be different"))
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+	q := s.q.WithTx(tx)
+
+	exists, err := q.labelExists(ctx, labelExistsParams{
+		WorkspaceID: wID.String(),
+		Name:        to,
+	})
+	if err != nil {
+		return 0, err
+	}
+	if exists == 0 {
+		return 0, errs.NewBadRequest(fmt.Errorf("label %s doesn't exist, create it first", to))
+	}
+
+	moved, err := q.moveEntryLabels(ctx, moveEntryLabelsParams{
+		ToLabel:     to,
+		WorkspaceID: wID.String(),
+		FromLabel:   from,
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	// the remaining entries already had the new label
+	deleted, err := q.deleteEntryLabelsByLabel(ctx, deleteEntryLabelsByLabelParams{
+		WorkspaceID: wID.String(),
+		LabelName:   from,
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return 0, err
+	}
+	return moved + deleted, nil
+}
+
+func (s *sqlite) ListEntryIDsByLabels(ctx context.Context, wID WorkspaceID, cID ChangelogID, labels []string, mode LabelMatchMode) ([]string, error) {
+	names := make([]string, 0, len(labels))
+	for _, l := range labels {
+		n, err := ParseLabel(l)
+		if err != nil {
+			return nil, err
+		}
+		if !slices.Contains(names, n) {
+			names = append(names, n)
+		}
+	}
+	if len(names) == 0 {
+		return make([]string, 0), nil
+	}
+
+	// with AllOf every label needs to be attached to the entry
+	minMatches := int64(1)
+	if mode == AllOf {
+		minMatches = int64(len(names))
+	}
+
+	ids, err := s.q.listEntryIDsByLabels(ctx, listEntryIDsByLabelsParams{
+		WorkspaceID: wID.String(),
+		ChangelogID: cID.String(),
+		Labels:      names,
+		MinMatches:  minMatches,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return make([]string, 0), nil
+		}
+		return nil, err
+	}
+	if ids == nil {
+		return make([]string, 0), nil
+	}
+	return ids, nil
+}
+
+func (s *sqlite) AttachMediaToEntry(ctx context.Context, wID WorkspaceID, cID ChangelogID, entryID string, att Attachment) (Attachment, error) {
+	att.EntryID = entryID
+	err := att.validate()
+	if err != nil {
+		return Attachment{}, err
+	}
+
+	row, err := s.q.createEntryAttachment(ctx, createEntryAttachmentParams{
+		ID:          newID(attachment_prefix),
+		WorkspaceID: wID.String(),
+		ChangelogID: cID.String(),
+		EntryID:     entryID,
+		Filename:    att.Filename,
+		MimeType:    att.MimeType,
+		SizeBytes:   att.SizeBytes,
+		Url:         att.URL,
+	})
+	if err != nil {
+		if strings.Contains(err.Error(), "FOREIGN KEY constraint failed") {
+			return Attachment{}, errNoChangelog
+		}
+		return Attachment{}, err
+	}
+	return row.toExported(), nil
+}
+
+func (s *sqlite) DetachMedia(ctx context.Context, wID WorkspaceID, cID ChangelogID, entryID, attID string) error {
+	n, err := s.q.deleteEntryAttachment(ctx, deleteEntryAttachmentParams{
+		WorkspaceID: wID.String(),
+		ChangelogID: cID.String(),
+		EntryID:     entryID,
+		ID:          attID,
+	})
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return errs.NewError(errs.ErrNotFound, errors.New("attachment not found"))
+	}
+	return nil
+}
+
+func (s *sqlite) ListEntryAttachments(ctx context.Context, wID WorkspaceID, cID ChangelogID, entryID string) ([]Attachment, error) {
+	rows, err := s.q.listEntryAttachments(ctx, listEntryAttachmentsParams{
+		WorkspaceID: wID.String(),
+		ChangelogID: cID.String(),
+		EntryID:     entryID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]Attachment, len(rows))
+	for i, r := range rows {
+		res[i] = r.toExported()
+	}
+	return res, nil
+}
+
+var errNoComment = errs.NewError(errs.ErrNotFound, errors.New("comment not found"))
+
+func (s *sqlite) CreateComment(ctx context.Context, c Comment) (Comment, error) {
+	err := c.validate()
+	if err != nil {
+		return Comment{}, err
+	}
+
+	row, err := s.q.createEntryComment(ctx, createEntryCommentParams{
+		ID:          newID(comment_prefix),
+		WorkspaceID: c.WorkspaceID.String(),
+		ChangelogID: c.ChangelogID.String(),
+		EntryID:     c.EntryID,
+		AuthorID:    c.AuthorID,
+		Body:        c.Body,
+	})
+	if err != nil {
+		if strings.Contains(err.Error(), "FOREIGN KEY constraint failed") {
+			return Comment{}, errNoChangelog
+		}
+		return Comment{}, err
+	}
+	return row.toExported(), nil
+}
+
+func (s *sqlite) UpdateComment(ctx context.Context, wID WorkspaceID, cID ChangelogID, commentID, body string) (Comment, error) {
+	err := validateCommentBody(body)
+	if err != nil {
+		return Comment{}, err
+	}
+
+	row, err := s.q.updateEntryComment(ctx, updateEntryCommentParams{
+		Body:        body,
+		WorkspaceID: wID.String(),
+		ChangelogID: cID.String(),
+		ID:          commentID,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return Comment{}, errNoComment
+		}
+		return Comment{}, err
+	}
+	return row.toExported(), nil
+}
+
+func (s *sqlite) DeleteComment(ctx context.Context, wID WorkspaceID, cID ChangelogID, commentID string) error {
+	n, err := s.q.deleteEntryComment(ctx, deleteEntryCommentParams{
+		WorkspaceID: wID.String(),
+		ChangelogID: cID.String(),
+		ID:          commentID,
+	})
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return errNoComment
+	}
+	return nil
+}
+
+func (s *sqlite) ResolveComment(ctx context.Context, wID WorkspaceID, cID ChangelogID, commentID string, resolved bool) error {
+	n, err := s.q.resolveEntryComment(ctx, resolveEntryCommentParams{
+		Resolved:    boolToInt(resolved),
+		WorkspaceID: wID.String(),
+		ChangelogID: cID.String(),
+		ID:          commentID,
+	})
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return errNoComment
+	}
+	return nil
+}
+
+func (s *sqlite) ListComments(ctx context.Context, wID WorkspaceID, cID ChangelogID, entryID string) ([]Comment, error) {
+	rows, err := s.q.listEntryComments(ctx, listEntryCommentsParams{
+		WorkspaceID: wID.String(),
+		ChangelogID: cID.String(),
+		EntryID:     entryID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]Comment, len(rows))
+	for i, r := range rows {
+		res[i] = r.toExported()
+	}
+	return res, nil
+}
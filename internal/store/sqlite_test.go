@@ -0,0 +1,1028 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jonashiltl/openchangelog/apitypes"
+	"github.com/jonashiltl/openchangelog/internal/errs"
+)
+
+// Opens a new database in a temporary directory and applies all migrations to it.
+func newTestSQLite(tb testing.TB) *sqlite {
+	tb.Helper()
+
+	conn := fmt.Sprintf("file:%s?_foreign_keys=on", filepath.Join(tb.TempDir(), "test.db"))
+	st, err := NewSQLiteStore(conn, SQLiteOptions{EncryptionKey: make([]byte, 32)})
+	if err != nil {
+		tb.Fatal(err)
+	}
+	s := st.(*sqlite)
+	tb.Cleanup(func() { s.db.Close() })
+
+	dir := filepath.Join("..", "..", "migrations")
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		tb.Fatal(err)
+	}
+	var files []string
+	for _, e := range entries {
+		if strings.HasSuffix(e.Name(), ".sql") {
+			files = append(files, e.Name())
+		}
+	}
+	sort.Strings(files)
+
+	for _, f := range files {
+		content, err := os.ReadFile(filepath.Join(dir, f))
+		if err != nil {
+			tb.Fatal(err)
+		}
+		// only the up migration, without the goose annotations
+		up, _, _ := strings.Cut(string(content), "-- +goose Down")
+		up = strings.NewReplacer(
+			"-- +goose Up", "",
+			"-- +goose StatementBegin", "",
+			"-- +goose StatementEnd", "",
+		).Replace(up)
+		_, err = s.db.Exec(up)
+		if err != nil {
+			tb.Fatalf("failed to apply migration %s: %s", f, err)
+		}
+	}
+	return s
+}
+
+// Creates a new workspace with a single changelog.
+func newTestChangelog(tb testing.TB, s *sqlite, domain string) Changelog {
+	tb.Helper()
+	ctx := context.Background()
+
+	wID := NewWID()
+	_, err := s.SaveWorkspace(ctx, Workspace{ID: wID, Name: wID.String()})
+	if err != nil {
+		tb.Fatal(err)
+	}
+	cl, err := s.CreateChangelog(ctx, Changelog{
+		ID:          NewCID(),
+		WorkspaceID: wID,
+		Subdomain:   NewSubdomain(wID.String()),
+		Domain:      Domain(apitypes.NewString(domain)),
+		ColorScheme: System,
+	})
+	if err != nil {
+		tb.Fatal(err)
+	}
+	return cl
+}
+
+// Fails the test if err isn't an errs.Error of the domain error.
+func expectDomainErr(t *testing.T, err error, domainErr error) {
+	t.Helper()
+	if err == nil {
+		t.Fatal("expected to error but no error returned")
+	}
+	var e errs.Error
+	if !errors.As(err, &e) || e.DomainErr() != domainErr {
+		t.Errorf("expected %s to be %s", err, domainErr)
+	}
+}
+
+func TestCustomDomains(t *testing.T) {
+	ctx := context.Background()
+	s := newTestSQLite(t)
+	acme := newTestChangelog(t, s, "changelog.acme.com")
+	other := newTestChangelog(t, s, "changelog.other.com")
+
+	t.Run("create copies the primary domain", func(t *testing.T) {
+		domains, err := s.ListCustomDomains(ctx, acme.WorkspaceID, acme.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(domains) != 1 || domains[0].Domain.String() != "changelog.acme.com" {
+			t.Errorf("expected %v to contain changelog.acme.com", domains)
+		}
+	})
+
+	t.Run("create with a taken domain", func(t *testing.T) {
+		wID := NewWID()
+		_, err := s.SaveWorkspace(ctx, Workspace{ID: wID, Name: wID.String()})
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, err = s.CreateChangelog(ctx, Changelog{
+			ID:          NewCID(),
+			WorkspaceID: wID,
+			Subdomain:   NewSubdomain(wID.String()),
+			Domain:      Domain(apitypes.NewString("changelog.acme.com")),
+			ColorScheme: System,
+		})
+		expectDomainErr(t, err, errs.ErrBadRequest)
+	})
+
+	t.Run("add primary domain of another changelog", func(t *testing.T) {
+		_, err := s.AddCustomDomain(ctx, acme.WorkspaceID, acme.ID, "changelog.other.com")
+		expectDomainErr(t, err, errs.ErrBadRequest)
+	})
+
+	t.Run("add custom domain of another changelog", func(t *testing.T) {
+		_, err := s.AddCustomDomain(ctx, other.WorkspaceID, other.ID, "news.other.com")
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, err = s.AddCustomDomain(ctx, acme.WorkspaceID, acme.ID, "news.other.com")
+		expectDomainErr(t, err, errs.ErrBadRequest)
+	})
+
+	t.Run("add to changelog of another workspace", func(t *testing.T) {
+		_, err := s.AddCustomDomain(ctx, other.WorkspaceID, acme.ID, "news.acme.com")
+		expectDomainErr(t, err, errs.ErrNotFound)
+	})
+
+	t.Run("update to custom domain of another changelog", func(t *testing.T) {
+		_, err := s.UpdateChangelog(ctx, acme.WorkspaceID, acme.ID, UpdateChangelogArgs{
+			Domain: Domain(apitypes.NewString("news.other.com")),
+		})
+		expectDomainErr(t, err, errs.ErrBadRequest)
+
+		cl, err := s.GetChangelog(ctx, acme.WorkspaceID, acme.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if cl.Domain.String() != "changelog.acme.com" {
+			t.Errorf("expected %s to equal %s", cl.Domain.String(), "changelog.acme.com")
+		}
+	})
+
+	t.Run("update replaces the primary domain", func(t *testing.T) {
+		_, err := s.UpdateChangelog(ctx, acme.WorkspaceID, acme.ID, UpdateChangelogArgs{
+			Domain: Domain(apitypes.NewString("updates.acme.com")),
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		domains, err := s.ListCustomDomains(ctx, acme.WorkspaceID, acme.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(domains) != 1 || domains[0].Domain.String() != "updates.acme.com" {
+			t.Errorf("expected %v to only contain updates.acme.com", domains)
+		}
+	})
+
+	t.Run("unverified domain doesn't resolve", func(t *testing.T) {
+		_, err := s.GetChangelogByCustomDomain(ctx, Domain(apitypes.NewString("news.other.com")))
+		expectDomainErr(t, err, errs.ErrNotFound)
+	})
+}
+
+func TestRSSTokens(t *testing.T) {
+	ctx := context.Background()
+	s := newTestSQLite(t)
+	cl := newTestChangelog(t, s, "")
+	other := newTestChangelog(t, s, "")
+
+	token, err := s.CreateRSSToken(ctx, cl.WorkspaceID, cl.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("only the hash is stored", func(t *testing.T) {
+		var n int
+		err := s.db.QueryRow("SELECT COUNT(*) FROM changelog_rss_tokens WHERE token_hash = ?", token).Scan(&n)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if n != 0 {
+			t.Error("expected the token to not be stored in plaintext")
+		}
+	})
+
+	t.Run("get by token", func(t *testing.T) {
+		got, err := s.GetChangelogByRSSToken(ctx, token)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got.ID != cl.ID {
+			t.Errorf("expected %s to equal %s", got.ID, cl.ID)
+		}
+	})
+
+	t.Run("get by hash", func(t *testing.T) {
+		_, err := s.GetChangelogByRSSToken(ctx, hashSecretToken(token))
+		expectDomainErr(t, err, errs.ErrUnauthorized)
+	})
+
+	t.Run("create for changelog of another workspace", func(t *testing.T) {
+		_, err := s.CreateRSSToken(ctx, other.WorkspaceID, cl.ID)
+		expectDomainErr(t, err, errs.ErrNotFound)
+	})
+
+	t.Run("revoke from another workspace", func(t *testing.T) {
+		err := s.RevokeRSSToken(ctx, other.WorkspaceID, cl.ID, token)
+		expectDomainErr(t, err, errs.ErrNotFound)
+	})
+
+	t.Run("revoke", func(t *testing.T) {
+		err := s.RevokeRSSToken(ctx, cl.WorkspaceID, cl.ID, token)
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, err = s.GetChangelogByRSSToken(ctx, token)
+		expectDomainErr(t, err, errs.ErrUnauthorized)
+	})
+}
+
+func TestPreviewTokens(t *testing.T) {
+	ctx := context.Background()
+	s := newTestSQLite(t)
+	cl := newTestChangelog(t, s, "")
+	other := newTestChangelog(t, s, "")
+
+	token, err := s.CreatePreviewToken(ctx, cl.WorkspaceID, cl.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("only the hash is stored", func(t *testing.T) {
+		var n int
+		err := s.db.QueryRow("SELECT COUNT(*) FROM changelog_preview_tokens WHERE token_hash = ?", token).Scan(&n)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if n != 0 {
+			t.Error("expected the token to not be stored in plaintext")
+		}
+	})
+
+	t.Run("get by token", func(t *testing.T) {
+		got, err := s.GetChangelogByPreviewToken(ctx, token)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got.ID != cl.ID {
+			t.Errorf("expected %s to equal %s", got.ID, cl.ID)
+		}
+	})
+
+	t.Run("expired", func(t *testing.T) {
+		expired, err := s.CreatePreviewToken(ctx, cl.WorkspaceID, cl.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, err = s.db.Exec("UPDATE changelog_preview_tokens SET expires_at = 0 WHERE token_hash = ?", hashSecretToken(expired))
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, err = s.GetChangelogByPreviewToken(ctx, expired)
+		expectDomainErr(t, err, errs.ErrNotFound)
+	})
+
+	t.Run("revoke from another workspace", func(t *testing.T) {
+		err := s.RevokePreviewToken(ctx, other.WorkspaceID, cl.ID, token)
+		expectDomainErr(t, err, errs.ErrNotFound)
+	})
+
+	t.Run("revoke", func(t *testing.T) {
+		err := s.RevokePreviewToken(ctx, cl.WorkspaceID, cl.ID, token)
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, err = s.GetChangelogByPreviewToken(ctx, token)
+		expectDomainErr(t, err, errs.ErrNotFound)
+	})
+}
+
+func TestInvites(t *testing.T) {
+	ctx := context.Background()
+	s := newTestSQLite(t)
+	cl := newTestChangelog(t, s, "")
+	other := newTestChangelog(t, s, "")
+
+	newInvite := func(t *testing.T, ttl time.Duration) string {
+		token, err := s.CreateInvite(ctx, cl.WorkspaceID, "jane@acme.com", MemberEditor.String(), ttl)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return token
+	}
+
+	t.Run("only the hash is stored", func(t *testing.T) {
+		token := newInvite(t, time.Hour)
+		var n int
+		err := s.db.QueryRow("SELECT COUNT(*) FROM workspace_invites WHERE token_hash = ?", token).Scan(&n)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if n != 0 {
+			t.Error("expected the token to not be stored in plaintext")
+		}
+		_, err = s.AcceptInvite(ctx, hashSecretToken(token))
+		expectDomainErr(t, err, errs.ErrUnauthorized)
+	})
+
+	t.Run("accept", func(t *testing.T) {
+		token := newInvite(t, time.Hour)
+		wID, err := s.AcceptInvite(ctx, token)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if wID != cl.WorkspaceID {
+			t.Errorf("expected %s to equal %s", wID, cl.WorkspaceID)
+		}
+		_, err = s.AcceptInvite(ctx, token)
+		expectDomainErr(t, err, errs.ErrUnauthorized)
+	})
+
+	t.Run("expired", func(t *testing.T) {
+		token := newInvite(t, -time.Hour)
+		_, err := s.AcceptInvite(ctx, token)
+		expectDomainErr(t, err, errs.ErrUnauthorized)
+	})
+
+	t.Run("unknown workspace", func(t *testing.T) {
+		_, err := s.CreateInvite(ctx, NewWID(), "jane@acme.com", MemberEditor.String(), time.Hour)
+		expectDomainErr(t, err, errs.ErrNotFound)
+	})
+
+	t.Run("revoke from another workspace", func(t *testing.T) {
+		token := newInvite(t, time.Hour)
+		err := s.RevokeInvite(ctx, other.WorkspaceID, token)
+		expectDomainErr(t, err, errs.ErrNotFound)
+
+		err = s.RevokeInvite(ctx, cl.WorkspaceID, token)
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, err = s.AcceptInvite(ctx, token)
+		expectDomainErr(t, err, errs.ErrUnauthorized)
+	})
+}
+
+func TestSearchWorkspaces(t *testing.T) {
+	ctx := context.Background()
+	s := newTestSQLite(t)
+	for _, name := range []string{"acme_corp", "acmeXcorp", "100% acme", `back\slash`} {
+		_, err := s.SaveWorkspace(ctx, Workspace{ID: NewWID(), Name: name})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tables := []struct {
+		prefix   string
+		expected []string
+	}{
+		{
+			prefix:   "acme_corp",
+			expected: []string{"acme_corp"},
+		},
+		{
+			prefix:   "acme_",
+			expected: []string{"acme_corp"},
+		},
+		{
+			prefix:   "ACME",
+			expected: []string{"acme_corp", "acmeXcorp"},
+		},
+		{
+			prefix:   "100%",
+			expected: []string{"100% acme"},
+		},
+		{
+			prefix:   "%",
+			expected: []string{},
+		},
+		{
+			prefix:   `back\`,
+			expected: []string{`back\slash`},
+		},
+	}
+
+	for _, table := range tables {
+		t.Run(table.prefix, func(t *testing.T) {
+			ws, err := s.SearchWorkspaces(ctx, table.prefix, 10)
+			if err != nil {
+				t.Fatal(err)
+			}
+			names := make([]string, len(ws))
+			for i, w := range ws {
+				names[i] = w.Name
+			}
+			if !slices.Equal(names, table.expected) {
+				t.Errorf("expected %v to equal %v", names, table.expected)
+			}
+		})
+	}
+}
+
+func TestEntryAttachments(t *testing.T) {
+	ctx := context.Background()
+	s := newTestSQLite(t)
+	cl := newTestChangelog(t, s, "")
+	other := newTestChangelog(t, s, "")
+	entryID := "1718000000"
+	att := Attachment{
+		Filename:  "screenshot.png",
+		MimeType:  "image/png",
+		SizeBytes: 1024,
+		URL:       "https://acme.com/screenshot.png",
+	}
+
+	created, err := s.AttachMediaToEntry(ctx, cl.WorkspaceID, cl.ID, entryID, att)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("list", func(t *testing.T) {
+		atts, err := s.ListEntryAttachments(ctx, cl.WorkspaceID, cl.ID, entryID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(atts) != 1 || atts[0].ID != created.ID || atts[0].EntryID != entryID {
+			t.Errorf("expected %v to only contain %v", atts, created)
+		}
+	})
+
+	t.Run("list other entry", func(t *testing.T) {
+		atts, err := s.ListEntryAttachments(ctx, cl.WorkspaceID, cl.ID, "1718000001")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(atts) != 0 {
+			t.Errorf("expected %v to be empty", atts)
+		}
+	})
+
+	t.Run("attach to changelog of another workspace", func(t *testing.T) {
+		_, err := s.AttachMediaToEntry(ctx, other.WorkspaceID, cl.ID, entryID, att)
+		expectDomainErr(t, err, errs.ErrNotFound)
+	})
+
+	t.Run("list from another workspace", func(t *testing.T) {
+		atts, err := s.ListEntryAttachments(ctx, other.WorkspaceID, cl.ID, entryID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(atts) != 0 {
+			t.Errorf("expected %v to be empty", atts)
+		}
+	})
+
+	t.Run("detach from another workspace", func(t *testing.T) {
+		err := s.DetachMedia(ctx, other.WorkspaceID, cl.ID, entryID, created.ID)
+		expectDomainErr(t, err, errs.ErrNotFound)
+	})
+
+	t.Run("detach", func(t *testing.T) {
+		err := s.DetachMedia(ctx, cl.WorkspaceID, cl.ID, entryID, created.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = s.DetachMedia(ctx, cl.WorkspaceID, cl.ID, entryID, created.ID)
+		expectDomainErr(t, err, errs.ErrNotFound)
+	})
+}
+
+func TestEntryComments(t *testing.T) {
+	ctx := context.Background()
+	s := newTestSQLite(t)
+	cl := newTestChangelog(t, s, "")
+	other := newTestChangelog(t, s, "")
+	entryID := "1718000000"
+	c := Comment{
+		WorkspaceID: cl.WorkspaceID,
+		ChangelogID: cl.ID,
+		EntryID:     entryID,
+		AuthorID:    "us_123",
+		Body:        "Can we add a screenshot here?",
+	}
+
+	created, err := s.CreateComment(ctx, c)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("create for changelog of another workspace", func(t *testing.T) {
+		c := c
+		c.WorkspaceID = other.WorkspaceID
+		_, err := s.CreateComment(ctx, c)
+		expectDomainErr(t, err, errs.ErrNotFound)
+	})
+
+	t.Run("update", func(t *testing.T) {
+		updated, err := s.UpdateComment(ctx, cl.WorkspaceID, cl.ID, created.ID, "Added one")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if updated.Body != "Added one" {
+			t.Errorf("expected %s to equal %s", updated.Body, "Added one")
+		}
+	})
+
+	t.Run("update from another workspace", func(t *testing.T) {
+		_, err := s.UpdateComment(ctx, other.WorkspaceID, cl.ID, created.ID, "Hijacked")
+		expectDomainErr(t, err, errs.ErrNotFound)
+	})
+
+	t.Run("resolve", func(t *testing.T) {
+		err := s.ResolveComment(ctx, cl.WorkspaceID, cl.ID, created.ID, true)
+		if err != nil {
+			t.Fatal(err)
+		}
+		comments, err := s.ListComments(ctx, cl.WorkspaceID, cl.ID, entryID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(comments) != 1 || !comments[0].Resolved || comments[0].Body != "Added one" {
+			t.Errorf("expected %v to only contain the resolved comment", comments)
+		}
+	})
+
+	t.Run("list from another workspace", func(t *testing.T) {
+		comments, err := s.ListComments(ctx, other.WorkspaceID, cl.ID, entryID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(comments) != 0 {
+			t.Errorf("expected %v to be empty", comments)
+		}
+	})
+
+	t.Run("delete from another workspace", func(t *testing.T) {
+		err := s.DeleteComment(ctx, other.WorkspaceID, cl.ID, created.ID)
+		expectDomainErr(t, err, errs.ErrNotFound)
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		err := s.DeleteComment(ctx, cl.WorkspaceID, cl.ID, created.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = s.ResolveComment(ctx, cl.WorkspaceID, cl.ID, created.ID, false)
+		expectDomainErr(t, err, errs.ErrNotFound)
+	})
+}
+
+func TestBulkUpdateEntryLabels(t *testing.T) {
+	ctx := context.Background()
+	s := newTestSQLite(t)
+	cl := newTestChangelog(t, s, "")
+	other := newTestChangelog(t, s, "")
+
+	for _, name := range []string{"bug", "fix"} {
+		_, err := s.CreateLabel(ctx, cl.WorkspaceID, name)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	_, err := s.CreateLabel(ctx, other.WorkspaceID, "feature")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, l := range []struct{ entryID, label string }{
+		{"1", "bug"},
+		{"2", "bug"},
+		{"2", "fix"},
+	} {
+		err := s.AttachLabelToEntry(ctx, cl.WorkspaceID, cl.ID, l.entryID, l.label)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	t.Run("missing label", func(t *testing.T) {
+		_, err := s.BulkUpdateEntryLabels(ctx, cl.WorkspaceID, "bug", "improvement")
+		expectDomainErr(t, err, errs.ErrBadRequest)
+	})
+
+	t.Run("label of another workspace", func(t *testing.T) {
+		_, err := s.BulkUpdateEntryLabels(ctx, cl.WorkspaceID, "bug", "feature")
+		expectDomainErr(t, err, errs.ErrBadRequest)
+	})
+
+	t.Run("move", func(t *testing.T) {
+		n, err := s.BulkUpdateEntryLabels(ctx, cl.WorkspaceID, "bug", "fix")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if n != 2 {
+			t.Errorf("expected %d to equal %d", n, 2)
+		}
+
+		for _, entryID := range []string{"1", "2"} {
+			labels, err := s.ListLabelsByEntry(ctx, cl.WorkspaceID, cl.ID, entryID)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(labels) != 1 || labels[0].Name != "fix" {
+				t.Errorf("expected entry %s to only be labeled with fix but got %v", entryID, labels)
+			}
+		}
+	})
+}
+
+func TestListEntryIDsByLabels(t *testing.T) {
+	ctx := context.Background()
+	s := newTestSQLite(t)
+	cl := newTestChangelog(t, s, "")
+	other := newTestChangelog(t, s, "")
+
+	for _, name := range []string{"bug", "fix"} {
+		_, err := s.CreateLabel(ctx, cl.WorkspaceID, name)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, l := range []struct{ entryID, label string }{
+		{"1", "bug"},
+		{"2", "bug"},
+		{"2", "fix"},
+		{"3", "fix"},
+	} {
+		err := s.AttachLabelToEntry(ctx, cl.WorkspaceID, cl.ID, l.entryID, l.label)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tables := []struct {
+		name     string
+		wID      WorkspaceID
+		labels   []string
+		mode     LabelMatchMode
+		expected []string
+	}{
+		{
+			name:     "any of",
+			wID:      cl.WorkspaceID,
+			labels:   []string{"bug", "fix"},
+			mode:     AnyOf,
+			expected: []string{"1", "2", "3"},
+		},
+		{
+			name:     "all of",
+			wID:      cl.WorkspaceID,
+			labels:   []string{"Bug", "fix"},
+			mode:     AllOf,
+			expected: []string{"2"},
+		},
+		{
+			name:     "duplicate labels",
+			wID:      cl.WorkspaceID,
+			labels:   []string{"bug", "bug"},
+			mode:     AllOf,
+			expected: []string{"1", "2"},
+		},
+		{
+			name:     "no labels",
+			wID:      cl.WorkspaceID,
+			mode:     AnyOf,
+			expected: []string{},
+		},
+		{
+			name:     "another workspace",
+			wID:      other.WorkspaceID,
+			labels:   []string{"bug", "fix"},
+			mode:     AnyOf,
+			expected: []string{},
+		},
+	}
+
+	for _, table := range tables {
+		t.Run(table.name, func(t *testing.T) {
+			ids, err := s.ListEntryIDsByLabels(ctx, table.wID, cl.ID, table.labels, table.mode)
+			if err != nil {
+				t.Fatal(err)
+			}
+			sort.Strings(ids)
+			if !slices.Equal(ids, table.expected) {
+				t.Errorf("expected %v to equal %v", ids, table.expected)
+			}
+		})
+	}
+}
+
+func TestGetOrCreateWorkspace(t *testing.T) {
+	ctx := context.Background()
+	s := newTestSQLite(t)
+
+	created, isNew, err := s.GetOrCreateWorkspace(ctx, "Acme")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !isNew || created.Token == "" {
+		t.Errorf("expected %v to be a new workspace with a token", created)
+	}
+
+	t.Run("existing", func(t *testing.T) {
+		ws, isNew, err := s.GetOrCreateWorkspace(ctx, "Acme")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if isNew {
+			t.Error("expected workspace to already exist")
+		}
+		if ws.ID != created.ID || ws.Token != created.Token {
+			t.Errorf("expected %v to equal %v", ws, created)
+		}
+	})
+
+	t.Run("save with taken name", func(t *testing.T) {
+		_, err := s.SaveWorkspace(ctx, Workspace{ID: NewWID(), Name: "Acme"})
+		expectDomainErr(t, err, errs.ErrBadRequest)
+	})
+
+	t.Run("rename", func(t *testing.T) {
+		_, err := s.SaveWorkspace(ctx, Workspace{ID: created.ID, Name: "Acme Inc"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, isNew, err := s.GetOrCreateWorkspace(ctx, "Acme")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !isNew {
+			t.Error("expected the renamed workspace to free its name")
+		}
+	})
+}
+
+func TestBulkGetChangelogs(t *testing.T) {
+	ctx := context.Background()
+	s := newTestSQLite(t)
+	cl := newTestChangelog(t, s, "")
+	other := newTestChangelog(t, s, "")
+
+	cls, err := s.BulkGetChangelogs(ctx, cl.WorkspaceID, []ChangelogID{other.ID, cl.ID, NewCID()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(cls) != 3 {
+		t.Fatalf("expected %d changelogs but got %d", 3, len(cls))
+	}
+	if cls[0] != nil {
+		t.Errorf("expected changelog of another workspace to be nil but got %v", cls[0])
+	}
+	if cls[1] == nil || cls[1].ID != cl.ID {
+		t.Errorf("expected %v to be changelog %s", cls[1], cl.ID)
+	}
+	if cls[2] != nil {
+		t.Errorf("expected missing changelog to be nil but got %v", cls[2])
+	}
+}
+
+func TestWorkspaceWebhookSecret(t *testing.T) {
+	ctx := context.Background()
+	s := newTestSQLite(t)
+	cl := newTestChangelog(t, s, "")
+
+	_, err := s.GetWorkspaceWebhookSecret(ctx, cl.WorkspaceID)
+	expectDomainErr(t, err, errs.ErrNotFound)
+
+	secret, err := s.RotateWorkspaceWebhookSecret(ctx, cl.WorkspaceID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := s.GetWorkspaceWebhookSecret(ctx, cl.WorkspaceID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != secret {
+		t.Errorf("expected %s to equal %s", got, secret)
+	}
+
+	_, err = s.RotateWorkspaceWebhookSecret(ctx, NewWID())
+	expectDomainErr(t, err, errs.ErrNotFound)
+}
+
+func TestInstallationToken(t *testing.T) {
+	ctx := context.Background()
+	s := newTestSQLite(t)
+
+	err := s.SetInstallationToken(ctx, 1, "ghs_valid", time.Now().Add(time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+	token, err := s.GetInstallationToken(ctx, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if token != "ghs_valid" {
+		t.Errorf("expected %s to equal %s", token, "ghs_valid")
+	}
+
+	t.Run("expiring", func(t *testing.T) {
+		err := s.SetInstallationToken(ctx, 2, "ghs_expiring", time.Now().Add(installation_token_expiry_margin/2))
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, err = s.GetInstallationToken(ctx, 2)
+		expectDomainErr(t, err, errs.ErrNotFound)
+	})
+}
+
+func TestGHSourceWebhookSecret(t *testing.T) {
+	ctx := context.Background()
+	s := newTestSQLite(t)
+	cl := newTestChangelog(t, s, "")
+	other := newTestChangelog(t, s, "")
+	gh, err := s.CreateGHSource(ctx, GHSource{
+		ID:          NewGHID(),
+		WorkspaceID: cl.WorkspaceID,
+		Owner:       "acme",
+		Repo:        "changelog",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = s.GetGHSourceWebhookSecret(ctx, cl.WorkspaceID, gh.ID)
+	expectDomainErr(t, err, errs.ErrNotFound)
+
+	err = s.SetGHSourceWebhookSecret(ctx, cl.WorkspaceID, gh.ID, "whsec_123")
+	if err != nil {
+		t.Fatal(err)
+	}
+	secret, err := s.GetGHSourceWebhookSecret(ctx, cl.WorkspaceID, gh.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if secret != "whsec_123" {
+		t.Errorf("expected %s to equal %s", secret, "whsec_123")
+	}
+
+	t.Run("another workspace", func(t *testing.T) {
+		_, err := s.GetGHSourceWebhookSecret(ctx, other.WorkspaceID, gh.ID)
+		expectDomainErr(t, err, errs.ErrNotFound)
+		err = s.SetGHSourceWebhookSecret(ctx, other.WorkspaceID, gh.ID, "whsec_456")
+		expectDomainErr(t, err, errs.ErrNotFound)
+	})
+
+	t.Run("clear", func(t *testing.T) {
+		err := s.SetGHSourceWebhookSecret(ctx, cl.WorkspaceID, gh.ID, "")
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, err = s.GetGHSourceWebhookSecret(ctx, cl.WorkspaceID, gh.ID)
+		expectDomainErr(t, err, errs.ErrNotFound)
+	})
+}
+
+func TestWebhookEndpoints(t *testing.T) {
+	ctx := context.Background()
+	s := newTestSQLite(t)
+	cl := newTestChangelog(t, s, "")
+	other := newTestChangelog(t, s, "")
+	endpoint := WebhookEndpoint{
+		WorkspaceID: cl.WorkspaceID,
+		ChangelogID: cl.ID,
+		URL:         "https://hooks.acme.com/changelog",
+		Events:      []WebhookEvent{WebhookEntryPublished},
+		Active:      true,
+	}
+
+	created, err := s.CreateWebhookEndpoint(ctx, endpoint)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if created.Secret == "" {
+		t.Error("expected a secret to be generated")
+	}
+
+	t.Run("private url", func(t *testing.T) {
+		e := endpoint
+		e.URL = "https://127.0.0.1/changelog"
+		_, err := s.CreateWebhookEndpoint(ctx, e)
+		expectDomainErr(t, err, errs.ErrBadRequest)
+	})
+
+	t.Run("changelog of another workspace", func(t *testing.T) {
+		e := endpoint
+		e.WorkspaceID = other.WorkspaceID
+		_, err := s.CreateWebhookEndpoint(ctx, e)
+		expectDomainErr(t, err, errs.ErrNotFound)
+	})
+
+	t.Run("get", func(t *testing.T) {
+		got, err := s.GetWebhookEndpoint(ctx, cl.WorkspaceID, created.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got.Secret != created.Secret || got.URL != endpoint.URL {
+			t.Errorf("expected %v to equal %v", got, created)
+		}
+	})
+
+	t.Run("get from another workspace", func(t *testing.T) {
+		_, err := s.GetWebhookEndpoint(ctx, other.WorkspaceID, created.ID)
+		expectDomainErr(t, err, errs.ErrNotFound)
+	})
+
+	t.Run("update keeps secret", func(t *testing.T) {
+		e := created
+		e.Secret = ""
+		e.Events = []WebhookEvent{WebhookEntryPublished, WebhookEntryUpdated}
+		updated, err := s.UpdateWebhookEndpoint(ctx, e)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if updated.Secret != created.Secret || len(updated.Events) != 2 {
+			t.Errorf("expected %v to keep the secret and have 2 events", updated)
+		}
+	})
+
+	t.Run("update from another workspace", func(t *testing.T) {
+		e := created
+		e.WorkspaceID = other.WorkspaceID
+		_, err := s.UpdateWebhookEndpoint(ctx, e)
+		expectDomainErr(t, err, errs.ErrNotFound)
+	})
+
+	t.Run("delete from another workspace", func(t *testing.T) {
+		err := s.DeleteWebhookEndpoint(ctx, other.WorkspaceID, created.ID)
+		expectDomainErr(t, err, errs.ErrNotFound)
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		err := s.DeleteWebhookEndpoint(ctx, cl.WorkspaceID, created.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		endpoints, err := s.ListWebhookEndpoints(ctx, cl.WorkspaceID, cl.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(endpoints) != 0 {
+			t.Errorf("expected %v to be empty", endpoints)
+		}
+	})
+}
+
+func TestAPIUsage(t *testing.T) {
+	ctx := context.Background()
+	s := newTestSQLite(t)
+	wID := NewWID()
+	token := NewToken()
+	_, err := s.SaveWorkspace(ctx, Workspace{ID: wID, Name: wID.String(), Token: token})
+	if err != nil {
+		t.Fatal(err)
+	}
+	other := newTestChangelog(t, s, "")
+
+	for _, endpoint := range []string{"GET /api/changelogs", "GET /api/changelogs", "POST /api/changelogs"} {
+		err := s.RecordAPIRequest(ctx, wID, token.String(), endpoint)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	from, to := time.Now().Add(-time.Minute), time.Now().Add(time.Minute)
+
+	t.Run("usage", func(t *testing.T) {
+		usage, err := s.GetAPIUsage(ctx, wID, from, to)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if usage.TotalRequests != 3 || usage.ByEndpoint["GET /api/changelogs"] != 2 || usage.ByEndpoint["POST /api/changelogs"] != 1 {
+			t.Errorf("expected 3 requests but got %v", usage)
+		}
+	})
+
+	t.Run("another workspace", func(t *testing.T) {
+		usage, err := s.GetAPIUsage(ctx, other.WorkspaceID, from, to)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if usage.TotalRequests != 0 {
+			t.Errorf("expected %d to equal %d", usage.TotalRequests, 0)
+		}
+	})
+
+	t.Run("unknown workspace", func(t *testing.T) {
+		err := s.RecordAPIRequest(ctx, NewWID(), token.String(), "GET /api/changelogs")
+		expectDomainErr(t, err, errs.ErrNotFound)
+	})
+
+	t.Run("purge", func(t *testing.T) {
+		err := s.PurgeAPIRequests(ctx, to)
+		if err != nil {
+			t.Fatal(err)
+		}
+		usage, err := s.GetAPIUsage(ctx, wID, from, to)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if usage.TotalRequests != 0 {
+			t.Errorf("expected %d to equal %d", usage.TotalRequests, 0)
+		}
+	})
+}
@@ -0,0 +1,53 @@
+package store
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/jonashiltl/openchangelog/internal/errs"
+)
+
+type SSOProvider string
+
+const (
+	SSOProviderSAML SSOProvider = "saml"
+	SSOProviderOIDC SSOProvider = "oidc"
+)
+
+// Single sign-on configuration of a workspace.
+type SSOConfig struct {
+	Provider  SSOProvider
+	IssuerURL string
+	// Only used by OIDC.
+	ClientID string
+	// Only used by OIDC, stored encrypted. The stored secret is kept if empty.
+	ClientSecret string
+	// PEM encoded certificate of the identity provider, only used by SAML.
+	Certificate string
+	Enabled     bool
+	CreatedAt   time.Time
+}
+
+var errNoSSOConfig = errs.NewError(errs.ErrNotFound, errors.New("sso config not found"))
+
+func (c SSOConfig) validate() error {
+	u, err := url.Parse(c.IssuerURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return errs.NewBadRequest(errors.New("invalid sso issuer url"))
+	}
+	switch c.Provider {
+	case SSOProviderOIDC:
+		if c.ClientID == "" {
+			return errs.NewBadRequest(errors.New("oidc requires a client id"))
+		}
+	case SSOProviderSAML:
+		if c.Certificate == "" {
+			return errs.NewBadRequest(errors.New("saml requires a certificate"))
+		}
+	default:
+		return errs.NewBadRequest(fmt.Errorf("unknown sso provider %s", c.Provider))
+	}
+	return nil
+}
@@ -0,0 +1,54 @@
+package store
+
+import "testing"
+
+func TestSSOConfigValidate(t *testing.T) {
+	tables := []struct {
+		name  string
+		cfg   SSOConfig
+		valid bool
+	}{
+		{
+			name:  "oidc",
+			cfg:   SSOConfig{Provider: SSOProviderOIDC, IssuerURL: "https://accounts.example.com", ClientID: "client"},
+			valid: true,
+		},
+		{
+			name:  "saml",
+			cfg:   SSOConfig{Provider: SSOProviderSAML, IssuerURL: "https://idp.example.com/saml", Certificate: "-----BEGIN CERTIFICATE-----"},
+			valid: true,
+		},
+		{
+			name:  "oidc without client id",
+			cfg:   SSOConfig{Provider: SSOProviderOIDC, IssuerURL: "https://accounts.example.com"},
+			valid: false,
+		},
+		{
+			name:  "saml without certificate",
+			cfg:   SSOConfig{Provider: SSOProviderSAML, IssuerURL: "https://idp.example.com/saml"},
+			valid: false,
+		},
+		{
+			name:  "unknown provider",
+			cfg:   SSOConfig{Provider: "ldap", IssuerURL: "https://idp.example.com"},
+			valid: false,
+		},
+		{
+			name:  "invalid issuer",
+			cfg:   SSOConfig{Provider: SSOProviderOIDC, IssuerURL: "accounts.example.com", ClientID: "client"},
+			valid: false,
+		},
+	}
+
+	for _, table := range tables {
+		t.Run(table.name, func(t *testing.T) {
+			err := table.cfg.validate()
+			if table.valid && err != nil {
+				t.Errorf("expected config to be valid, got %s", err)
+			}
+			if !table.valid && err == nil {
+				t.Error("expected config to be invalid")
+			}
+		})
+	}
+}
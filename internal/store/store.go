@@ -15,6 +15,7 @@ type Changelog struct {
 	ID            ChangelogID
 	Subdomain     Subdomain
 	Domain        Domain
+	Slug          string
 	Title         apitypes.NullString
 	Subtitle      apitypes.NullString
 	LogoSrc       apitypes.NullString
@@ -22,6 +23,8 @@ type Changelog struct {
 	LogoAlt       apitypes.NullString
 	LogoHeight    apitypes.NullString
 	LogoWidth     apitypes.NullString
+	FaviconSrc    apitypes.NullString
+	SocialLinks   SocialLinks
 	ColorScheme   ColorScheme
 	Analytics     bool
 	HidePoweredBy bool
@@ -34,9 +37,10 @@ type Changelog struct {
 }
 
 type Workspace struct {
-	ID    WorkspaceID
-	Name  string
-	Token Token
+	ID        WorkspaceID
+	Name      string
+	Token     Token
+	CreatedAt time.Time
 }
 
 type GHSource struct {
@@ -46,6 +50,35 @@ type GHSource struct {
 	Repo           string
 	Path           string
 	InstallationID int64
+	LastFetchedAt  time.Time
+	FetchInterval  time.Duration
+	// Set by GetGHSource if the last syncs of the source failed repeatedly.
+	Degraded bool
+	// Private key of the GitHub App, stored encrypted.
+	// Only decrypted by GetGHSource.
+	PrivateKeyID string
+	PrivateKey   []byte
+}
+
+// Describes a single sync run of a GitHub source.
+type GHSyncLog struct {
+	ID           string
+	WorkspaceID  WorkspaceID
+	GHSourceID   GHSourceID
+	StartedAt    time.Time
+	FinishedAt   time.Time
+	FilesFound   int
+	FilesChanged int
+	// Empty if the sync succeeded.
+	Error string
+}
+
+type GHSourceHealth struct {
+	WorkspaceID         WorkspaceID
+	GHSourceID          GHSourceID
+	LastError           string
+	ConsecutiveFailures int
+	LastCheckedAt       time.Time
 }
 
 type LocalSource struct {
@@ -68,6 +101,123 @@ type UpdateChangelogArgs struct {
 	Analytics     *bool
 	Searchable    *bool
 	PasswordHash  apitypes.NullString
+	Slug          apitypes.NullString
+	FaviconSrc    apitypes.NullString
+	// Replaces all social links if not nil, empty links remove them.
+	SocialLinks *SocialLinks
+}
+
+type GrowthDataPoint struct {
+	Period        string
+	NewWorkspaces int64
+	NewChangelogs int64
+}
+
+type TimelinePoint struct {
+	Period string
+	Count  int64
+}
+
+type SearchQueryStat struct {
+	Query string
+	Count int64
+}
+
+// The requests made with the tokens of a workspace.
+type APIUsage struct {
+	TotalRequests int64
+	ByEndpoint    map[string]int64
+}
+
+// A token of a workspace, without the secret part of its key.
+type APIKey struct {
+	ID string
+	// The masked key, see Token.Masked.
+	Key       string
+	Label     string
+	CreatedAt time.Time
+	// Zero if the key doesn't expire.
+	ExpiresAt time.Time
+	// Zero if the key was never used.
+	LastUsedAt time.Time
+}
+
+// Page views of all changelogs of a workspace.
+type WorkspaceAnalytics struct {
+	TotalViews     int64
+	UniqueVisitors int64
+}
+
+// The visual fields of a changelog, used to brand its pages.
+type BrandKit struct {
+	LogoSrc     apitypes.NullString
+	LogoLink    apitypes.NullString
+	LogoAlt     apitypes.NullString
+	LogoHeight  apitypes.NullString
+	LogoWidth   apitypes.NullString
+	ColorScheme ColorScheme
+	FaviconSrc  apitypes.NullString
+	SocialLinks SocialLinks
+}
+
+// Overview of a workspace for its dashboard.
+type DashboardSummary struct {
+	ChangelogCount       int64
+	TotalViewsLast30Days int64
+	// Failed webhook deliveries which weren't retried yet.
+	UnprocessedWebhooks int64
+	DegradedSources     int64
+}
+
+type TrafficSource struct {
+	// Hostname of the referer, "(direct)" for visits without one.
+	Domain string
+	Visits int64
+}
+
+// An additional domain a changelog is served under, next to its primary domain.
+type CustomDomain struct {
+	ID          string
+	WorkspaceID WorkspaceID
+	ChangelogID ChangelogID
+	Domain      Domain
+	Verified    bool
+	CreatedAt   time.Time
+}
+
+// The state of the CNAME verification of a changelog's custom domain.
+type DomainVerification struct {
+	ChangelogID ChangelogID
+	Domain      Domain
+	// Host the CNAME record of the domain must point to.
+	CNAMETarget   string
+	Verified      bool
+	VerifiedAt    time.Time
+	LastCheckedAt time.Time
+	// Error of the last check, empty if it succeeded or the domain wasn't checked yet.
+	CheckError string
+}
+
+// A single view of a public changelog page.
+type AccessLogEntry struct {
+	ID          string
+	WorkspaceID WorkspaceID
+	ChangelogID ChangelogID
+	// Anonymized identifier of the visitor, must not contain the ip address in plain text.
+	VisitorHash string
+	UserAgent   string
+	Referer     string
+	// Defaults to the current time if zero.
+	AccessedAt time.Time
+}
+
+// Links a workspace to its customer at the payment provider, e.g. Stripe or Paddle.
+type BillingInfo struct {
+	CustomerID string
+	// Empty if the workspace has no subscription.
+	SubscriptionID     string
+	SubscriptionStatus string
+	CurrentPeriodEnd   time.Time
 }
 
 type WorkspaceChangelogCount struct {
@@ -78,25 +228,308 @@ type WorkspaceChangelogCount struct {
 type Store interface {
 	GetChangelog(context.Context, WorkspaceID, ChangelogID) (Changelog, error)
 	GetChangelogByDomainOrSubdomain(ctx context.Context, domain Domain, subdomain Subdomain) (Changelog, error)
+	GetChangelogBySlug(ctx context.Context, wID WorkspaceID, slug string) (Changelog, error)
+	// Returns the changelog with the subdomain, or the changelog with the slug if no changelog has the subdomain.
+	GetChangelogBySubdomainOrSlug(ctx context.Context, subdomain Subdomain, slug string) (Changelog, error)
+	// Returns only the branding of the changelog, without loading its source.
+	GetChangelogBrandKit(ctx context.Context, domain Domain, subdomain Subdomain) (BrandKit, error)
+	// Resolves the url of a changelog page to the data of its oEmbed response.
+	GetChangelogOEmbedData(ctx context.Context, url string) (OEmbedResponse, error)
+	// Returns the canonical url of the changelog, its custom domain if it has one, else its subdomain.
+	GetChangelogPublicURL(context.Context, WorkspaceID, ChangelogID) (string, error)
+	// Returns the urls to purge from the CDN after the changelog changed.
+	// entryID is optional, if set the permalink of the entry is included.
+	GetCDNPurgeList(ctx context.Context, wID WorkspaceID, cID ChangelogID, entryID string) ([]string, error)
+	AddCustomDomain(ctx context.Context, wID WorkspaceID, cID ChangelogID, domain string) (CustomDomain, error)
+	RemoveCustomDomain(ctx context.Context, wID WorkspaceID, cID ChangelogID, domainID string) error
+	// Returns the custom domains of the changelog, in the order they were added.
+	ListCustomDomains(context.Context, WorkspaceID, ChangelogID) ([]CustomDomain, error)
+	// Marks the custom domain as verified, only verified custom domains serve the changelog.
+	SetCustomDomainVerified(ctx context.Context, wID WorkspaceID, cID ChangelogID, domainID string, verified bool) error
+	GetChangelogByCustomDomain(ctx context.Context, domain Domain) (Changelog, error)
+	// Returns the changelogs of the workspace ordered by their sort order, newest first if it's equal.
 	ListChangelogs(context.Context, WorkspaceID) ([]Changelog, error)
+	// Returns the changelogs in the same order as cIDs.
+	// Changelogs which don't exist in the workspace are returned as nil.
+	BulkGetChangelogs(ctx context.Context, wID WorkspaceID, cIDs []ChangelogID) ([]*Changelog, error)
+	// Starts the verification of the changelog's custom domain, resetting the result of previous checks.
+	StartDomainVerification(ctx context.Context, wID WorkspaceID, cID ChangelogID) error
+	// Records the result of a CNAME check, err is the reason the check failed.
+	// Errors if the domain of the changelog changed since the verification was started.
+	RecordDomainVerification(ctx context.Context, wID WorkspaceID, cID ChangelogID, verified bool, err error) error
+	GetDomainVerificationStatus(ctx context.Context, wID WorkspaceID, cID ChangelogID) (DomainVerification, error)
+	// Overrides the robots.txt of the changelog, an empty content restores the default.
+	SetChangelogRobotsTxt(ctx context.Context, wID WorkspaceID, cID ChangelogID, content string) error
+	// Returns the robots.txt of the changelog or DefaultRobotsTxt if it wasn't overridden.
+	GetChangelogRobotsTxt(ctx context.Context, domain Domain, subdomain Subdomain) (string, error)
+	// Overrides the url search engines should index the changelog under, an empty url restores the default.
+	SetCanonicalURL(ctx context.Context, wID WorkspaceID, cID ChangelogID, canonicalURL string) error
+	// Returns the canonical url of the changelog, if it wasn't overridden the custom domain is preferred over the subdomain.
+	GetCanonicalURL(ctx context.Context, domain Domain, subdomain Subdomain) (string, error)
+	// Overrides a ui text of the changelog, e.g. the "Read more" label under the key entry.read_more.
+	SetI18nString(ctx context.Context, wID WorkspaceID, cID ChangelogID, key, value string) error
+	// Returns the overridden ui texts of the changelog by their key.
+	GetI18nStrings(ctx context.Context, wID WorkspaceID, cID ChangelogID) (map[string]string, error)
+	// Restores the default of the ui text.
+	DeleteI18nString(ctx context.Context, wID WorkspaceID, cID ChangelogID, key string) error
+	// Returns the theme of the changelog, an empty Theme if it was never set.
+	GetChangelogTheme(ctx context.Context, wID WorkspaceID, cID ChangelogID) (Theme, error)
+	// Replaces the theme of the changelog, colors must be hex colors.
+	SetChangelogTheme(ctx context.Context, wID WorkspaceID, cID ChangelogID, theme Theme) error
+	// Replaces the social links of the changelog, every link must be a https url.
+	SetSocialLinks(ctx context.Context, wID WorkspaceID, cID ChangelogID, links SocialLinks) error
+	SetChangelogSortOrder(ctx context.Context, wID WorkspaceID, cID ChangelogID, order int) error
+	// Sets the sort order of multiple changelogs at once, fails without changes if any changelog doesn't exist.
+	BulkSetChangelogSortOrder(ctx context.Context, wID WorkspaceID, orders map[ChangelogID]int) error
+	// Returns the changelogs of the workspace which have no content source connected.
+	// Entries are only loaded from the source, so these changelogs are empty.
+	ListChangelogsWithoutSource(context.Context, WorkspaceID) ([]Changelog, error)
+	// Returns the changelogs of all workspaces whose GitHub source uses the app installation, with their GHSource.
+	ListChangelogsByInstallationID(ctx context.Context, installationID int64) ([]Changelog, error)
+	// Returns the unprotected changelogs with analytics enabled across all workspaces, newest first.
+	// Pages start at 1. The second return value is the total number of public changelogs.
+	ListPublicChangelogs(ctx context.Context, page, pageSize int) ([]Changelog, int64, error)
+	CountPublicChangelogs(context.Context) (int64, error)
+	// Returns the changelogs with analytics enabled across all workspaces, oldest first.
+	// Pages start at 1. The second return value is the total number of changelogs with analytics enabled.
+	ListChangelogsWithAnalytics(ctx context.Context, page, pageSize int) ([]Changelog, int64, error)
+	// Returns the subdomains of all changelogs, might be cached depending on the store options.
+	ListAllSubdomains(context.Context) ([]Subdomain, error)
+	ListSubdomainsByWorkspace(context.Context, WorkspaceID) ([]Subdomain, error)
 	CreateChangelog(context.Context, Changelog) (Changelog, error)
 	UpdateChangelog(context.Context, WorkspaceID, ChangelogID, UpdateChangelogArgs) (Changelog, error)
 	DeleteChangelog(context.Context, WorkspaceID, ChangelogID) error
 	SetChangelogGHSource(context.Context, WorkspaceID, ChangelogID, GHSourceID) error
 	DeleteChangelogSource(context.Context, WorkspaceID, ChangelogID) error
+	// Downloads the favicon at faviconURL in the background, stores it as changelog asset and points the favicon of the changelog to it.
+	// Only fails if the url is invalid or the changelog doesn't exist, errors of the download are logged.
+	SetChangelogFaviconFromURL(ctx context.Context, wID WorkspaceID, cID ChangelogID, faviconURL string) error
+	GetChangelogAsset(ctx context.Context, assetID string) (ChangelogAsset, error)
+	// Stores the generated open graph image of the changelog, must be smaller than 2 MB.
+	SetChangelogOGImage(ctx context.Context, wID WorkspaceID, cID ChangelogID, image []byte) error
+	// Returns the open graph image of the changelog, its ETag and when it was generated.
+	GetChangelogOGImage(ctx context.Context, wID WorkspaceID, cID ChangelogID) ([]byte, string, time.Time, error)
+	// Returns the SHA-256 hash of the content last synced from the source of the changelog, empty if it was never synced.
+	GetContentHash(ctx context.Context, wID WorkspaceID, cID ChangelogID) (string, error)
+	// Stores the hex encoded SHA-256 hash of the content synced from the source of the changelog.
+	SetContentHash(ctx context.Context, wID WorkspaceID, cID ChangelogID, hash string) error
+	// Checks whether the changelog has everything search engines and social media previews rely on.
+	GetChangelogSEOScore(context.Context, WorkspaceID, ChangelogID) (SEOScore, error)
+	// Creates a random token for the changelog, only its hash is stored so it can't be retrieved again.
+	CreateChangelogToken(context.Context, WorkspaceID, ChangelogID) (ChangelogToken, error)
+	GetChangelogByToken(ctx context.Context, token string) (Changelog, error)
+	RevokeChangelogToken(context.Context, WorkspaceID, ChangelogToken) error
+	// Creates a secret token which grants access to the RSS feed of a protected changelog.
+	// Only its hash is stored, the token doesn't expire until it's revoked.
+	CreateRSSToken(context.Context, WorkspaceID, ChangelogID) (string, error)
+	GetChangelogByRSSToken(ctx context.Context, token string) (Changelog, error)
+	RevokeRSSToken(ctx context.Context, wID WorkspaceID, cID ChangelogID, token string) error
+	// Creates a secret token which grants access to the changelog before it's published, expires after a week.
+	// Only its hash is stored.
+	CreatePreviewToken(context.Context, WorkspaceID, ChangelogID) (string, error)
+	// Returns errs.ErrNotFound if the token is unknown or expired.
+	GetChangelogByPreviewToken(ctx context.Context, token string) (Changelog, error)
+	RevokePreviewToken(ctx context.Context, wID WorkspaceID, cID ChangelogID, token string) error
+	// Stores a copy of the current state of the changelog.
+	SnapshotChangelog(context.Context, WorkspaceID, ChangelogID) error
+	// Returns at most limit snapshots of the changelog, newest first.
+	// A limit <= 0 returns the 20 newest, the limit is capped at 100.
+	ListSnapshots(ctx context.Context, wID WorkspaceID, cID ChangelogID, limit int) ([]ChangelogSnapshot, error)
+	// Restores the branding of the changelog from the snapshot and returns the updated changelog.
+	RestoreSnapshot(ctx context.Context, wID WorkspaceID, cID ChangelogID, snapshotID string) (Changelog, error)
+	// Returns the fields which differ between changelog aID and bID, both need to belong to the workspace.
+	DiffChangelogs(ctx context.Context, wID WorkspaceID, aID, bID ChangelogID) (ChangelogDiff, error)
+	RecordSearchQuery(ctx context.Context, wID WorkspaceID, cID ChangelogID, query string, resultCount int) error
+	// Returns at most limit of the most frequent search queries of the changelog between from and to.
+	GetTopSearchQueries(ctx context.Context, wID WorkspaceID, cID ChangelogID, limit int, from, to time.Time) ([]SearchQueryStat, error)
+	AppendAccessLog(ctx context.Context, log AccessLogEntry) error
+	// Returns the page views of the changelog between from and to, newest first. Pages start at 1.
+	ListAccessLog(ctx context.Context, wID WorkspaceID, cID ChangelogID, from, to time.Time, page, pageSize int) ([]AccessLogEntry, error)
+	// Deletes all page views before the given time and returns the number of deleted views.
+	PurgeAccessLog(ctx context.Context, before time.Time) (int64, error)
+	// Returns at most limit hostnames which referred the most visitors to the changelog between from and to.
+	GetTrafficSources(ctx context.Context, wID WorkspaceID, cID ChangelogID, from, to time.Time, limit int) ([]TrafficSource, error)
+	// Returns the page views of the changelog between from and to per day of the week in UTC, index 0 is Sunday.
+	GetHeatmapByDayOfWeek(ctx context.Context, wID WorkspaceID, cID ChangelogID, from, to time.Time) ([7]int64, error)
+	// Returns the page views of the changelog between from and to per hour of the day, index 0 is midnight UTC.
+	GetHeatmapByHour(ctx context.Context, wID WorkspaceID, cID ChangelogID, from, to time.Time) ([24]int64, error)
+	// Returns the numbers shown on the dashboard of the workspace in a single query.
+	GetWorkspaceDashboardSummary(context.Context, WorkspaceID) (DashboardSummary, error)
+	// Returns the page views of all changelogs of the workspace between from and to.
+	GetWorkspaceAnalytics(ctx context.Context, wID WorkspaceID, from, to time.Time) (WorkspaceAnalytics, error)
+
+	// Announcements
+	CreateAnnouncement(context.Context, Announcement) (Announcement, error)
+	DeleteAnnouncement(ctx context.Context, wID WorkspaceID, cID ChangelogID, announcementID string) error
+	// Returns the announcements of the changelog which are shown at the given time.
+	ListActiveAnnouncements(ctx context.Context, cID ChangelogID, at time.Time) ([]Announcement, error)
+	ListAllAnnouncements(context.Context, WorkspaceID, ChangelogID) ([]Announcement, error)
+
+	// Webhooks
+	// Creates the endpoint, a signing secret is generated if none is set.
+	CreateWebhookEndpoint(context.Context, WebhookEndpoint) (WebhookEndpoint, error)
+	// Updates the url, events and active state of the endpoint, the secret is only replaced if it's set.
+	UpdateWebhookEndpoint(context.Context, WebhookEndpoint) (WebhookEndpoint, error)
+	DeleteWebhookEndpoint(ctx context.Context, wID WorkspaceID, endpointID string) error
+	// Returns the endpoints of the changelog, in the order they were created.
+	ListWebhookEndpoints(context.Context, WorkspaceID, ChangelogID) ([]WebhookEndpoint, error)
+	GetWebhookEndpoint(ctx context.Context, wID WorkspaceID, endpointID string) (WebhookEndpoint, error)
+	RecordWebhookDelivery(ctx context.Context, delivery WebhookDelivery) error
+	// Returns the deliveries of the endpoint, newest first. Pages start at 1.
+	ListWebhookDeliveries(ctx context.Context, wID WorkspaceID, endpointID string, page, pageSize int) ([]WebhookDelivery, error)
+	// Returns at most limit failed deliveries of the active endpoint which should be sent again, oldest first.
+	// A failed delivery is no longer returned once the same event was recorded again, whether it succeeded or not.
+	RetryFailedDeliveries(ctx context.Context, endpointID string, limit int) ([]WebhookDelivery, error)
 
 	// Workspace
 	GetWorkspace(context.Context, WorkspaceID) (Workspace, error)
+	// Returns the workspace the changelog belongs to, without its token.
+	// Errors if the changelog doesn't belong to the workspace.
+	GetWorkspaceByChangelog(context.Context, WorkspaceID, ChangelogID) (Workspace, error)
+	// Deprecated: use GetOrCreateWorkspace to provision workspaces, SaveWorkspace remains for renaming them.
 	SaveWorkspace(context.Context, Workspace) (Workspace, error)
+	// Returns the workspace with the given name and creates it with a new token if it doesn't exist yet.
+	// Workspace names are unique.
+	// The returned bool is true if the workspace was created.
+	GetOrCreateWorkspace(ctx context.Context, name string) (Workspace, bool, error)
+	// Returns the workspace the token belongs to and marks the token as used.
 	GetWorkspaceIDByToken(ctx context.Context, token string) (WorkspaceID, error)
+	// Returns the tokens of the workspace, newest first.
+	ListAPIKeys(ctx context.Context, wID WorkspaceID) ([]APIKey, error)
+	// Deletes the token with the id of the APIKey, requests made with it are rejected afterwards.
+	RevokeAPIKey(ctx context.Context, wID WorkspaceID, id string) error
+	// Restricts the token of the workspace to the given scopes, see AllTokenScopes.
+	SetTokenScopes(ctx context.Context, wID WorkspaceID, tokenKey string, scopes []string) error
+	// Returns the scopes granted to the token, all scopes if it was never restricted.
+	GetTokenScopes(ctx context.Context, token string) ([]string, error)
+	RecordAPIRequest(ctx context.Context, wID WorkspaceID, tokenKey, endpoint string) error
+	// Returns the number of requests made with the tokens of the workspace between from and to.
+	GetAPIUsage(ctx context.Context, wID WorkspaceID, from, to time.Time) (APIUsage, error)
+	// Deletes all recorded requests made before the given time.
+	PurgeAPIRequests(ctx context.Context, before time.Time) error
+	// Returns the most recently created token of the workspace.
+	GetWorkspaceToken(context.Context, WorkspaceID) (Token, error)
 	DeleteWorkspace(context.Context, WorkspaceID) error
+	// Returns the number of resources the workspace uses, to be compared against the PlanLimits of its plan.
+	GetWorkspaceQuotaUsage(context.Context, WorkspaceID) (QuotaUsage, error)
+	GetWorkspaceBillingInfo(context.Context, WorkspaceID) (BillingInfo, error)
+	UpdateWorkspaceBillingInfo(ctx context.Context, wID WorkspaceID, info BillingInfo) error
+	RecordEmailDelivery(ctx context.Context, d EmailDelivery) error
+	// Returns the delivery with the id, e.g. to check if a retried job already sent its email.
+	GetEmailDelivery(ctx context.Context, wID WorkspaceID, id string) (EmailDelivery, error)
+	// Returns the emails sent for the changelog, newest first. Pages start at 1.
+	ListEmailDeliveries(ctx context.Context, wID WorkspaceID, cID ChangelogID, page, pageSize int) ([]EmailDelivery, error)
+	// Returns the secret all outgoing webhooks of the workspace are signed with.
+	GetWorkspaceWebhookSecret(context.Context, WorkspaceID) (string, error)
+	// Replaces the webhook secret of the workspace with a new random one and returns it.
+	RotateWorkspaceWebhookSecret(context.Context, WorkspaceID) (string, error)
+	GetEmailSettings(context.Context, WorkspaceID) (EmailSettings, error)
+	// Creates or replaces the email settings of the workspace, the smtp password is encrypted before it is stored.
+	SetEmailSettings(ctx context.Context, wID WorkspaceID, settings EmailSettings) error
+	GetSSOConfig(context.Context, WorkspaceID) (SSOConfig, error)
+	// Creates or replaces the sso config of the workspace, the client secret is encrypted before it is stored.
+	SetSSOConfig(ctx context.Context, wID WorkspaceID, cfg SSOConfig) error
+	DeleteSSOConfig(context.Context, WorkspaceID) error
+	// Returns the workspaces which have at least one token that expired before the given time.
+	ListWorkspacesWithExpiredTokens(ctx context.Context, before time.Time) ([]WorkspaceID, error)
+	// Deletes all tokens that expired before the given time and returns the number of deleted tokens.
+	PurgeExpiredTokens(ctx context.Context, before time.Time) (int64, error)
+	// Creates an invite to the workspace which expires after ttl and returns its token, only its hash is stored.
+	// role is one of MemberEditor or MemberViewer.
+	CreateInvite(ctx context.Context, wID WorkspaceID, email, role string, ttl time.Duration) (string, error)
+	// Marks the invite as accepted and returns the workspace it belongs to.
+	// Returns errs.ErrUnauthorized if the invite is unknown, expired or already accepted.
+	AcceptInvite(ctx context.Context, token string) (WorkspaceID, error)
+	RevokeInvite(ctx context.Context, wID WorkspaceID, token string) error
+	AddWorkspaceMember(ctx context.Context, wID WorkspaceID, userID string, role MemberRole) (WorkspaceMember, error)
+	RemoveWorkspaceMember(ctx context.Context, wID WorkspaceID, userID string) error
+	GetWorkspaceMember(ctx context.Context, wID WorkspaceID, userID string) (WorkspaceMember, error)
+	// Returns the members of the workspace, in the order they joined.
+	ListWorkspaceMembers(context.Context, WorkspaceID) ([]WorkspaceMember, error)
+	UpdateMemberRole(ctx context.Context, wID WorkspaceID, userID string, role MemberRole) error
 
 	// admin only methods
 	ListWorkspacesChangelogCount(context.Context) ([]WorkspaceChangelogCount, error)
+	// Returns the number of created workspaces and changelogs per period between from and to.
+	// granularity is one of "day", "week" or "month", periods without any creations are omitted.
+	GetWorkspaceGrowthStats(ctx context.Context, granularity string, from, to time.Time) ([]GrowthDataPoint, error)
+	// Returns the number of created workspaces per period between the from and to dates (YYYY-MM-DD), both inclusive.
+	// granularity is one of "day", "month" or "quarter", periods without any creations are omitted.
+	GetWorkspaceCreationTimeline(ctx context.Context, granularity, from, to string) ([]TimelinePoint, error)
+	// Returns at most limit workspaces whose name starts with namePrefix, ignoring case.
+	SearchWorkspaces(ctx context.Context, namePrefix string, limit int) ([]Workspace, error)
 
 	// Source
 	CreateGHSource(context.Context, GHSource) (GHSource, error)
 	GetGHSource(context.Context, WorkspaceID, GHSourceID) (GHSource, error)
+	// Returns the github source linked to the changelog, errs.ErrNotFound if it has none.
+	GetGHSourceByChangelog(ctx context.Context, wID WorkspaceID, cID ChangelogID) (GHSource, error)
 	ListGHSources(context.Context, WorkspaceID) ([]GHSource, error)
 	DeleteGHSource(context.Context, WorkspaceID, GHSourceID) error
+	// Returns at most limit sources whose fetch interval elapsed, least recently fetched first.
+	ListGHSourcesNeedingRefresh(ctx context.Context, limit int) ([]GHSource, error)
+	// Returns the sources of all workspaces which aren't connected to any changelog.
+	ListOrphanedGHSources(context.Context) ([]GHSource, error)
+	UpdateGHSourceLastFetched(context.Context, WorkspaceID, GHSourceID) error
+	RecordGHSourceFailure(ctx context.Context, wID WorkspaceID, ghID GHSourceID, err error) error
+	RecordGHSourceSuccess(ctx context.Context, wID WorkspaceID, ghID GHSourceID) error
+	GetGHSourceHealth(ctx context.Context, wID WorkspaceID, ghID GHSourceID) (GHSourceHealth, error)
+	// Stores the HMAC secret used to validate the webhooks of the source, an empty secret removes it.
+	SetGHSourceWebhookSecret(ctx context.Context, wID WorkspaceID, ghID GHSourceID, secret string) error
+	GetGHSourceWebhookSecret(ctx context.Context, wID WorkspaceID, ghID GHSourceID) (string, error)
+	// Sets how often the source is refreshed, seconds must be between 60 and 86400.
+	SetGHSourcePollInterval(ctx context.Context, wID WorkspaceID, ghID GHSourceID, seconds int) error
+	AppendSyncLog(ctx context.Context, log GHSyncLog) error
+	// Returns at most limit sync logs of the source, most recent first.
+	ListSyncLogs(ctx context.Context, wID WorkspaceID, ghID GHSourceID, limit int) ([]GHSyncLog, error)
+	// Deletes the sync logs of all sources which started before the given time.
+	PurgeSyncLogs(ctx context.Context, before time.Time) (int64, error)
+	// Returns the cached file listing of the source directory and the ETag of the GitHub response it was read from.
+	GetCachedTree(ctx context.Context, wID WorkspaceID, ghID GHSourceID) ([]string, string, error)
+	SetCachedTree(ctx context.Context, wID WorkspaceID, ghID GHSourceID, files []string, etag string) error
+	// Returns the sha of every file of the source at its last sync, keyed by path.
+	GetFileCache(ctx context.Context, wID WorkspaceID, ghID GHSourceID) (map[string]string, error)
+	// Replaces the cached files of the source.
+	SetFileCache(ctx context.Context, wID WorkspaceID, ghID GHSourceID, files map[string]string) error
+	// Removes the modified and removed files of a push from the file cache of the source.
+	// Returns the added and modified paths which weren't removed, they have to be fetched and cached again.
+	ProcessGHPushPayload(ctx context.Context, wID WorkspaceID, ghID GHSourceID, addedFiles, modifiedFiles, removedFiles []string) ([]string, error)
+
+	// Labels
+	CreateLabel(ctx context.Context, wID WorkspaceID, name string) (Label, error)
+	DeleteLabel(ctx context.Context, wID WorkspaceID, name string) error
+	AttachLabelToEntry(ctx context.Context, wID WorkspaceID, cID ChangelogID, entryID string, label string) error
+	DetachLabelFromEntry(ctx context.Context, wID WorkspaceID, cID ChangelogID, entryID string, label string) error
+	ListLabelsByEntry(ctx context.Context, wID WorkspaceID, cID ChangelogID, entryID string) ([]Label, error)
+	// Moves all entries of the workspace labeled with fromLabel to toLabel, which must exist.
+	// Returns the number of entries which were labeled with fromLabel.
+	BulkUpdateEntryLabels(ctx context.Context, wID WorkspaceID, fromLabel, toLabel string) (int64, error)
+	// Returns the ids of the entries matching the labels, combined according to mode.
+	// Entries are stored in the changelog source, so callers filter the loaded release notes by the returned ids.
+	ListEntryIDsByLabels(ctx context.Context, wID WorkspaceID, cID ChangelogID, labels []string, mode LabelMatchMode) ([]string, error)
+
+	// Attachments
+	// Adds the image or video to the entry of the changelog, entryID is the id of the parsed release note.
+	AttachMediaToEntry(ctx context.Context, wID WorkspaceID, cID ChangelogID, entryID string, att Attachment) (Attachment, error)
+	DetachMedia(ctx context.Context, wID WorkspaceID, cID ChangelogID, entryID, attID string) error
+	// Returns the attachments of the entry, oldest first.
+	ListEntryAttachments(ctx context.Context, wID WorkspaceID, cID ChangelogID, entryID string) ([]Attachment, error)
+
+	// Comments
+	// Adds an internal comment to the entry of the changelog, comments are never shown publicly.
+	CreateComment(ctx context.Context, c Comment) (Comment, error)
+	UpdateComment(ctx context.Context, wID WorkspaceID, cID ChangelogID, commentID, body string) (Comment, error)
+	DeleteComment(ctx context.Context, wID WorkspaceID, cID ChangelogID, commentID string) error
+	// Marks the comment as resolved, or reopens it if resolved is false.
+	ResolveComment(ctx context.Context, wID WorkspaceID, cID ChangelogID, commentID string, resolved bool) error
+	// Returns the comments of the entry, oldest first.
+	ListComments(ctx context.Context, wID WorkspaceID, cID ChangelogID, entryID string) ([]Comment, error)
+
+	// GitHub App
+	GetInstallationIDByWorkspace(context.Context, WorkspaceID) (int64, error)
+	SetInstallationIDForWorkspace(ctx context.Context, wID WorkspaceID, installationID int64) error
+	// Returns the cached access token of the installation, errs.ErrNotFound if there is none or it's about to expire.
+	GetInstallationToken(ctx context.Context, installationID int64) (string, error)
+	// Caches the access token of the installation until expiresAt, the token is encrypted before it is stored.
+	SetInstallationToken(ctx context.Context, installationID int64, token string, expiresAt time.Time) error
 }
@@ -0,0 +1,64 @@
+package store
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/jonashiltl/openchangelog/internal/errs"
+)
+
+const (
+	max_font_family_length = 128
+	max_border_radius_px   = 64
+	max_custom_css_length  = 16 * 1024
+)
+
+// #rgb, #rgba, #rrggbb or #rrggbbaa
+var hexColorRegex = regexp.MustCompile(`^#([0-9a-fA-F]{3,4}|[0-9a-fA-F]{6}|[0-9a-fA-F]{8})$`)
+
+// font names can be quoted and separated by commas, e.g. "Inter", sans-serif
+var fontFamilyRegex = regexp.MustCompile(`^[a-zA-Z0-9 ,'"-]*$`)
+
+// Styles of a changelog on top of its ColorScheme, empty fields use the default of the theme.
+type Theme struct {
+	FontFamily      string
+	AccentColor     string
+	BackgroundColor string
+	TextColor       string
+	// nil uses the default radius.
+	BorderRadiusPx *int
+	// Added to the page after the default styles.
+	CustomCSS string
+}
+
+func (t Theme) validate() error {
+	if len(t.FontFamily) > max_font_family_length || !fontFamilyRegex.MatchString(t.FontFamily) {
+		return errs.NewBadRequest(errors.New("invalid font family"))
+	}
+
+	colors := []struct{ name, value string }{
+		{"accent", t.AccentColor},
+		{"background", t.BackgroundColor},
+		{"text", t.TextColor},
+	}
+	for _, c := range colors {
+		if c.value != "" && !hexColorRegex.MatchString(c.value) {
+			return errs.NewBadRequest(fmt.Errorf("%s color must be a hex color like #1a2b3c", c.name))
+		}
+	}
+
+	if t.BorderRadiusPx != nil && (*t.BorderRadiusPx < 0 || *t.BorderRadiusPx > max_border_radius_px) {
+		return errs.NewBadRequest(fmt.Errorf("border radius must be between 0 and %dpx", max_border_radius_px))
+	}
+
+	if len(t.CustomCSS) > max_custom_css_length {
+		return errs.NewBadRequest(fmt.Errorf("custom css can't be longer than %d characters", max_custom_css_length))
+	}
+	// the css is rendered inside a style tag, which must not be closed
+	if strings.Contains(t.CustomCSS, "<") {
+		return errs.NewBadRequest(errors.New("custom css can't contain <"))
+	}
+	return nil
+}
@@ -0,0 +1,48 @@
+package store
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidateTheme(t *testing.T) {
+	radius := func(r int) *int { return &r }
+
+	tables := []struct {
+		name  string
+		theme Theme
+		valid bool
+	}{
+		{name: "empty", theme: Theme{}, valid: true},
+		{name: "full", theme: Theme{
+			FontFamily:      `"Inter", sans-serif`,
+			AccentColor:     "#1A2b3c",
+			BackgroundColor: "#fff",
+			TextColor:       "#00000080",
+			BorderRadiusPx:  radius(0),
+			CustomCSS:       "h1 { letter-spacing: -0.02em; }",
+		}, valid: true},
+		{name: "short alpha color", theme: Theme{AccentColor: "#fffa"}, valid: true},
+		{name: "missing hash", theme: Theme{AccentColor: "1a2b3c"}, valid: false},
+		{name: "named color", theme: Theme{TextColor: "red"}, valid: false},
+		{name: "five digits", theme: Theme{BackgroundColor: "#12345"}, valid: false},
+		{name: "non hex digit", theme: Theme{AccentColor: "#12345g"}, valid: false},
+		{name: "font with semicolon", theme: Theme{FontFamily: "Inter; color: red"}, valid: false},
+		{name: "negative radius", theme: Theme{BorderRadiusPx: radius(-1)}, valid: false},
+		{name: "large radius", theme: Theme{BorderRadiusPx: radius(max_border_radius_px + 1)}, valid: false},
+		{name: "closing style tag", theme: Theme{CustomCSS: "</style><script>"}, valid: false},
+		{name: "long css", theme: Theme{CustomCSS: strings.Repeat("a", max_custom_css_length+1)}, valid: false},
+	}
+
+	for _, table := range tables {
+		t.Run(table.name, func(t *testing.T) {
+			err := table.theme.validate()
+			if table.valid && err != nil {
+				t.Errorf("expected theme to be valid, got %s", err)
+			}
+			if !table.valid && err == nil {
+				t.Errorf("expected theme to be invalid")
+			}
+		})
+	}
+}
@@ -0,0 +1,47 @@
+package store
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Converts the monthly growth stats to the workspace creation timeline of the granularity.
+// Months are merged into quarters if quarterly is set, periods without new workspaces are omitted.
+func creationTimeline(stats []GrowthDataPoint, quarterly bool) []TimelinePoint {
+	points := make([]TimelinePoint, 0, len(stats))
+	for _, s := range stats {
+		if s.NewWorkspaces == 0 {
+			continue
+		}
+
+		period := s.Period
+		if quarterly {
+			period = monthToQuarter(period)
+		}
+
+		// stats are ordered by period, so months of the same quarter are next to each other
+		if len(points) > 0 && points[len(points)-1].Period == period {
+			points[len(points)-1].Count += s.NewWorkspaces
+			continue
+		}
+		points = append(points, TimelinePoint{
+			Period: period,
+			Count:  s.NewWorkspaces,
+		})
+	}
+	return points
+}
+
+// Returns the quarter of a YYYY-MM period, e.g. 2024-05 becomes 2024-Q2.
+func monthToQuarter(period string) string {
+	year, month, ok := strings.Cut(period, "-")
+	if !ok {
+		return period
+	}
+	m, err := strconv.Atoi(month)
+	if err != nil {
+		return period
+	}
+	return fmt.Sprintf("%s-Q%d", year, (m+2)/3)
+}
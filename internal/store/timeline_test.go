@@ -0,0 +1,73 @@
+package store
+
+import (
+	"testing"
+)
+
+func TestCreationTimeline(t *testing.T) {
+	tables := []struct {
+		name      string
+		stats     []GrowthDataPoint
+		quarterly bool
+		expected  []TimelinePoint
+	}{
+		{
+			name: "monthly",
+			stats: []GrowthDataPoint{
+				{Period: "2024-01", NewWorkspaces: 2, NewChangelogs: 3},
+				{Period: "2024-02", NewWorkspaces: 1},
+			},
+			expected: []TimelinePoint{
+				{Period: "2024-01", Count: 2},
+				{Period: "2024-02", Count: 1},
+			},
+		},
+		{
+			name: "omits periods without new workspaces",
+			stats: []GrowthDataPoint{
+				{Period: "2024-01-01", NewWorkspaces: 1},
+				{Period: "2024-01-02", NewChangelogs: 4},
+				{Period: "2024-01-03", NewWorkspaces: 5},
+			},
+			expected: []TimelinePoint{
+				{Period: "2024-01-01", Count: 1},
+				{Period: "2024-01-03", Count: 5},
+			},
+		},
+		{
+			name: "quarterly",
+			stats: []GrowthDataPoint{
+				{Period: "2023-12", NewWorkspaces: 1},
+				{Period: "2024-01", NewWorkspaces: 2},
+				{Period: "2024-03", NewWorkspaces: 3},
+				{Period: "2024-04", NewChangelogs: 1},
+				{Period: "2024-05", NewWorkspaces: 4},
+			},
+			quarterly: true,
+			expected: []TimelinePoint{
+				{Period: "2023-Q4", Count: 1},
+				{Period: "2024-Q1", Count: 5},
+				{Period: "2024-Q2", Count: 4},
+			},
+		},
+		{
+			name:     "empty",
+			stats:    []GrowthDataPoint{},
+			expected: []TimelinePoint{},
+		},
+	}
+
+	for _, table := range tables {
+		t.Run(table.name, func(t *testing.T) {
+			points := creationTimeline(table.stats, table.quarterly)
+			if len(points) != len(table.expected) {
+				t.Fatalf("expected %d points but got %d: %v", len(table.expected), len(points), points)
+			}
+			for i, p := range points {
+				if p != table.expected[i] {
+					t.Errorf("expected %v to equal %v", p, table.expected[i])
+				}
+			}
+		})
+	}
+}
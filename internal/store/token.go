@@ -2,16 +2,22 @@ package store
 
 import (
 	"crypto/md5"
+	"crypto/rand"
+	"crypto/sha256"
 	"encoding/hex"
 	"errors"
 	"strings"
+	"time"
 
 	"github.com/jonashiltl/openchangelog/internal/errs"
 	"github.com/rs/xid"
 )
 
 const (
-	token_prefix = "tkn"
+	token_prefix   = "tkn"
+	api_key_prefix = "ak"
+	// how often the last used at of a token is updated at most
+	token_touch_interval = time.Minute
 )
 
 type Token string
@@ -48,3 +54,52 @@ func (k Token) String() string {
 func (k Token) IsSet() bool {
 	return string(k) != ""
 }
+
+// Hides all but the last 4 characters of the key, so it can be shown to identify the token.
+func (k Token) Masked() string {
+	if len(k) <= 8 {
+		return token_prefix + id_separator + "****"
+	}
+	return token_prefix + id_separator + "****" + string(k[len(k)-4:])
+}
+
+const (
+	changelog_token_prefix = "ctkn"
+)
+
+// A ChangelogToken grants read access to a single changelog.
+// Unlike the workspace token it can be shared publicly, e.g. in embed widgets.
+type ChangelogToken string
+
+func NewChangelogToken() (ChangelogToken, error) {
+	secret, err := newSecretToken()
+	if err != nil {
+		return "", err
+	}
+	return ChangelogToken(changelog_token_prefix + id_separator + secret), nil
+}
+
+func (k ChangelogToken) String() string {
+	return string(k)
+}
+
+// Only the hash of a changelog token is stored, the token itself is shown once on creation.
+func (k ChangelogToken) hash() string {
+	return hashSecretToken(string(k))
+}
+
+// Secret tokens are only stored as their hash, so they can't be read from the database.
+func hashSecretToken(token string) string {
+	h := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(h[:])
+}
+
+// Returns a random 32-byte hex token, e.g. used to access the RSS feed of protected changelogs.
+func newSecretToken() (string, error) {
+	b := make([]byte, 32)
+	_, err := rand.Read(b)
+	if err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
@@ -0,0 +1,28 @@
+package store
+
+import "testing"
+
+func TestTokenMasked(t *testing.T) {
+	tables := []struct {
+		key      Token
+		expected string
+	}{
+		{
+			key:      "tkn_5d41402abc4b2a76b9719d911017c592",
+			expected: "tkn_****c592",
+		},
+		{
+			key:      "tkn_ab",
+			expected: "tkn_****",
+		},
+	}
+
+	for _, table := range tables {
+		t.Run(table.key.String(), func(t *testing.T) {
+			masked := table.key.Masked()
+			if masked != table.expected {
+				t.Errorf("expected %s to equal %s", masked, table.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,112 @@
+package store
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/netip"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/jonashiltl/openchangelog/internal/errs"
+)
+
+const (
+	webhook_endpoint_prefix = "wh"
+	webhook_delivery_prefix = "whd"
+)
+
+type WebhookEvent string
+
+const (
+	WebhookEntryPublished WebhookEvent = "entry.published"
+	WebhookEntryUpdated   WebhookEvent = "entry.updated"
+)
+
+// An external url which is notified about events of a changelog.
+type WebhookEndpoint struct {
+	ID          string
+	WorkspaceID WorkspaceID
+	ChangelogID ChangelogID
+	URL         string
+	// Used to sign the payloads sent to the endpoint, generated if empty on creation.
+	Secret    string
+	Events    []WebhookEvent
+	Active    bool
+	CreatedAt time.Time
+}
+
+// A single attempt to send an event to a webhook endpoint.
+type WebhookDelivery struct {
+	ID         string
+	EndpointID string
+	EventType  WebhookEvent
+	Payload    []byte
+	// 0 if the endpoint couldn't be reached.
+	StatusCode   int
+	ResponseBody string
+	// Defaults to the current time if zero.
+	DeliveredAt time.Time
+	Duration    time.Duration
+	Success     bool
+}
+
+var errNoWebhookEndpoint = errs.NewError(errs.ErrNotFound, errors.New("webhook endpoint not found"))
+
+func (w WebhookEndpoint) validate() error {
+	u, err := url.Parse(w.URL)
+	if err != nil || u.Scheme != "https" || u.Host == "" {
+		return errs.NewBadRequest(errors.New("webhook url must be a https url"))
+	}
+	// webhooks must not be usable to reach internal services
+	host := strings.ToLower(u.Hostname())
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		return errs.NewBadRequest(errors.New("webhook url must be a public host"))
+	}
+	if ip, err := netip.ParseAddr(host); err == nil && !isPublicIP(ip) {
+		return errs.NewBadRequest(errors.New("webhook url must be a public host"))
+	}
+	if len(w.Events) == 0 {
+		return errs.NewBadRequest(errors.New("webhook must subscribe to at least one event"))
+	}
+	for _, e := range w.Events {
+		switch e {
+		case WebhookEntryPublished, WebhookEntryUpdated:
+		default:
+			return errs.NewBadRequest(fmt.Errorf("unknown webhook event %s", e))
+		}
+	}
+	return nil
+}
+
+// The secret is stored encrypted and isn't set, see sqlite.webhookEndpointToExported.
+func (w webhookEndpoint) toExported() WebhookEndpoint {
+	var events []WebhookEvent
+	// events are validated before they are stored
+	_ = json.Unmarshal([]byte(w.Events), &events)
+
+	return WebhookEndpoint{
+		ID:          w.ID,
+		WorkspaceID: WorkspaceID(w.WorkspaceID),
+		ChangelogID: ChangelogID(w.ChangelogID),
+		URL:         w.Url,
+		Events:      events,
+		Active:      w.Active == 1,
+		CreatedAt:   time.Unix(w.CreatedAt, 0),
+	}
+}
+
+func (d webhookDelivery) toExported() WebhookDelivery {
+	return WebhookDelivery{
+		ID:           d.ID,
+		EndpointID:   d.EndpointID,
+		EventType:    WebhookEvent(d.EventType),
+		Payload:      d.Payload,
+		StatusCode:   int(d.StatusCode.Int64),
+		ResponseBody: d.ResponseBody.V(),
+		DeliveredAt:  time.Unix(d.DeliveredAt, 0),
+		Duration:     time.Duration(d.DurationMs) * time.Millisecond,
+		Success:      d.Success == 1,
+	}
+}
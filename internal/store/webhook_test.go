@@ -0,0 +1,86 @@
+package store
+
+import "testing"
+
+func TestWebhookEndpointValidate(t *testing.T) {
+	tables := []struct {
+		name      string
+		endpoint  WebhookEndpoint
+		expectErr bool
+	}{
+		{
+			name: "valid",
+			endpoint: WebhookEndpoint{
+				URL:    "https://hooks.slack.com/services/T000/B000/XXXX",
+				Events: []WebhookEvent{WebhookEntryPublished, WebhookEntryUpdated},
+			},
+		},
+		{
+			name: "invalid url",
+			endpoint: WebhookEndpoint{
+				URL:    "hooks.slack.com",
+				Events: []WebhookEvent{WebhookEntryPublished},
+			},
+			expectErr: true,
+		},
+		{
+			name: "http url",
+			endpoint: WebhookEndpoint{
+				URL:    "http://hooks.slack.com/services/T000/B000/XXXX",
+				Events: []WebhookEvent{WebhookEntryPublished},
+			},
+			expectErr: true,
+		},
+		{
+			name: "loopback host",
+			endpoint: WebhookEndpoint{
+				URL:    "https://127.0.0.1/hook",
+				Events: []WebhookEvent{WebhookEntryPublished},
+			},
+			expectErr: true,
+		},
+		{
+			name: "private host",
+			endpoint: WebhookEndpoint{
+				URL:    "https://10.0.0.1/hook",
+				Events: []WebhookEvent{WebhookEntryPublished},
+			},
+			expectErr: true,
+		},
+		{
+			name: "localhost",
+			endpoint: WebhookEndpoint{
+				URL:    "https://localhost:8080/hook",
+				Events: []WebhookEvent{WebhookEntryPublished},
+			},
+			expectErr: true,
+		},
+		{
+			name: "no events",
+			endpoint: WebhookEndpoint{
+				URL: "https://discord.com/api/webhooks/1/abc",
+			},
+			expectErr: true,
+		},
+		{
+			name: "unknown event",
+			endpoint: WebhookEndpoint{
+				URL:    "https://discord.com/api/webhooks/1/abc",
+				Events: []WebhookEvent{"entry.deleted"},
+			},
+			expectErr: true,
+		},
+	}
+
+	for _, table := range tables {
+		t.Run(table.name, func(t *testing.T) {
+			err := table.endpoint.validate()
+			if table.expectErr && err == nil {
+				t.Error("expected to error but no error returned")
+			}
+			if !table.expectErr && err != nil {
+				t.Errorf("expected no error but got %s", err)
+			}
+		})
+	}
+}
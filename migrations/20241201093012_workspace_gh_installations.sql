@@ -0,0 +1,13 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS workspace_gh_installations (
+    workspace_id TEXT NOT NULL PRIMARY KEY REFERENCES workspaces(id) ON DELETE CASCADE,
+    installation_id INTEGER NOT NULL UNIQUE,
+    installed_at INTEGER NOT NULL DEFAULT (unixepoch('now'))
+) STRICT;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE workspace_gh_installations;
+-- +goose StatementEnd
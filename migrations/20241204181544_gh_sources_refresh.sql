@@ -0,0 +1,11 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE gh_sources ADD last_fetched_at INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE gh_sources ADD fetch_interval_seconds INTEGER NOT NULL DEFAULT 300;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE gh_sources DROP last_fetched_at;
+ALTER TABLE gh_sources DROP fetch_interval_seconds;
+-- +goose StatementEnd
@@ -0,0 +1,26 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS labels (
+    workspace_id TEXT NOT NULL REFERENCES workspaces(id) ON DELETE CASCADE,
+    name TEXT NOT NULL,
+    created_at INTEGER NOT NULL DEFAULT (unixepoch('now')),
+    PRIMARY KEY (workspace_id, name)
+) STRICT;
+
+-- entries are identified by the id of the parsed release note, which is only unique per changelog
+CREATE TABLE IF NOT EXISTS entry_labels (
+    workspace_id TEXT NOT NULL,
+    changelog_id TEXT NOT NULL,
+    entry_id TEXT NOT NULL,
+    label_name TEXT NOT NULL,
+    PRIMARY KEY (workspace_id, changelog_id, entry_id, label_name),
+    FOREIGN KEY (workspace_id, changelog_id) REFERENCES changelogs(workspace_id, id) ON DELETE CASCADE,
+    FOREIGN KEY (workspace_id, label_name) REFERENCES labels(workspace_id, name) ON DELETE CASCADE ON UPDATE CASCADE
+) STRICT;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE entry_labels;
+DROP TABLE labels;
+-- +goose StatementEnd
@@ -0,0 +1,21 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS changelog_assets (
+    id TEXT NOT NULL PRIMARY KEY,
+    workspace_id TEXT NOT NULL,
+    changelog_id TEXT NOT NULL,
+    asset_type TEXT NOT NULL,
+    content_type TEXT NOT NULL,
+    data BLOB NOT NULL,
+    created_at INTEGER NOT NULL DEFAULT (unixepoch('now')),
+    FOREIGN KEY (workspace_id, changelog_id) REFERENCES changelogs(workspace_id, id) ON DELETE CASCADE
+) STRICT;
+
+ALTER TABLE changelogs ADD favicon_src TEXT;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE changelogs DROP favicon_src;
+DROP TABLE changelog_assets;
+-- +goose StatementEnd
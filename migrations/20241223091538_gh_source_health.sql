@@ -0,0 +1,17 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS gh_source_health (
+    workspace_id TEXT NOT NULL,
+    gh_source_id TEXT NOT NULL,
+    last_error TEXT,
+    consecutive_failures INTEGER NOT NULL DEFAULT 0,
+    last_checked_at INTEGER NOT NULL,
+    PRIMARY KEY (workspace_id, gh_source_id),
+    FOREIGN KEY (workspace_id, gh_source_id) REFERENCES gh_sources(workspace_id, id) ON DELETE CASCADE
+) STRICT;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE gh_source_health;
+-- +goose StatementEnd
@@ -0,0 +1,19 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS changelog_announcements (
+    id TEXT PRIMARY KEY,
+    workspace_id TEXT NOT NULL,
+    changelog_id TEXT NOT NULL,
+    message TEXT NOT NULL,
+    cta_url TEXT,
+    starts_at INTEGER NOT NULL,
+    ends_at INTEGER NOT NULL,
+    created_at INTEGER NOT NULL DEFAULT (unixepoch('now')),
+    FOREIGN KEY (workspace_id, changelog_id) REFERENCES changelogs(workspace_id, id) ON DELETE CASCADE
+) STRICT;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE changelog_announcements;
+-- +goose StatementEnd
@@ -0,0 +1,21 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS gh_sync_logs (
+    id TEXT PRIMARY KEY,
+    workspace_id TEXT NOT NULL,
+    gh_source_id TEXT NOT NULL,
+    started_at INTEGER NOT NULL,
+    finished_at INTEGER NOT NULL,
+    files_found INTEGER NOT NULL DEFAULT 0,
+    files_changed INTEGER NOT NULL DEFAULT 0,
+    error TEXT,
+    FOREIGN KEY (workspace_id, gh_source_id) REFERENCES gh_sources(workspace_id, id) ON DELETE CASCADE
+) STRICT;
+
+CREATE INDEX IF NOT EXISTS gh_sync_logs_source_idx ON gh_sync_logs (workspace_id, gh_source_id, started_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE gh_sync_logs;
+-- +goose StatementEnd
@@ -0,0 +1,13 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE workspaces ADD created_at INTEGER NOT NULL DEFAULT 0;
+-- approximate the creation time of existing workspaces with their first changelog
+UPDATE workspaces SET created_at = COALESCE((
+    SELECT MIN(c.created_at) FROM changelogs c WHERE c.workspace_id = workspaces.id
+), 0);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE workspaces DROP created_at;
+-- +goose StatementEnd
@@ -0,0 +1,12 @@
+-- +goose Up
+-- +goose StatementBegin
+-- the private key is encrypted by the server before it's stored
+ALTER TABLE gh_sources ADD private_key_id TEXT;
+ALTER TABLE gh_sources ADD private_key_blob BLOB;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE gh_sources DROP private_key_id;
+ALTER TABLE gh_sources DROP private_key_blob;
+-- +goose StatementEnd
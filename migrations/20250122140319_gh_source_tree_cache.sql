@@ -0,0 +1,17 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS gh_source_tree_cache (
+    workspace_id TEXT NOT NULL,
+    gh_source_id TEXT NOT NULL,
+    tree_json BLOB NOT NULL,
+    cached_at INTEGER NOT NULL,
+    etag TEXT NOT NULL,
+    PRIMARY KEY (workspace_id, gh_source_id),
+    FOREIGN KEY (workspace_id, gh_source_id) REFERENCES gh_sources(workspace_id, id) ON DELETE CASCADE
+) STRICT;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE gh_source_tree_cache;
+-- +goose StatementEnd
@@ -0,0 +1,10 @@
+-- +goose Up
+-- +goose StatementBegin
+-- the secret is encrypted by the server before it's stored
+ALTER TABLE gh_sources ADD webhook_secret TEXT;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE gh_sources DROP webhook_secret;
+-- +goose StatementEnd
@@ -0,0 +1,10 @@
+-- +goose Up
+-- +goose StatementBegin
+-- json encoded SocialLinks
+ALTER TABLE changelogs ADD social_links TEXT;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE changelogs DROP social_links;
+-- +goose StatementEnd
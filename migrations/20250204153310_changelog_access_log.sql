@@ -0,0 +1,22 @@
+-- +goose Up
+-- +goose StatementBegin
+-- visitor_hash is a salted hash of the visitor, the ip address itself is never stored
+CREATE TABLE IF NOT EXISTS changelog_access_log (
+    id TEXT PRIMARY KEY,
+    workspace_id TEXT NOT NULL,
+    changelog_id TEXT NOT NULL,
+    visitor_hash TEXT NOT NULL,
+    user_agent TEXT,
+    referer TEXT,
+    accessed_at INTEGER NOT NULL,
+    FOREIGN KEY (workspace_id, changelog_id) REFERENCES changelogs(workspace_id, id) ON DELETE CASCADE
+) STRICT;
+
+CREATE INDEX IF NOT EXISTS changelog_access_log_changelog_idx ON changelog_access_log (workspace_id, changelog_id, accessed_at);
+CREATE INDEX IF NOT EXISTS changelog_access_log_accessed_at_idx ON changelog_access_log (accessed_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE changelog_access_log;
+-- +goose StatementEnd
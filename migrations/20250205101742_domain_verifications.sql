@@ -0,0 +1,19 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS domain_verifications (
+    workspace_id TEXT NOT NULL,
+    changelog_id TEXT NOT NULL,
+    domain TEXT NOT NULL,
+    cname_target TEXT NOT NULL,
+    verified_at INTEGER,
+    last_checked_at INTEGER,
+    check_error TEXT,
+    PRIMARY KEY (workspace_id, changelog_id),
+    FOREIGN KEY (workspace_id, changelog_id) REFERENCES changelogs(workspace_id, id) ON DELETE CASCADE
+) STRICT;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE domain_verifications;
+-- +goose StatementEnd
@@ -0,0 +1,17 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS changelog_og_images (
+    workspace_id TEXT NOT NULL,
+    changelog_id TEXT NOT NULL,
+    image BLOB NOT NULL,
+    generated_at INTEGER NOT NULL DEFAULT (unixepoch('now')),
+    etag TEXT NOT NULL,
+    PRIMARY KEY (workspace_id, changelog_id),
+    FOREIGN KEY (workspace_id, changelog_id) REFERENCES changelogs(workspace_id, id) ON DELETE CASCADE
+) STRICT;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE changelog_og_images;
+-- +goose StatementEnd
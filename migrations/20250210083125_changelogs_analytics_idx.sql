@@ -0,0 +1,9 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE INDEX IF NOT EXISTS changelogs_analytics_idx ON changelogs (created_at, id) WHERE analytics = 1;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX changelogs_analytics_idx;
+-- +goose StatementEnd
@@ -0,0 +1,10 @@
+-- +goose Up
+-- +goose StatementBegin
+-- null serves the default robots.txt
+ALTER TABLE changelogs ADD robots_txt TEXT;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE changelogs DROP robots_txt;
+-- +goose StatementEnd
@@ -0,0 +1,10 @@
+-- +goose Up
+-- +goose StatementBegin
+-- json array of the scopes granted to the token, null grants all scopes
+ALTER TABLE tokens ADD scopes TEXT;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE tokens DROP scopes;
+-- +goose StatementEnd
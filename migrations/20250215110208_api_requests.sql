@@ -0,0 +1,19 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS api_requests (
+    workspace_id TEXT NOT NULL REFERENCES workspaces(id) ON DELETE CASCADE,
+    -- requests of revoked tokens still count towards the workspace usage
+    token_key TEXT REFERENCES tokens(key) ON DELETE SET NULL,
+    endpoint TEXT NOT NULL,
+    requested_at INTEGER NOT NULL
+) STRICT;
+
+CREATE INDEX IF NOT EXISTS api_requests_workspace_idx ON api_requests (workspace_id, requested_at);
+CREATE INDEX IF NOT EXISTS api_requests_token_idx ON api_requests (token_key);
+CREATE INDEX IF NOT EXISTS api_requests_requested_at_idx ON api_requests (requested_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE api_requests;
+-- +goose StatementEnd
@@ -0,0 +1,25 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS changelog_custom_domains (
+    id TEXT PRIMARY KEY,
+    workspace_id TEXT NOT NULL,
+    changelog_id TEXT NOT NULL,
+    domain TEXT NOT NULL UNIQUE,
+    verified INTEGER NOT NULL DEFAULT 0,
+    created_at INTEGER NOT NULL DEFAULT (unixepoch('now')),
+    FOREIGN KEY (workspace_id, changelog_id) REFERENCES changelogs(workspace_id, id) ON DELETE CASCADE
+) STRICT;
+
+CREATE INDEX IF NOT EXISTS changelog_custom_domains_changelog_idx ON changelog_custom_domains (workspace_id, changelog_id);
+
+-- existing domains are already serving their changelog, so they count as verified
+INSERT INTO changelog_custom_domains (id, workspace_id, changelog_id, domain, verified, created_at)
+SELECT 'cd_' || lower(hex(randomblob(10))), workspace_id, id, domain, 1, unixepoch('now')
+FROM changelogs
+WHERE domain IS NOT NULL AND domain != '';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE changelog_custom_domains;
+-- +goose StatementEnd
@@ -0,0 +1,16 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS gh_source_file_cache (
+    workspace_id TEXT NOT NULL,
+    gh_source_id TEXT NOT NULL,
+    path TEXT NOT NULL,
+    sha TEXT NOT NULL,
+    PRIMARY KEY (workspace_id, gh_source_id, path),
+    FOREIGN KEY (workspace_id, gh_source_id) REFERENCES gh_sources(workspace_id, id) ON DELETE CASCADE
+) STRICT;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE gh_source_file_cache;
+-- +goose StatementEnd
@@ -0,0 +1,15 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS workspace_billing (
+    workspace_id TEXT PRIMARY KEY REFERENCES workspaces(id) ON DELETE CASCADE,
+    customer_id TEXT NOT NULL,
+    subscription_id TEXT,
+    subscription_status TEXT,
+    current_period_end INTEGER
+) STRICT;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE workspace_billing;
+-- +goose StatementEnd
@@ -0,0 +1,18 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE tokens ADD label TEXT;
+ALTER TABLE tokens ADD last_used_at INTEGER;
+
+-- identifies a token without exposing its key
+ALTER TABLE tokens ADD id TEXT;
+UPDATE tokens SET id = 'ak_' || lower(hex(randomblob(10)));
+CREATE UNIQUE INDEX IF NOT EXISTS tokens_id_idx ON tokens (id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX tokens_id_idx;
+ALTER TABLE tokens DROP id;
+ALTER TABLE tokens DROP last_used_at;
+ALTER TABLE tokens DROP label;
+-- +goose StatementEnd
@@ -0,0 +1,11 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE changelogs ADD slug TEXT;
+CREATE UNIQUE INDEX IF NOT EXISTS changelogs_slug_idx ON changelogs (slug);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX changelogs_slug_idx;
+ALTER TABLE changelogs DROP slug;
+-- +goose StatementEnd
@@ -0,0 +1,14 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS gh_installation_tokens (
+    installation_id INTEGER PRIMARY KEY,
+    -- base64 encoded, encrypted with the encryption key
+    token TEXT NOT NULL,
+    expires_at INTEGER NOT NULL
+) STRICT;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE gh_installation_tokens;
+-- +goose StatementEnd
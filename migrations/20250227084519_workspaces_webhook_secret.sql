@@ -0,0 +1,10 @@
+-- +goose Up
+-- +goose StatementBegin
+-- base64 encoded, encrypted with the encryption key
+ALTER TABLE workspaces ADD webhook_secret TEXT;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE workspaces DROP webhook_secret;
+-- +goose StatementEnd
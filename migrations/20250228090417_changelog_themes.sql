@@ -0,0 +1,20 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS changelog_themes (
+    workspace_id TEXT NOT NULL,
+    changelog_id TEXT NOT NULL,
+    font_family TEXT,
+    accent_color TEXT,
+    background_color TEXT,
+    text_color TEXT,
+    border_radius_px INTEGER,
+    custom_css TEXT,
+    PRIMARY KEY (workspace_id, changelog_id),
+    FOREIGN KEY (workspace_id, changelog_id) REFERENCES changelogs(workspace_id, id) ON DELETE CASCADE
+) STRICT;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE changelog_themes;
+-- +goose StatementEnd
@@ -0,0 +1,10 @@
+-- +goose Up
+-- +goose StatementBegin
+-- null derives the canonical url from the custom domain or subdomain
+ALTER TABLE changelogs ADD canonical_url TEXT;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE changelogs DROP canonical_url;
+-- +goose StatementEnd
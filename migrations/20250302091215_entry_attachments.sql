@@ -0,0 +1,23 @@
+-- +goose Up
+-- +goose StatementBegin
+-- entries are identified by the id of the parsed release note, like in entry_labels
+CREATE TABLE IF NOT EXISTS entry_attachments (
+    id TEXT PRIMARY KEY,
+    workspace_id TEXT NOT NULL,
+    changelog_id TEXT NOT NULL,
+    entry_id TEXT NOT NULL,
+    filename TEXT NOT NULL,
+    mime_type TEXT NOT NULL,
+    size_bytes INTEGER NOT NULL,
+    url TEXT NOT NULL,
+    created_at INTEGER NOT NULL DEFAULT (unixepoch('now')),
+    FOREIGN KEY (workspace_id, changelog_id) REFERENCES changelogs(workspace_id, id) ON DELETE CASCADE
+) STRICT;
+
+CREATE INDEX IF NOT EXISTS entry_attachments_entry_idx ON entry_attachments (workspace_id, changelog_id, entry_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE entry_attachments;
+-- +goose StatementEnd
@@ -0,0 +1,23 @@
+-- +goose Up
+-- +goose StatementBegin
+-- entries are identified by the id of the parsed release note, like in entry_labels
+CREATE TABLE IF NOT EXISTS entry_comments (
+    id TEXT PRIMARY KEY,
+    workspace_id TEXT NOT NULL,
+    changelog_id TEXT NOT NULL,
+    entry_id TEXT NOT NULL,
+    author_id TEXT NOT NULL,
+    body TEXT NOT NULL,
+    created_at INTEGER NOT NULL DEFAULT (unixepoch('now')),
+    updated_at INTEGER NOT NULL DEFAULT (unixepoch('now')),
+    resolved INTEGER NOT NULL DEFAULT 0,
+    FOREIGN KEY (workspace_id, changelog_id) REFERENCES changelogs(workspace_id, id) ON DELETE CASCADE
+) STRICT;
+
+CREATE INDEX IF NOT EXISTS entry_comments_entry_idx ON entry_comments (workspace_id, changelog_id, entry_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE entry_comments;
+-- +goose StatementEnd
@@ -0,0 +1,13 @@
+-- +goose Up
+-- +goose StatementBegin
+-- workspaces are provisioned by name, so existing duplicates get the id appended before names become unique
+UPDATE workspaces SET name = name || ' ' || id
+WHERE rowid NOT IN (SELECT MIN(rowid) FROM workspaces GROUP BY name);
+
+CREATE UNIQUE INDEX IF NOT EXISTS workspaces_name_unique_idx ON workspaces (name);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX workspaces_name_unique_idx;
+-- +goose StatementEnd